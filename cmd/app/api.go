@@ -0,0 +1,95 @@
+package main
+
+import (
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
+	"louder/internal/adapters/driving/api_provider/stdlib/auditadapter"
+	"louder/internal/adapters/driving/api_provider/stdlib/authadapter"
+	"louder/internal/adapters/driving/api_provider/stdlib/countryadapter"
+	"louder/internal/adapters/driving/api_provider/stdlib/currencyadapter"
+	"louder/internal/adapters/driving/api_provider/stdlib/exportadapter"
+	"louder/internal/adapters/driving/api_provider/stdlib/messageadapter"
+	"louder/internal/adapters/driving/api_provider/stdlib/openapi"
+	"louder/internal/adapters/driving/api_provider/stdlib/personadapter"
+	"louder/internal/adapters/driving/api_provider/stdlib/randomnumberadapter"
+	"louder/internal/adapters/driving/api_provider/stdlib/ratelimit"
+	"louder/internal/core/service/auditcore"
+	"louder/internal/core/service/authcore"
+	"louder/internal/core/service/countrycore"
+	"louder/internal/core/service/currencycore"
+	"louder/internal/core/service/exportcore"
+	"louder/internal/core/service/messagecore"
+	"louder/internal/core/service/personcore"
+	"louder/internal/core/service/randomnumberscore"
+	"louder/pkg/config"
+	"time"
+)
+
+// person create/update bodies are a handful of fields
+const personMaxBodyBytes = 64 << 10
+
+const apiV1Prefix = "/api/v1"
+
+// the unversioned routes were deprecated when /api/v1 was introduced
+var legacyRoutesDeprecatedSince = time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+
+var apiInfo = openapi.Info{
+	Title:       "louder API",
+	Version:     "1.0.0",
+	Description: "People, countries, currencies and a few random things. Errors are RFC 7807 problem documents.",
+}
+
+// services holds the core services the driving adapters are built on
+type services struct {
+	randomNumber randomnumberscore.Port
+	diceRoll     randomnumberscore.Port
+	message      messagecore.MessageService
+	person       personcore.PersonService
+	country      countrycore.CountryService
+	currency     currencycore.CurrencyService
+	auth         authcore.AuthService
+	audit        auditcore.AuditService
+	export       exportcore.ExportService
+}
+
+// apiResources instantiates the driving adapters and mounts them. Everything lives under /api/v1, the bare routes the API started with are kept until their sunset date so existing clients have time to move. Each adapter declares the role its routes need.
+func apiResources(svc services, legacySunset time.Time) []stdlibapiadapter.Resource {
+	// person payloads are small so they get a tighter body limit than the global one
+	resources := []stdlibapiadapter.Resource{
+		randomnumberadapter.NewRandomNumberHandler(svc.randomNumber),
+		randomnumberadapter.NewRandomDiceHandler(svc.diceRoll),
+		messageadapter.NewMessageHandler(svc.message),
+		stdlibapiadapter.WithMiddleware(personadapter.NewPersonHandler(svc.person), stdlibapiadapter.MaxBodySize(personMaxBodyBytes)),
+		countryadapter.NewCountryHandler(svc.country),
+		currencyadapter.NewCurrencyHandler(svc.currency),
+	}
+
+	return []stdlibapiadapter.Resource{
+		stdlibapiadapter.NewGroup(apiV1Prefix, append(resources,
+			authadapter.NewAuthHandler(svc.auth),
+			auditadapter.NewAuditHandler(svc.audit),
+			exportadapter.NewExportHandler(svc.export),
+		)...),
+		stdlibapiadapter.NewGroup("", resources...).Deprecate(stdlibapiadapter.Deprecation{
+			Since:     legacyRoutesDeprecatedSince,
+			Sunset:    legacySunset,
+			Successor: apiV1Prefix,
+		}),
+	}
+}
+
+// rateLimitConfig maps the configured budgets, whose patterns are the same under /api/v1 and on the legacy routes
+func rateLimitConfig(cfg *config.AppConfig) ratelimit.Config {
+	toLimit := func(c config.RateLimitConfig) ratelimit.Limit {
+		return ratelimit.Limit{Requests: c.Requests, Per: c.Per, Burst: c.Burst}
+	}
+
+	rlc := ratelimit.Config{
+		Default:      toLimit(cfg.RateLimitDefault),
+		Prefixes:     []string{apiV1Prefix},
+		AuthFailures: toLimit(cfg.RateLimitAuth),
+	}
+	for pattern, limit := range cfg.RateLimitRoutes {
+		rlc.Budgets = append(rlc.Budgets, ratelimit.Budget{Pattern: pattern, Limit: toLimit(limit)})
+	}
+	return rlc
+}
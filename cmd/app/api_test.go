@@ -0,0 +1,54 @@
+package main
+
+import (
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
+	"louder/internal/adapters/driving/api_provider/stdlib/openapi"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+var wildcard = regexp.MustCompile(`\{[^}]*\}`)
+
+// TestEveryRouteIsDocumented fails when a route is added without its OpenAPI operation (or an operation outlives its route)
+func TestEveryRouteIsDocumented(t *testing.T) {
+	doc, err := openapi.Build(apiInfo, apiResources(services{}, time.Time{})...)
+	if err != nil {
+		t.Fatalf("OpenAPI document out of sync with the router:\n%v", err)
+	}
+
+	for path, item := range doc.Paths {
+		for method, op := range item {
+			if op.Summary == "" {
+				t.Errorf("%s %s has no summary", method, path)
+			}
+		}
+	}
+}
+
+// TestEveryRouteRequiresAuthentication fails when a route is registered without Authorize, anonymous callers must be turned away before any handler runs
+func TestEveryRouteRequiresAuthentication(t *testing.T) {
+	api := apiResources(services{}, time.Time{})
+	doc, err := openapi.Build(apiInfo, api...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the services are nil, a handler running without Authorize in front of it panics
+	router := stdlibapiadapter.Recover()(stdlibapiadapter.NewRouter(api...))
+
+	for path, item := range doc.Paths {
+		for method := range item {
+			target := wildcard.ReplaceAllString(path, "x")
+			req := httptest.NewRequest(strings.ToUpper(method), target, nil)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusUnauthorized {
+				t.Errorf("anonymous %s %s got %d, want %d", strings.ToUpper(method), path, rec.Code, http.StatusUnauthorized)
+			}
+		}
+	}
+}
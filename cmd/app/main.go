@@ -2,9 +2,13 @@ package main
 
 import (
 	"context"
-	"log"
+	"errors"
+	"log/slog"
+	geodbclient "louder/internal/adapters/driven/api/geodb_client"
 	sqlitedbadapter "louder/internal/adapters/driven/db"
 	bunadapter "louder/internal/adapters/driven/db/bun_adapter"
+	fakedata "louder/internal/adapters/driven/fake_data"
+	jwtverifier "louder/internal/adapters/driven/jwt_verifier"
 	randomgenerator "louder/internal/adapters/driven/random_generator"
 	"net/http"
 	"os"
@@ -12,25 +16,41 @@ import (
 	"syscall"
 	"time"
 
-	// sqlxadapter "louder/internal/adapters/driven/db/sqlx_adapter"
+	sqlxadapter "louder/internal/adapters/driven/db/sqlx_adapter"
 	dbdriven "louder/internal/adapters/driven/mock_db"
 	apidriving "louder/internal/adapters/driving/api_provider/stdlib"
 	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
-	"louder/internal/adapters/driving/api_provider/stdlib/messageadapter"
-	"louder/internal/adapters/driving/api_provider/stdlib/personadapter"
-	"louder/internal/adapters/driving/api_provider/stdlib/randomnumberadapter"
+	"louder/internal/adapters/driving/api_provider/stdlib/healthadapter"
+	"louder/internal/adapters/driving/api_provider/stdlib/openapi"
+	"louder/internal/adapters/driving/api_provider/stdlib/ratelimit"
+	"louder/internal/core/domain"
+	"louder/internal/core/service/auditcore"
+	"louder/internal/core/service/authcore"
+	"louder/internal/core/service/countrycore"
+	"louder/internal/core/service/currencycore"
+	"louder/internal/core/service/exportcore"
+	"louder/internal/core/service/healthcore"
 	"louder/internal/core/service/messagecore"
 	"louder/internal/core/service/personcore"
 	"louder/internal/core/service/randomnumberscore"
 
 	"louder/pkg/config"
+	"louder/pkg/logging"
+	"louder/pkg/metrics"
 )
 
 func main() {
 
 	cfg := config.LoadConfig()
+
+	// every package logs through the default logger or one derived from it, log.Printf from third party code included
+	logger := logging.New(os.Stderr, cfg.LogFormat, cfg.LogLevel)
+	slog.SetDefault(logger)
+
 	// serverAddr := os.Getenv("SERVER_ADDR")
-	log.Println("LOUDER starting")
+	logger.Info("LOUDER starting", "log_format", cfg.LogFormat, "log_level", cfg.LogLevel.String())
+
+	shutdownTracing := setupTracing(cfg, logger)
 
 	// instantiate driven adapters
 	dataRepo := dbdriven.NewMockDBMessageRepository("Message With Time")
@@ -39,12 +59,12 @@ func main() {
 	// instantiate driven adapter for sqlitedb
 	db, err := sqlitedbadapter.Init("./louder.db")
 	if err != nil {
-		log.Fatalf("error cannot init DB: %s", err)
+		fatal("cannot init DB", "err", err)
 	}
 	defer func() {
-		log.Println("closing DB connection...")
+		logger.Info("closing DB connection...")
 		if err := db.Close(); err != nil {
-			log.Printf("error closing DB: %v", err)
+			logger.Error("error closing DB", "err", err)
 		}
 	}() // this is deferred to ensure it happens on shutdown
 
@@ -54,54 +74,228 @@ func main() {
 	// Run database migrations instead of creating new one
 	err = sqlitedbadapter.RunMigrations(db, migrationsPath)
 	if err != nil {
-		log.Fatalf("error cannot run database migrations: %v", err)
+		fatal("cannot run database migrations", "err", err)
 	}
+	sqlitedbadapter.RegisterPoolMetrics(db)
+
 	// err = sqlitedbadapter.CreateSchema(db)
 	// if err != nil {
 	// 	log.Fatalf("error cannot initialise db schema: %s", err)
 	// }
 
+	// readiness checks, the DB ones are critical as no route works without it
+	migrationCheck, err := sqlitedbadapter.NewMigrationCheck(db, migrationsPath)
+	if err != nil {
+		fatal("cannot read migrations for the readiness check", "err", err)
+	}
+	healthChecks := []healthcore.Check{
+		{Name: "sqlite", Critical: true, Run: func(ctx context.Context) error { return sqlitedbadapter.Ping(ctx, db) }},
+		{Name: "migrations", Critical: true, Run: migrationCheck},
+		{Name: "queries", Critical: true, Run: sqlxadapter.CheckQueries},
+	}
+	geoAPIResilience := geodbclient.Resilience{
+		MaxAttempts:      cfg.GeoAPIMaxAttempts,
+		BaseDelay:        cfg.GeoAPIRetryBaseDelay,
+		MaxDelay:         cfg.GeoAPIRetryMaxDelay,
+		FailureThreshold: cfg.GeoAPIBreakerThreshold,
+		OpenFor:          cfg.GeoAPIBreakerCooldown,
+	}
+	// one limiter for every GeoDB client, they share the quota
+	geoAPILimiter := geodbclient.NewLimiter(cfg.GeoAPIRateLimit, cfg.GeoAPIRateBurst)
+	if cfg.GeoAPIHealthCheck {
+		// only reported, the API is needed to sync countries and nothing else. Every call counts against the quota, hence the cache
+		healthChecks = append(healthChecks, healthcore.Check{
+			Name:     "geodb",
+			Timeout:  5 * time.Second,
+			CacheFor: 5 * time.Minute,
+			Run:      geodbclient.NewReachabilityCheck(cfg.GeoAPIBaseURL, cfg.GeoAPICountryEndpoint, cfg.GeoAPIKey, geoAPIResilience, geoAPILimiter),
+		})
+	}
+	healthService := healthcore.NewHealthService(healthChecks...)
+
 	// a little silly at the moment but Creating a single person is done through Bun, the whole list of people through SQLx
 	singlePostRepo, err := bunadapter.NewBunPersonRepo(db)
 	if err != nil {
-		log.Fatalf("error cannot instantiate DB via Bun")
+		fatal("cannot instantiate DB via Bun", "err", err)
+	}
+	// countries and currencies are done via SQLx
+	countryRepo, err := sqlxadapter.NewCountryRepo(db)
+	if err != nil {
+		fatal("cannot instantiate country repo via SQLx", "err", err)
+	}
+	currencyRepo, err := sqlxadapter.NewCurrencyRepo(db)
+	if err != nil {
+		fatal("cannot instantiate currency repo via SQLx", "err", err)
 	}
+
+	apiKeyRepo, err := sqlxadapter.NewAPIKeyRepo(db)
+	if err != nil {
+		fatal("cannot instantiate API key repo via SQLx", "err", err)
+	}
+	// the repos above write the audit trail themselves, this one only reads it
+	auditRepo, err := sqlxadapter.NewAuditRepo(db)
+	if err != nil {
+		fatal("cannot instantiate audit repo via SQLx", "err", err)
+	}
+
+	// bearer tokens are only accepted once an issuer is configured, a broken key stops the server rather than locking everyone out
+	var tokenVerifier authcore.TokenVerifier
+	if len(cfg.JWTIssuers) > 0 {
+		issuers := make([]jwtverifier.Issuer, 0, len(cfg.JWTIssuers))
+		for _, iss := range cfg.JWTIssuers {
+			issuers = append(issuers, jwtverifier.Issuer{Name: iss.Name, Algorithm: iss.Algorithm, Key: iss.Key})
+		}
+		verifier, err := jwtverifier.NewVerifier(issuers, cfg.JWTAudience)
+		if err != nil {
+			fatal("invalid JWT issuer configuration", "err", err)
+		}
+		tokenVerifier = verifier
+	}
+
 	// the rest is done via SQLx
 	// personRepo, err := sqlxadapter.NewSQLxPersonRepo(db)
 	// if err != nil {
 	// 	log.Fatalf("error cannot instantiate DB via SQLx")
 	// }
 
+	// dice limits come from config, the domain makes sure they're usable
+	diceDefaultLimits, err := domain.NewDiceLimits(cfg.DiceLimits.MaxDice, cfg.DiceLimits.MaxSides)
+	if err != nil {
+		fatal("invalid default dice limits", "err", err)
+	}
+
+	diceProfileLimits := make(map[string]domain.DiceLimits, len(cfg.DiceProfileLimits))
+	for name, profile := range cfg.DiceProfileLimits {
+		limits, err := domain.NewDiceLimits(profile.MaxDice, profile.MaxSides)
+		if err != nil {
+			logger.Warn("skipping dice profile", "profile", name, "err", err)
+			continue
+		}
+		diceProfileLimits[name] = limits
+	}
+	// clients and routes get their limits by profile name, resolved here once
+	diceLimits := randomnumberscore.DiceLimitRules{
+		Default:   diceDefaultLimits,
+		Clients:   assignDiceProfiles(logger, cfg.DiceClientProfiles, diceProfileLimits),
+		Endpoints: assignDiceProfiles(logger, cfg.DiceRouteProfiles, diceProfileLimits),
+	}
+
+	// fake people generator for load testing
+	personFaker, err := fakedata.NewPersonFaker()
+	if err != nil {
+		fatal("cannot instantiate person faker", "err", err)
+	}
+
 	// instantiate core app services
 	messageService := messagecore.NewMessageService(dataRepo)
 	randomNumberService := randomnumberscore.NewRandNumberService(randomGen)
-	diceRollService := randomnumberscore.NewDiceRollService(randomGen)
+	diceRollService := randomnumberscore.NewDiceRollService(randomGen, authcore.ContextCaller{}, diceLimits)
 	// instantiate single Person get via Bun
-	singlePostService := personcore.NewPersonService(singlePostRepo)
+	singlePostService := personcore.NewPersonService(singlePostRepo, randomGen, countryRepo, personFaker, authcore.ContextCaller{})
+	countryService := countrycore.NewCountryService(countryRepo, randomGen)
+	currencyService := currencycore.NewCurrencyService(currencyRepo, randomGen)
+	authService := authcore.NewAuthService(apiKeyRepo, tokenVerifier)
+	auditService := auditcore.NewAuditService(auditRepo)
+	// a subject access export is made of what every subsystem stores about the person, each one brings its exporter
+	exportService, err := exportcore.NewExportService(singlePostRepo, authcore.ContextCaller{},
+		personcore.ProfileExporter{},
+		countrycore.NewPersonCountryExporter(countryRepo),
+		auditcore.NewPersonAuditExporter(auditRepo),
+	)
+	if err != nil {
+		fatal("cannot instantiate export service", "err", err)
+	}
 	// instantiate Person core app service
 	// personService := coreservice.NewPersonService(personRepo)
 
-	// instantiate driving adapters
-	randomNumberHandler := randomnumberadapter.NewRandomNumberHandler(randomNumberService)
-	diceRollHandler := randomnumberadapter.NewRandomDiceHandler(diceRollService)
-	messageHandler := messageadapter.NewMessageHandler(messageService)
+	// instantiate driving adapters and the router
+	api := apiResources(services{
+		randomNumber: randomNumberService,
+		diceRoll:     diceRollService,
+		message:      messageService,
+		person:       singlePostService,
+		country:      countryService,
+		currency:     currencyService,
+		auth:         authService,
+		audit:        auditService,
+		export:       exportService,
+	}, cfg.LegacyRoutesSunset)
+
+	// the OpenAPI document is built from the very same resources, a route without an entry stops the server from starting
+	spec, err := openapi.Build(apiInfo, api...)
+	if err != nil {
+		fatal("cannot build the OpenAPI document", "err", err)
+	}
+	specHandler, err := openapi.NewHandler(spec)
+	if err != nil {
+		fatal("cannot serve the OpenAPI document", "err", err)
+	}
+
+	router := stdlibapiadapter.NewRouter(append(api, specHandler)...)
 
-	// for now with only the POST user Handler
-	singlePostHandler := personadapter.NewPersonHandler(singlePostService)
-	// peopleHandler :=
+	// a bad budget is a config mistake, better found now than when the route is hammered
+	limiter, err := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), rateLimitConfig(cfg))
+	if err != nil {
+		fatal("invalid rate limits", "err", err)
+	}
 
-	// and everything else will go here
-	// var _ *coreservice.personServiceImpl = peopleService
+	// middlewares run in the order listed, each wrapping everything after it:
+	// - RequestID first so every later log line and response (timeouts and panics included) carries the ID
+	// - RealIP next so the access log and anything below know the client
+	// - Trace starts the request's span early so everything below is part of it, and tags the logger with the trace ID
+	// - AccessLog sees the final status, after Recover has turned a panic into a 500, and so does Metrics
+	// - CORS answers preflights before any real work is done, preflights carry no credentials
+	// - failed authentications are throttled per IP before Authenticate, which answers them itself
+	// - Authenticate resolves the caller, each route then checks the caller's role
+	// - the rate limiter needs the caller to pick its bucket
+	// - Compress wraps the response the timeout handler eventually writes
+	// - MaxBodySize and Timeout sit closest to the routes as they only concern the handlers themselves
+	authenticate := stdlibapiadapter.Authenticate(authService)
+	if !cfg.AuthRequired {
+		// callers without credentials are editors, the ones with credentials keep their own role
+		logger.Warn("authentication is disabled, anyone can call the API")
+		authenticate = stdlibapiadapter.Chain(authenticate, stdlibapiadapter.Anonymous(domain.AnonymousPrincipal()))
+	}
 
-	// instantiate router
-	router := stdlibapiadapter.NewRouter(randomNumberHandler, diceRollHandler, messageHandler, singlePostHandler)
+	handler := stdlibapiadapter.Chain(
+		stdlibapiadapter.RequestID(logger),
+		stdlibapiadapter.RealIP(cfg.TrustedProxies),
+		stdlibapiadapter.Trace(router),
+		stdlibapiadapter.AccessLog(),
+		stdlibapiadapter.Metrics(router),
+		stdlibapiadapter.Recover(),
+		stdlibapiadapter.CORS(stdlibapiadapter.CORSConfig{
+			AllowedOrigins: cfg.CORSAllowedOrigins,
+			AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodPatch, http.MethodDelete},
+			AllowedHeaders: []string{"Content-Type", "Authorization", stdlibapiadapter.APIKeyHeader, stdlibapiadapter.RequestIDHeader},
+			ExposedHeaders: []string{stdlibapiadapter.RequestIDHeader, "RateLimit-Policy", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After"},
+			MaxAge:         10 * time.Minute,
+		}),
+		limiter.AuthFailures(),
+		authenticate,
+		limiter.Middleware(),
+		stdlibapiadapter.Compress(),
+		stdlibapiadapter.MaxBodySize(cfg.MaxBodyBytes),
+		stdlibapiadapter.Timeout(cfg.RequestTimeout),
+	)(router)
 
-	// wrap the router in a timeout handler - every incoming request will have a 5 sec deadline
-	timeoutDuration := 5 * time.Second
-	timedHandler := http.TimeoutHandler(router, timeoutDuration, "request timed out")
+	// the probes and /metrics skip the middlewares: orchestrators and Prometheus call them every few seconds without credentials, they mustn't be rate limited or fill the access log
+	root := http.NewServeMux()
+	healthadapter.NewHealthHandler(healthService).RegisterRoutes(root)
+	root.Handle("GET /metrics", metrics.Default)
+	root.Handle("/", handler)
 
 	// gracefully shutdown
-	stdAPIServer := apidriving.NewStdAPIServer(":"+cfg.ServerPort, timedHandler)
+	stdAPIServer := apidriving.NewStdAPIServer(":"+cfg.ServerPort, root)
+
+	// soft deleted people are kept for the retention period, then the purge job removes them for good
+	purgeCtx, stopPurge := context.WithCancel(logging.WithLogger(context.Background(), logger))
+	defer stopPurge()
+	if cfg.PersonRetention > 0 && cfg.PurgeInterval > 0 {
+		go runPurge(purgeCtx, singlePostService, cfg.PersonRetention, cfg.PurgeInterval)
+	} else {
+		logger.Warn("purge of deleted people is disabled, they are kept forever", "retention", cfg.PersonRetention, "interval", cfg.PurgeInterval)
+	}
 
 	// channel to listen for OS signals
 	stopChan := make(chan os.Signal, 1)
@@ -109,8 +303,8 @@ func main() {
 
 	// we start the server in a non-blocking way (go routine)
 	go func() {
-		if err := stdAPIServer.ListenAndServe(); err != nil {
-			log.Printf("server error: %v", err)
+		if err := stdAPIServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("server error", "err", err)
 		}
 	}()
 
@@ -118,7 +312,15 @@ func main() {
 	<-stopChan
 
 	// invoke graceful shutdown
-	log.Println("shutdown signal received. starting graceful shutdown...")
+	logger.Info("shutdown signal received. starting graceful shutdown...")
+	stopPurge()
+
+	// /readyz fails from now on, requests keep being served until the load balancers have noticed
+	healthService.Drain()
+	if cfg.ShutdownDrainDelay > 0 {
+		logger.Info("draining before shutdown", "delay", cfg.ShutdownDrainDelay)
+		time.Sleep(cfg.ShutdownDrainDelay)
+	}
 
 	// this is a new context with a timeout for the graceful shutdown only
 	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 20*time.Second)
@@ -126,8 +328,31 @@ func main() {
 
 	// do the actual shutdown here
 	if err := stdAPIServer.Shutdown(shutdownCtx); err != nil {
-		log.Fatalf("Graceful shutdwon failed :(")
+		fatal("graceful shutdown failed :(", "err", err)
 	}
 
-	log.Printf("server shutdown gracefully")
+	// the last requests' spans go out before the process does
+	shutdownTracing(shutdownCtx)
+
+	logger.Info("server shutdown gracefully")
+}
+
+// assignDiceProfiles maps each key to the limits of the profile it's assigned, skipping profiles that aren't defined
+func assignDiceProfiles(logger *slog.Logger, assignments map[string]string, profiles map[string]domain.DiceLimits) map[string]domain.DiceLimits {
+	limits := make(map[string]domain.DiceLimits, len(assignments))
+	for key, profile := range assignments {
+		profileLimits, ok := profiles[profile]
+		if !ok {
+			logger.Warn("skipping dice profile assignment to an undefined profile", "key", key, "profile", profile)
+			continue
+		}
+		limits[key] = profileLimits
+	}
+	return limits
+}
+
+// fatal logs at error level and exits, slog has no Fatal of its own
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
 }
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"louder/internal/core/service/personcore"
+	"louder/pkg/logging"
+	"time"
+)
+
+// runPurge hard deletes people soft deleted more than retention ago, once at start and then every interval until ctx is done
+func runPurge(ctx context.Context, people personcore.PersonService, retention, interval time.Duration) {
+	ctx = logging.WithLogger(ctx, logging.FromContext(ctx).With("job", "purge"))
+	logger := logging.FromContext(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := people.PurgeDeletedPeople(ctx, retention)
+		switch {
+		case err != nil:
+			logger.Error("purging deleted people failed", "err", err)
+		case purged > 0:
+			logger.Info("purged deleted people", "purged", purged, "retention", retention)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"louder/pkg/buildinfo"
+	"louder/pkg/config"
+	"louder/pkg/tracing"
+	"os"
+	"strings"
+)
+
+// setupTracing installs the span exporter picked in the config, the returned function flushes and stops it
+func setupTracing(cfg *config.AppConfig, logger *slog.Logger) func(context.Context) {
+	var exporter tracing.Exporter
+	switch cfg.TracingExporter {
+	case "", "none":
+		return func(context.Context) {}
+	case "stdout":
+		exporter = tracing.NewStdoutExporter(os.Stdout)
+	case "otlp":
+		exporter = tracing.NewOTLPExporter(strings.TrimSuffix(cfg.OTLPEndpoint, "/")+"/v1/traces", cfg.OTLPHeaders)
+	default:
+		fatal("unknown tracing exporter, use none, stdout or otlp", "exporter", cfg.TracingExporter)
+	}
+
+	info := buildinfo.Read()
+	provider := tracing.NewProvider(exporter, tracing.Options{
+		Resource: []tracing.Attr{
+			tracing.String("service.name", "louder"),
+			tracing.String("service.version", info.Version),
+		},
+		SampleRatio: cfg.TracingSampleRatio,
+	})
+	tracing.SetProvider(provider)
+	logger.Info("tracing enabled", "exporter", cfg.TracingExporter, "sample_ratio", cfg.TracingSampleRatio)
+
+	return func(ctx context.Context) {
+		tracing.SetProvider(nil)
+		if err := provider.Shutdown(ctx); err != nil {
+			logger.Error("cannot flush the remaining spans", "err", err)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+// authtool manages API keys and mints keys and tokens for local use, everything happens offline against the sqlite DB.
+//
+//	go run ./cmd/authtool apikey create -name "first admin" -role admin
+//	go run ./cmd/authtool apikey list
+//	go run ./cmd/authtool apikey revoke -id <uuid>
+//	go run ./cmd/authtool keygen -alg EdDSA
+//	go run ./cmd/authtool token -alg EdDSA -key <base64 private key> -iss local -sub alice -ttl 1h
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"log/slog"
+	sqlitedbadapter "louder/internal/adapters/driven/db"
+	sqlxadapter "louder/internal/adapters/driven/db/sqlx_adapter"
+	jwtverifier "louder/internal/adapters/driven/jwt_verifier"
+	"louder/internal/core/domain"
+	"louder/internal/core/service/authcore"
+	"os"
+	"strings"
+	"time"
+)
+
+const usage = `usage:
+  authtool apikey create -name NAME [-role admin|editor|reader]
+                                       create an API key, the secret is printed once
+  authtool apikey list                 list API keys
+  authtool apikey revoke -id ID        revoke an API key
+  authtool keygen -alg HS256|EdDSA     generate a key pair (or secret) for JWT_ISSUERS
+  authtool token -alg ALG -key KEY -iss ISSUER -sub SUBJECT [-role ROLE] [-person ID] [-aud AUDIENCE] [-ttl 1h]
+                                       sign a bearer token`
+
+func main() {
+	if len(os.Args) < 2 {
+		fatalUsage()
+	}
+
+	ctx := context.Background()
+	switch os.Args[1] {
+	case "apikey":
+		if len(os.Args) < 3 {
+			fatalUsage()
+		}
+		apiKey(ctx, os.Args[2], os.Args[3:])
+	case "keygen":
+		keygen(os.Args[2:])
+	case "token":
+		token(os.Args[2:])
+	default:
+		fatalUsage()
+	}
+}
+
+func apiKey(ctx context.Context, action string, args []string) {
+	fs := flag.NewFlagSet("apikey "+action, flag.ExitOnError)
+	dbPath := fs.String("db", "./louder.db", "path to the sqlite DB file")
+	migrationsPath := fs.String("migrations", "./migrations", "path to the migration files")
+	name := fs.String("name", "", "what the key is for (create)")
+	roleName := fs.String("role", "reader", "admin, editor or reader (create)")
+	id := fs.String("id", "", "ID of the key (revoke)")
+	fs.Parse(args)
+
+	db, err := sqlitedbadapter.Init(*dbPath)
+	if err != nil {
+		fatal("cannot init DB", "err", err)
+	}
+	defer db.Close()
+
+	if err := sqlitedbadapter.RunMigrations(db, *migrationsPath); err != nil {
+		fatal("cannot run database migrations", "err", err)
+	}
+
+	keyRepo, err := sqlxadapter.NewAPIKeyRepo(db)
+	if err != nil {
+		fatal("cannot instantiate API key repo via SQLx", "err", err)
+	}
+	authService := authcore.NewAuthService(keyRepo, nil)
+
+	switch action {
+	case "create":
+		role, err := domain.ParseRole(*roleName)
+		if err != nil {
+			fatal("invalid role", "role", *roleName, "err", err)
+		}
+		key, secret, err := authService.CreateAPIKey(ctx, *name, role)
+		if err != nil {
+			fatal("cannot create API key", "err", err)
+		}
+		fmt.Printf("id:     %s\nname:   %s\nrole:   %s\nsecret: %s\n\nthe secret is not stored, keep it somewhere safe\n", key.ID(), key.Name(), key.Role(), secret)
+	case "list":
+		keys, err := authService.ListAPIKeys(ctx)
+		if err != nil {
+			fatal("cannot list API keys", "err", err)
+		}
+		for _, k := range keys {
+			status := "active"
+			if !k.Active() {
+				status = "revoked " + k.RevokedAt().Format(time.RFC3339)
+			}
+			fmt.Printf("%s  %s…  %-20s  %-6s  %s\n", k.ID(), k.Prefix(), k.Name(), k.Role(), status)
+		}
+	case "revoke":
+		if err := authService.RevokeAPIKey(ctx, domain.APIKeyID(strings.TrimSpace(*id))); err != nil {
+			fatal("cannot revoke API key", "id", *id, "err", err)
+		}
+		fmt.Println("revoked", *id)
+	default:
+		fatalUsage()
+	}
+}
+
+func keygen(args []string) {
+	fs := flag.NewFlagSet("keygen", flag.ExitOnError)
+	alg := fs.String("alg", jwtverifier.AlgEdDSA, "HS256 or EdDSA")
+	fs.Parse(args)
+
+	switch *alg {
+	case jwtverifier.AlgHS256:
+		secret := make([]byte, 32)
+		rand.Read(secret)
+		fmt.Printf("secret: %s\n\nboth the server (JWT_ISSUERS=<issuer>=HS256:<secret>) and the signer use the secret\n", base64.StdEncoding.EncodeToString(secret))
+	case jwtverifier.AlgEdDSA:
+		public, private, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			fatal("cannot generate Ed25519 key", "err", err)
+		}
+		fmt.Printf("public:  %s\nprivate: %s\n\nthe server gets the public key (JWT_ISSUERS=<issuer>=EdDSA:<public>), only the signer has the private one\n",
+			base64.StdEncoding.EncodeToString(public), base64.StdEncoding.EncodeToString(private))
+	default:
+		fatal("unsupported algorithm", "alg", *alg)
+	}
+}
+
+func token(args []string) {
+	fs := flag.NewFlagSet("token", flag.ExitOnError)
+	alg := fs.String("alg", jwtverifier.AlgEdDSA, "HS256 or EdDSA")
+	encodedKey := fs.String("key", "", "base64 HS256 secret or Ed25519 private key, as printed by keygen")
+	iss := fs.String("iss", "", "issuer, must match an entry of JWT_ISSUERS")
+	sub := fs.String("sub", "", "subject, who the token is for")
+	aud := fs.String("aud", "", "audience, must match JWT_AUDIENCE when the server sets it")
+	role := fs.String("role", "", "admin, editor or reader, the server assumes reader when missing")
+	person := fs.String("person", "", "ID of the person the token is for, lets them edit their own data")
+	ttl := fs.Duration("ttl", time.Hour, "how long the token is valid")
+	fs.Parse(args)
+
+	if *encodedKey == "" || *iss == "" || *sub == "" {
+		fatal("-key, -iss and -sub are required")
+	}
+	key, err := base64.StdEncoding.DecodeString(*encodedKey)
+	if err != nil {
+		fatal("key is not valid base64", "err", err)
+	}
+
+	now := time.Now()
+	claims := jwtverifier.Claims{
+		Issuer:    *iss,
+		Subject:   *sub,
+		ExpiresAt: now.Add(*ttl).Unix(),
+		IssuedAt:  now.Unix(),
+		Role:      *role,
+		PersonID:  *person,
+	}
+	if *aud != "" {
+		claims.Audience = jwtverifier.Audience{*aud}
+	}
+
+	signed, err := jwtverifier.Sign(claims, *alg, key)
+	if err != nil {
+		fatal("cannot sign token", "err", err)
+	}
+	fmt.Println(signed)
+}
+
+func fatalUsage() {
+	fmt.Fprintln(os.Stderr, usage)
+	os.Exit(2)
+}
+
+// fatal logs at error level and exits, slog has no Fatal of its own
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
@@ -0,0 +1,153 @@
+// geosync copies countries, currencies, and the regions and cities of a country from the GeoDB API into the DB. Every page is saved as it arrives and checkpointed, a sync that fails or gets interrupted resumes where it stopped on the next run.
+//
+//	go run ./cmd/geosync
+//	go run ./cmd/geosync -restart
+//	go run ./cmd/geosync -resource currencies
+//	go run ./cmd/geosync -resource cities -country IT
+//	go run ./cmd/geosync -resource cities -country IT -status
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	geodbclient "louder/internal/adapters/driven/api/geodb_client"
+	sqlitedbadapter "louder/internal/adapters/driven/db"
+	dbcommon "louder/internal/adapters/driven/db/dbcommon"
+	sqlxadapter "louder/internal/adapters/driven/db/sqlx_adapter"
+	"louder/internal/core/domain"
+	"louder/internal/core/service/datasync"
+	"louder/pkg/config"
+	"louder/pkg/logging"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+func main() {
+	restart := flag.Bool("restart", false, "start from the first page even if the last sync didn't finish")
+	status := flag.Bool("status", false, "print the state of the last sync and exit")
+	resourceName := flag.String("resource", "countries", "what to sync: countries, currencies, regions or cities")
+	countryFlag := flag.String("country", "", "2 letter code of the country whose regions or cities are synced")
+	dbPath := flag.String("db", "./louder.db", "path to the sqlite DB file")
+	migrationsPath := flag.String("migrations", "./migrations", "path to the migration files")
+	flag.Parse()
+
+	cfg := config.LoadConfig()
+	slog.SetDefault(logging.New(os.Stderr, cfg.LogFormat, cfg.LogLevel))
+
+	resource, country, err := parseResource(*resourceName, *countryFlag)
+	if err != nil {
+		fatal("invalid flags", "err", err)
+	}
+
+	// Ctrl+C stops the sync, the pages saved so far stay and the next run resumes after them
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	db, err := sqlitedbadapter.Init(*dbPath)
+	if err != nil {
+		fatal("cannot init DB", "err", err)
+	}
+	defer db.Close()
+
+	if err := sqlitedbadapter.RunMigrations(db, *migrationsPath); err != nil {
+		fatal("cannot run database migrations", "err", err)
+	}
+
+	countryRepo, err := sqlxadapter.NewCountryRepo(db)
+	if err != nil {
+		fatal("cannot instantiate country repo via SQLx", "err", err)
+	}
+	currencyRepo, err := sqlxadapter.NewCurrencyRepo(db)
+	if err != nil {
+		fatal("cannot instantiate currency repo via SQLx", "err", err)
+	}
+	cityRepo, err := sqlxadapter.NewCityRepo(db)
+	if err != nil {
+		fatal("cannot instantiate city repo via SQLx", "err", err)
+	}
+	jobRepo, err := sqlxadapter.NewSyncJobRepo(db)
+	if err != nil {
+		fatal("cannot instantiate sync job repo via SQLx", "err", err)
+	}
+
+	provider := geodbclient.NewProvider(cfg.GeoAPIBaseURL, cfg.GeoAPICountryEndpoint, cfg.GeoAPIKey, currencyRepo, cfg.GeoAPIPageLimit, cfg.GeoAPIPageConcurrency,
+		geodbclient.Resilience{
+			MaxAttempts:      cfg.GeoAPIMaxAttempts,
+			BaseDelay:        cfg.GeoAPIRetryBaseDelay,
+			MaxDelay:         cfg.GeoAPIRetryMaxDelay,
+			FailureThreshold: cfg.GeoAPIBreakerThreshold,
+			OpenFor:          cfg.GeoAPIBreakerCooldown,
+		},
+		geodbclient.NewLimiter(cfg.GeoAPIRateLimit, cfg.GeoAPIRateBurst),
+	)
+	synchroniser := datasync.NewDataSynchroniser(provider, countryRepo, currencyRepo, cityRepo, jobRepo)
+
+	if *status {
+		printStatus(ctx, synchroniser, resource)
+		return
+	}
+
+	var saved, total int
+	switch *resourceName {
+	case "countries":
+		saved, total, err = synchroniser.SyncCountries(ctx, *restart)
+	case "currencies":
+		saved, total, err = synchroniser.SyncCurrencies(ctx, *restart)
+	case "regions":
+		saved, total, err = synchroniser.SyncRegions(ctx, country, *restart)
+	case "cities":
+		saved, total, err = synchroniser.SyncCities(ctx, country, *restart)
+	}
+	if err != nil {
+		fatal("sync failed, run again to resume", "resource", resource, "saved", saved, "total", total, "err", err)
+	}
+	slog.Info("sync completed", "resource", resource, "saved", saved, "total", total)
+}
+
+// parseResource maps the flags to the resource whose sync jobs they name, regions and cities are synced a country at a time
+func parseResource(name, countryFlag string) (domain.SyncResource, domain.CountryCode, error) {
+	switch name {
+	case "countries":
+		return domain.SyncCountries, "", nil
+	case "currencies":
+		return domain.SyncCurrencies, "", nil
+	case "regions", "cities":
+		country, err := domain.NewCountryCode(countryFlag)
+		if err != nil {
+			return "", "", fmt.Errorf("-resource %s needs -country: %w", name, err)
+		}
+		if name == "regions" {
+			return domain.SyncRegionsOf(country), country, nil
+		}
+		return domain.SyncCitiesOf(country), country, nil
+	}
+	return "", "", fmt.Errorf("unknown -resource %q, want countries, currencies, regions or cities", name)
+}
+
+func printStatus(ctx context.Context, synchroniser datasync.DataSynchroniser, resource domain.SyncResource) {
+	job, err := synchroniser.LatestSync(ctx, resource)
+	if errors.Is(err, dbcommon.ErrNotFound) {
+		fmt.Printf("%s was never synced\n", resource)
+		return
+	}
+	if err != nil {
+		fatal("cannot read the last sync", "err", err)
+	}
+
+	fmt.Printf("job:     %s\nstatus:  %s\noffset:  %d of %d\nsaved:   %d\nstarted: %s\nupdated: %s\n",
+		job.ID(), job.Status(), job.Offset(), job.TotalCount(), job.Saved(), job.StartedAt().Format(time.RFC3339), job.UpdatedAt().Format(time.RFC3339))
+	if job.LastError() != "" {
+		fmt.Printf("error:   %s\n", job.LastError())
+	}
+}
+
+// fatal logs at error level and exits, slog has no Fatal of its own
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
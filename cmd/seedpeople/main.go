@@ -0,0 +1,74 @@
+// seedpeople fills the DB with realistic fake people for load testing. Countries must have been synced first.
+//
+//	go run ./cmd/seedpeople -count 5000
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	sqlitedbadapter "louder/internal/adapters/driven/db"
+	bunadapter "louder/internal/adapters/driven/db/bun_adapter"
+	sqlxadapter "louder/internal/adapters/driven/db/sqlx_adapter"
+	fakedata "louder/internal/adapters/driven/fake_data"
+	randomgenerator "louder/internal/adapters/driven/random_generator"
+	"louder/internal/core/service/authcore"
+	"louder/internal/core/service/personcore"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+func main() {
+	count := flag.Int("count", 1000, "number of people to generate")
+	dbPath := flag.String("db", "./louder.db", "path to the sqlite DB file")
+	migrationsPath := flag.String("migrations", "./migrations", "path to the migration files")
+	flag.Parse()
+
+	// Ctrl+C stops between batches, whatever was committed stays
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	db, err := sqlitedbadapter.Init(*dbPath)
+	if err != nil {
+		fatal("cannot init DB", "err", err)
+	}
+	defer db.Close()
+
+	if err := sqlitedbadapter.RunMigrations(db, *migrationsPath); err != nil {
+		fatal("cannot run database migrations", "err", err)
+	}
+
+	personRepo, err := bunadapter.NewBunPersonRepo(db)
+	if err != nil {
+		fatal("cannot instantiate DB via Bun", "err", err)
+	}
+	countryRepo, err := sqlxadapter.NewCountryRepo(db)
+	if err != nil {
+		fatal("cannot instantiate country repo via SQLx", "err", err)
+	}
+	personFaker, err := fakedata.NewPersonFaker()
+	if err != nil {
+		fatal("cannot instantiate person faker", "err", err)
+	}
+
+	personService := personcore.NewPersonService(personRepo, randomgenerator.NewStdLibGenerator(), countryRepo, personFaker, authcore.ContextCaller{})
+
+	// the service caps a single call, so larger runs are split into several
+	remaining := *count
+	for remaining > 0 {
+		created, err := personService.GeneratePeople(ctx, min(remaining, personcore.MaxGeneratedPeople))
+		remaining -= created
+		if err != nil {
+			fatal("error generating people", "created", *count-remaining, "requested", *count, "err", err)
+		}
+	}
+
+	slog.Info("generated people", "count", *count)
+}
+
+// fatal logs at error level and exits, slog has no Fatal of its own
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
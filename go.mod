@@ -5,10 +5,10 @@ go 1.24.3
 require github.com/joho/godotenv v1.5.1
 
 require (
+	github.com/andybalholm/brotli v1.2.6
 	github.com/gofrs/uuid v4.4.0+incompatible
 	github.com/gofrs/uuid/v5 v5.3.2
 	github.com/golang-migrate/migrate/v4 v4.18.3
-	github.com/google/go-cmp v0.7.0
 	github.com/jmoiron/sqlx v1.4.0
 	github.com/mattn/go-sqlite3 v1.14.28
 	github.com/uptrace/bun v1.2.11
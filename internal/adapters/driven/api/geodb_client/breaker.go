@@ -0,0 +1,94 @@
+package geodbclient
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without calling GeoDB while the breaker is open
+var ErrCircuitOpen = errors.New("GeoDB circuit breaker is open, not calling the API")
+
+type breakerState int
+
+const (
+	breakerClosed   breakerState = iota // calls go through
+	breakerOpen                         // calls fail fast until the cooldown is over
+	breakerHalfOpen                     // one trial call is in flight, its outcome closes or reopens the breaker
+)
+
+// breaker stops calling GeoDB after threshold consecutive failures, for cooldown, then lets a single call through to see whether it's back
+type breaker struct {
+	threshold int // 0 never opens
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+}
+
+func newBreaker(threshold int, cooldown time.Duration) *breaker {
+	return &breaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// allow reports whether a call may go out now, a half open breaker only lets the first one through
+func (b *breaker) allow() bool {
+	if b.threshold <= 0 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = breakerHalfOpen
+		return true
+	case breakerHalfOpen:
+		return false
+	}
+	return true
+}
+
+// callOutcome is what a call tells about GeoDB's health
+type callOutcome int
+
+const (
+	callHealthy callOutcome = iota // GeoDB answered, even with a 4xx or a 429
+	callFailed                     // 5xx or no answer at all
+	callAborted                    // the caller gave up first, nothing learnt
+)
+
+// record counts the outcome of a call allow let through. Every such call must be recorded, a half open breaker waits for its trial call.
+func (b *breaker) record(outcome callOutcome) {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch outcome {
+	case callHealthy:
+		b.failures = 0
+		if b.state != breakerClosed {
+			b.state = breakerClosed
+		}
+	case callFailed:
+		b.failures++
+		if b.state == breakerHalfOpen || b.failures >= b.threshold {
+			b.state = breakerOpen
+			b.openedAt = b.now()
+		}
+	case callAborted:
+		// the cooldown is already over, the next call becomes the trial one
+		if b.state == breakerHalfOpen {
+			b.state = breakerOpen
+		}
+	}
+}
@@ -4,11 +4,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"iter"
 	"louder/internal/core/domain"
+	"louder/internal/core/service/citycore"
 	"louder/internal/core/service/countrycore"
 	"louder/internal/core/service/currencycore"
-	"time"
+	"louder/pkg/logging"
+	"net/url"
+)
+
+const (
+	currencyEndpoint = "/v1/locale/currencies"
+	regionsEndpoint  = "/v1/geo/countries/%s/regions" // of the country code
+	citiesEndpoint   = "/v1/geo/cities"
 )
 
 type Provider struct {
@@ -16,81 +24,94 @@ type Provider struct {
 	currencyRepo       currencycore.Repository
 	apiCountryEndpoint string
 	apiPageLimit       int
-	apiRateLimitSleep  time.Duration
+	apiConcurrency     int // pages fetched at once
 }
 
-func NewProvider(baseURL, countryEndpoint, apiKey string, currencyRepo currencycore.Repository, pageLimit int, rateLimitSleep time.Duration) countrycore.ExternalCountryProvider {
-	client := NewHTTPClient(baseURL, apiKey)
+var (
+	_ countrycore.ExternalCountryProvider   = (*Provider)(nil)
+	_ currencycore.ExternalCurrencyProvider = (*Provider)(nil)
+	_ citycore.ExternalCityProvider         = (*Provider)(nil)
+)
+
+func NewProvider(baseURL, countryEndpoint, apiKey string, currencyRepo currencycore.Repository, pageLimit, concurrency int, resilience Resilience, limiter *Limiter) *Provider {
+	client := NewHTTPClient(baseURL, apiKey, resilience, limiter)
 	return &Provider{
 		httpClient:         client,
 		currencyRepo:       currencyRepo,
 		apiCountryEndpoint: countryEndpoint,
 		apiPageLimit:       pageLimit,
-		apiRateLimitSleep:  rateLimitSleep,
+		apiConcurrency:     concurrency,
 	}
 }
 
 func (p *Provider) FetchAllCountries(ctx context.Context) ([]*domain.Country, error) {
-	log.Println("GeoDB Provider: FetchAllCountries called.")
+	logger := logging.FromContext(ctx)
+	logger.Info("GeoDB Provider: FetchAllCountries called")
 	var domainCountries []*domain.Country
 
-	// create a processor
-	proc := newProcessor(p.httpClient)
-
-	// create a paginator
-	countriesPaginator := NewPaginator(proc, p.apiCountryEndpoint, p.apiPageLimit)
-
-	for countriesPaginator.hasNext {
-		select {
-		case <-ctx.Done():
-			return domainCountries, fmt.Errorf("FetchAllCountries aborted by context: %w", ctx.Err())
-		default:
-		}
-
-		countryDTOs, err := countriesPaginator.NextPage(ctx)
+	for page, err := range p.CountryPages(ctx, 0) {
 		if err != nil {
 			return domainCountries, fmt.Errorf("error fetching page via paginator: %w", err)
 		}
 
-		// if countryDTOs is empty and there are no more pages
-		if len(countryDTOs) == 0 && !countriesPaginator.HasNext() {
-			break
-		}
+		domainCountries = append(domainCountries, page.Items...)
+		logger.Debug("GeoDB Provider: fetched page", "countries_so_far", len(domainCountries))
+	}
+	logger.Info("GeoDB Provider: fetched and mapped countries", "countries", len(domainCountries))
+	return domainCountries, nil
+}
 
-		for _, dto := range countryDTOs {
-			select {
-			case <-ctx.Done():
-				return domainCountries, fmt.Errorf("FetchAllCountries aborted during DTO mapping: %w", ctx.Err())
+// CountryPages streams the pages of countries from offset on, fetching up to apiConcurrency of them at once
+func (p *Provider) CountryPages(ctx context.Context, offset int) iter.Seq2[*domain.Page[*domain.Country], error] {
+	return pages(ctx, p, p.apiCountryEndpoint, nil, offset, p.mapDTOToDomainCountry)
+}
 
-			default:
-			}
+// CurrencyPages streams the pages of currencies, named after ISO 4217
+func (p *Provider) CurrencyPages(ctx context.Context, offset int) iter.Seq2[*domain.Page[*domain.Currency], error] {
+	return pages(ctx, p, currencyEndpoint, nil, offset, mapCurrencyDTO)
+}
 
-			domainCountry, mapErr := p.mapDTOToDomainCountry(ctx, dto)
-			if mapErr != nil {
-				log.Printf("ERROR Provider: Failed to map country DTO %s: %v. Skipping.", dto.CountryCode, mapErr)
-				continue
-			}
+func (p *Provider) RegionPages(ctx context.Context, country domain.CountryCode, offset int) iter.Seq2[*domain.Page[*domain.Region], error] {
+	return pages(ctx, p, fmt.Sprintf(regionsEndpoint, url.PathEscape(country.String())), nil, offset, mapRegionDTO)
+}
 
-			domainCountries = append(domainCountries, domainCountry)
-		}
+// CityPages streams the cities of a country, leaving out the ADM2 areas GeoDB lists with them
+func (p *Provider) CityPages(ctx context.Context, country domain.CountryCode, offset int) iter.Seq2[*domain.Page[*domain.City], error] {
+	filter := url.Values{"countryIds": {country.String()}, "types": {"CITY"}}
+	return pages(ctx, p, citiesEndpoint, filter, offset, mapCityDTO)
+}
+
+// pages streams the pages of endpoint from offset on, mapping each DTO of type D to a T and leaving out the ones that can't be mapped
+func pages[D, T any](ctx context.Context, p *Provider, endpoint string, filter url.Values, offset int, mapDTO func(context.Context, D) (T, error)) iter.Seq2[*domain.Page[T], error] {
+	return func(yield func(*domain.Page[T], error) bool) {
+		pg := NewPaginator(newProcessor[D](p.httpClient), endpoint, p.apiPageLimit, filter)
+		pg.offset = offset
 
-		if countriesPaginator.HasNext() {
-			log.Printf("ProviderImpl: Fetched page, %d countries total so far. Sleeping for rate limit.", len(domainCountries))
+		for page, err := range pg.Pages(ctx, p.apiConcurrency) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
 
-			select {
-			case <-time.After(p.apiRateLimitSleep):
-			case <-ctx.Done():
-				return domainCountries, fmt.Errorf("FetchAllCountries aborted during rate limit sleep: %w", ctx.Err())
+			items := make([]T, 0, len(page.items))
+			for _, dto := range page.items {
+				item, err := mapDTO(ctx, dto)
+				if err != nil {
+					logging.FromContext(ctx).Error("GeoDB Provider: failed to map DTO, skipping", "endpoint", endpoint, "dto", dto, "err", err)
+					continue
+				}
+				items = append(items, item)
+			}
+			if !yield(&domain.Page[T]{Items: items, NextOffset: page.next, TotalCount: page.totalCount}, nil) {
+				return
 			}
 		}
 	}
-	log.Printf("ProviderImpl: Successfully fetched and mapped %d countries.", len(domainCountries))
-	return domainCountries, nil
 }
 
 func (p *Provider) GetTotalCountryCountFromAPI(ctx context.Context) (int, error) {
-	proc := newProcessor(p.httpClient)
-	pg := NewPaginator(proc, p.apiCountryEndpoint, p.apiPageLimit)
+	proc := newProcessor[CountryDTO](p.httpClient)
+	pg := NewPaginator(proc, p.apiCountryEndpoint, p.apiPageLimit, nil)
 
 	_, err := pg.NextPage(ctx)
 	if err != nil && pg.TotalCount() == -1 {
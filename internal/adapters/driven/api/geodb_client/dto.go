@@ -7,11 +7,40 @@ type CountryDTO struct {
 	WikiDataId    string   `json:"wikiDataId"`
 }
 
-// I wish there was a better way of doing this...
-type GeoDBAPIResponse struct {
+// RegionDTO is a country's subdivision, a state or a province
+type RegionDTO struct {
+	CountryCode string `json:"countryCode"`
+	ISOCode     string `json:"isoCode"` // unique within the country, may be empty for some
+	FIPSCode    string `json:"fipsCode"`
+	Name        string `json:"name"`
+	WikiDataId  string `json:"wikiDataId"`
+}
+
+type CityDTO struct {
+	ID          int64   `json:"id"`
+	WikiDataId  string  `json:"wikiDataId"`
+	Type        string  `json:"type"` // CITY or ADM2
+	Name        string  `json:"name"`
+	CountryCode string  `json:"countryCode"`
+	RegionCode  string  `json:"regionCode"`
+	Region      string  `json:"region"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+	Population  int     `json:"population"`
+}
+
+// CurrencyDTO comes without a name, currencyName fills it in from ISO 4217
+type CurrencyDTO struct {
+	Code         string   `json:"code"`
+	CountryCodes []string `json:"countryCodes"`
+	Symbol       string   `json:"symbol"`
+}
+
+// GeoDBAPIResponse is the envelope of every paginated GeoDB endpoint, T is the DTO of its data
+type GeoDBAPIResponse[T any] struct {
 	Metadata struct {
 		Count  int `json:"totalCount"`
 		Offset int `json:"offset"`
 	} `json:"metadata"`
-	Countries []CountryDTO `json:"data"`
+	Data []T `json:"data"`
 }
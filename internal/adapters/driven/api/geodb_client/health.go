@@ -0,0 +1,21 @@
+package geodbclient
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// NewReachabilityCheck returns a readiness check that asks the GeoDB API for a single country. It counts against the API quota, the caller should cache its result.
+func NewReachabilityCheck(baseURL, countryEndpoint, apiKey string, resilience Resilience, limiter *Limiter) func(ctx context.Context) error {
+	client := NewHTTPClient(baseURL, apiKey, resilience, limiter)
+	params := url.Values{"limit": {"1"}}
+
+	return func(ctx context.Context) error {
+		var resp GeoDBAPIResponse[CountryDTO]
+		if err := client.queryAPI(ctx, countryEndpoint, params, &resp); err != nil {
+			return fmt.Errorf("GeoDB API unreachable: %w", err)
+		}
+		return nil
+	}
+}
@@ -6,9 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log"
+	"louder/pkg/logging"
+	"louder/pkg/tracing"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 )
 
@@ -17,11 +19,18 @@ type httpClient struct {
 	apiKeyHeaderName string
 	apiKeyValue      string
 	httpClient       *http.Client
+	resilience       Resilience
+	breaker          *breaker
+	limiter          *Limiter                                         // shared with the other clients, nil for no limit
+	sleep            func(ctx context.Context, d time.Duration) error // waits between attempts
 }
 
+// requestIDHeader matches the header set by the driving adapters
+const requestIDHeader = "X-Request-ID"
+
 // baseURL: "https://wft-geo-db.p.rapidapi.com",
 
-func NewHTTPClient(baseURL, apiKey string) *httpClient {
+func NewHTTPClient(baseURL, apiKey string, resilience Resilience, limiter *Limiter) *httpClient {
 	return &httpClient{
 		baseURL:          baseURL,
 		apiKeyHeaderName: "x-rapidapi-key",
@@ -30,23 +39,40 @@ func NewHTTPClient(baseURL, apiKey string) *httpClient {
 		httpClient: &http.Client{
 			Timeout: 15 * time.Second,
 		},
+		resilience: resilience,
+		breaker:    newBreaker(resilience.FailureThreshold, resilience.OpenFor),
+		limiter:    limiter,
+		sleep:      sleepContext,
 	}
 }
 
-// endpoint := "/v1/geo/countries"
+// statusError is a response other than 200 OK
+type statusError struct {
+	code       int
+	status     string
+	body       string
+	retryAfter time.Duration // set when the response had a valid Retry-After
+	hasRetry   bool
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("API request failed with code %s: %s", e.status, e.body)
+}
 
-func (c *httpClient) queryAPI(ctx context.Context, endpoint string, params url.Values) (*GeoDBAPIResponse, error) {
+// endpoint := "/v1/geo/countries"
 
+// queryAPI calls endpoint, retrying with backoff on 429, 5xx and network errors as c.resilience allows. Every attempt waits its turn at the limiter, calls fail with ErrCircuitOpen while the breaker is open.
+func (c *httpClient) queryAPI(ctx context.Context, endpoint string, params url.Values, out any) error {
 	// join base url and endpoint and check for errors
 	joinedURL, err := url.JoinPath(c.baseURL, endpoint)
 	if err != nil {
-		return nil, fmt.Errorf("failed to join base URL and endpoint: %w", err)
+		return fmt.Errorf("failed to join base URL and endpoint: %w", err)
 	}
 
 	// parse url and check for errors
 	parsedURL, err := url.Parse(joinedURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse URL: %w", err)
+		return fmt.Errorf("failed to parse URL: %w", err)
 	}
 
 	if params != nil {
@@ -54,51 +80,147 @@ func (c *httpClient) queryAPI(ctx context.Context, endpoint string, params url.V
 		parsedURL.RawQuery = params.Encode()
 	}
 
+	logger := logging.FromContext(ctx)
+	attempts := max(c.resilience.MaxAttempts, 1)
+
+	for attempt := 1; ; attempt++ {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return err
+		}
+		if !c.breaker.allow() {
+			geodbErrors.With(endpoint, reasonOpen).Inc()
+			return ErrCircuitOpen
+		}
+
+		status, err := c.queryOnce(ctx, endpoint, parsedURL, attempt-1, out)
+		c.breaker.record(outcome(ctx, status, err))
+		if err == nil {
+			return nil
+		}
+
+		if attempt >= attempts || ctx.Err() != nil || !retryable(status) {
+			if attempt > 1 {
+				return fmt.Errorf("giving up after %d attempts: %w", attempt, err)
+			}
+			return err
+		}
+
+		delay := c.resilience.backoff(attempt)
+		var statusErr *statusError
+		if errors.As(err, &statusErr) && statusErr.hasRetry {
+			if c.resilience.MaxDelay > 0 && statusErr.retryAfter > c.resilience.MaxDelay {
+				return fmt.Errorf("GeoDB asked to retry in %s, more than the %s we wait: %w", statusErr.retryAfter, c.resilience.MaxDelay, err)
+			}
+			delay = max(delay, statusErr.retryAfter)
+		}
+
+		logger.Warn("HTTP Client: request failed, retrying", "endpoint", endpoint, "attempt", attempt, "delay", delay, "err", err)
+		geodbRetries.With(endpoint).Inc()
+		if err := c.sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// outcome tells the breaker what a call's result says about GeoDB
+func outcome(ctx context.Context, status int, err error) callOutcome {
+	switch {
+	case err == nil:
+		return callHealthy
+	case ctx.Err() != nil:
+		return callAborted
+	case status == 0 || status >= 500:
+		return callFailed
+	}
+	return callHealthy
+}
+
+// queryOnce makes a single attempt, decoding the body into out. resend counts the attempts before it, status is 0 when no response came back.
+func (c *httpClient) queryOnce(ctx context.Context, endpoint string, parsedURL *url.URL, resend int, out any) (int, error) {
+	// a client span per call, its context goes to GeoDB in the traceparent header
+	ctx, span := tracing.StartWithKind(ctx, tracing.KindClient, http.MethodGet+" "+endpoint,
+		tracing.String("http.request.method", http.MethodGet),
+		tracing.String("server.address", parsedURL.Hostname()),
+		tracing.String("url.full", parsedURL.String()),
+	)
+	defer span.End()
+	if resend > 0 {
+		span.SetAttributes(tracing.Int("http.request.resend_count", resend))
+	}
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, parsedURL.String(), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to create request: %w", err)
 	}
+	tracing.Inject(ctx, req.Header)
 
-	log.Printf("HTTP Client: Sending request to %s", parsedURL.String())
+	logger := logging.FromContext(ctx)
+	logger.Debug("HTTP Client: sending request", "url", parsedURL.String())
 
 	// add the auth key to the header
 	req.Header.Set(c.apiKeyHeaderName, c.apiKeyValue)
 
+	// pass the correlation ID on so a sync can be matched with the provider's side if needed
+	if requestID := logging.RequestID(ctx); requestID != "" {
+		req.Header.Set(requestIDHeader, requestID)
+	}
+
+	// every way out below is counted in the metrics, and fails the span when it's an error
+	start := time.Now()
+	var status, reason string
+	defer func() {
+		recordCall(endpoint, status, reason, time.Since(start))
+		if reason != "" {
+			span.SetStatus(tracing.StatusError, "GeoDB call failed: "+reason)
+		}
+	}()
+
 	// use the http client injected to the function
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		// context cancelled or deadline?
 		switch {
 		case errors.Is(err, context.Canceled):
-			log.Printf("HTTP Client: Request cancelled: %v", err)
-			return nil, context.Canceled
+			reason = reasonCancelled
+			logger.Warn("HTTP Client: request cancelled", "err", err)
+			return 0, context.Canceled
 		case errors.Is(err, context.DeadlineExceeded):
-			log.Printf("HTTP Client: Request timed out: %v", err)
-			return nil, context.DeadlineExceeded
+			reason = reasonTimeout
+			logger.Warn("HTTP Client: request timed out", "err", err)
+			return 0, context.DeadlineExceeded
 		default:
-			return nil, fmt.Errorf("http_client: httpClient.Do: %w", err)
+			reason = reasonTransport
+			return 0, fmt.Errorf("http_client: httpClient.Do: %w", err)
 		}
 	}
 	defer resp.Body.Close()
+	c.limiter.observe(resp.Header)
+	status = strconv.Itoa(resp.StatusCode)
+	span.SetAttributes(tracing.Int("http.response.status_code", resp.StatusCode))
 
 	// Check the response status code
 	if resp.StatusCode != http.StatusOK {
+		reason = reasonStatus
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with code %s: %s", resp.Status, string(bodyBytes))
+		statusErr := &statusError{code: resp.StatusCode, status: resp.Status, body: string(bodyBytes)}
+		statusErr.retryAfter, statusErr.hasRetry = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		return resp.StatusCode, statusErr
 	}
 
 	// read and parse the body
 	bodyBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		reason = reasonTransport
+		return resp.StatusCode, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	// unmarshall the parsed json data into GeoDBAPIresponse struct
-	var apiResp GeoDBAPIResponse
-	if err := json.Unmarshal(bodyBytes, &apiResp); err != nil {
-		log.Printf("HTTP Client: Failed to unmarshal JSON. Body was: %s", string(bodyBytes))
-		return nil, fmt.Errorf("failed to unmarshall json data: %w", err)
+	// unmarshall the parsed json data into out, a GeoDBAPIResponse of some DTO
+	if err := json.Unmarshal(bodyBytes, out); err != nil {
+		reason = reasonDecode
+		logger.Error("HTTP Client: failed to unmarshal JSON", "body", string(bodyBytes), "err", err)
+		return resp.StatusCode, fmt.Errorf("failed to unmarshall json data: %w", err)
 	}
 
-	return &apiResp, nil
+	return resp.StatusCode, nil
 }
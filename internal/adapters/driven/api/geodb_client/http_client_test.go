@@ -0,0 +1,170 @@
+package geodbclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const okBody = `{"data":[{"code":"IT","name":"Italy"}],"metadata":{"offset":0,"totalCount":1}}`
+
+// flakyServer answers with statuses in turn, then 200 with okBody, and counts the requests
+func flakyServer(t *testing.T, statuses ...int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(hits.Add(1))
+		if n <= len(statuses) {
+			if statuses[n-1] == http.StatusTooManyRequests {
+				w.Header().Set("Retry-After", "2")
+			}
+			http.Error(w, http.StatusText(statuses[n-1]), statuses[n-1])
+			return
+		}
+		w.Write([]byte(okBody))
+	}))
+	t.Cleanup(server.Close)
+	return server, &hits
+}
+
+// newTestClient returns a client that records the delays it would sleep instead of sleeping
+func newTestClient(baseURL string, resilience Resilience) (*httpClient, *[]time.Duration) {
+	c := NewHTTPClient(baseURL, "key", resilience, nil)
+	var slept []time.Duration
+	c.sleep = func(_ context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		return nil
+	}
+	return c, &slept
+}
+
+func TestQueryAPIRetries(t *testing.T) {
+	resilience := Resilience{MaxAttempts: 3, BaseDelay: 10 * time.Millisecond, MaxDelay: 5 * time.Second}
+
+	tests := map[string]struct {
+		statuses  []int
+		wantErr   bool
+		wantHits  int32
+		wantSleep time.Duration // minimum of the first sleep, when there is one
+	}{
+		"first try":                   {wantHits: 1},
+		"recovers from 5xx":           {statuses: []int{503, 502}, wantHits: 3},
+		"429 waits for Retry-After":   {statuses: []int{429}, wantHits: 2, wantSleep: 2 * time.Second},
+		"gives up after max attempts": {statuses: []int{500, 500, 500}, wantErr: true, wantHits: 3},
+		"4xx is not retried":          {statuses: []int{400}, wantErr: true, wantHits: 1},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			server, hits := flakyServer(t, tc.statuses...)
+			c, slept := newTestClient(server.URL, resilience)
+
+			var resp GeoDBAPIResponse[CountryDTO]
+			err := c.queryAPI(context.Background(), "/v1/geo/countries", nil, &resp)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("err = %v, want error: %v", err, tc.wantErr)
+			}
+			if !tc.wantErr && len(resp.Data) != 1 {
+				t.Errorf("response = %+v, want one country", resp)
+			}
+			if got := hits.Load(); got != tc.wantHits {
+				t.Errorf("server hit %d times, want %d", got, tc.wantHits)
+			}
+			if len(*slept) != int(tc.wantHits)-1 {
+				t.Errorf("slept %d times, want %d", len(*slept), tc.wantHits-1)
+			}
+			if tc.wantSleep > 0 && (*slept)[0] < tc.wantSleep {
+				t.Errorf("first sleep = %s, want at least %s", (*slept)[0], tc.wantSleep)
+			}
+		})
+	}
+}
+
+func TestQueryAPIRetryAfterTooLong(t *testing.T) {
+	server, hits := flakyServer(t, http.StatusTooManyRequests)
+	c, _ := newTestClient(server.URL, Resilience{MaxAttempts: 3, MaxDelay: time.Second})
+
+	if err := c.queryAPI(context.Background(), "/v1/geo/countries", nil, &GeoDBAPIResponse[CountryDTO]{}); err == nil {
+		t.Fatal("expected an error when Retry-After is longer than MaxDelay")
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("server hit %d times, want 1", got)
+	}
+}
+
+func TestQueryAPICircuitBreaker(t *testing.T) {
+	down := atomic.Bool{}
+	down.Store(true)
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if down.Load() {
+			http.Error(w, "down", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(okBody))
+	}))
+	defer server.Close()
+
+	c, _ := newTestClient(server.URL, Resilience{MaxAttempts: 2, FailureThreshold: 2, OpenFor: time.Minute})
+	now := time.Now()
+	c.breaker.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// two failed attempts open the breaker
+	if err := c.queryAPI(ctx, "/v1/geo/countries", nil, &GeoDBAPIResponse[CountryDTO]{}); err == nil || errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("first call err = %v, want the 503", err)
+	}
+
+	// fails fast without calling GeoDB
+	if err := c.queryAPI(ctx, "/v1/geo/countries", nil, &GeoDBAPIResponse[CountryDTO]{}); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("open breaker err = %v, want ErrCircuitOpen", err)
+	}
+	if got := hits.Load(); got != 2 {
+		t.Errorf("server hit %d times, want 2", got)
+	}
+
+	// after the cooldown a trial call goes through and closes it
+	down.Store(false)
+	now = now.Add(time.Minute)
+	if err := c.queryAPI(ctx, "/v1/geo/countries", nil, &GeoDBAPIResponse[CountryDTO]{}); err != nil {
+		t.Fatalf("trial call err = %v", err)
+	}
+	if err := c.queryAPI(ctx, "/v1/geo/countries", nil, &GeoDBAPIResponse[CountryDTO]{}); err != nil {
+		t.Fatalf("closed breaker err = %v", err)
+	}
+	if got := hits.Load(); got != 4 {
+		t.Errorf("server hit %d times, want 4", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		"seconds":       {value: "120", want: 2 * time.Minute, wantOK: true},
+		"HTTP date":     {value: now.Add(90 * time.Second).Format(http.TimeFormat), want: 90 * time.Second, wantOK: true},
+		"date passed":   {value: now.Add(-time.Minute).Format(http.TimeFormat), want: 0, wantOK: true},
+		"missing":       {value: ""},
+		"negative":      {value: "-5"},
+		"not a delay":   {value: "soon"},
+		"padded number": {value: "  3", want: 3 * time.Second, wantOK: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tc.value, now)
+			if got != tc.want || ok != tc.wantOK {
+				t.Errorf("parseRetryAfter(%q) = %s, %v, want %s, %v", tc.value, got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
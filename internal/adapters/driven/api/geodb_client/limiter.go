@@ -0,0 +1,140 @@
+package geodbclient
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limiter is a token bucket shared by every GeoDB client so concurrent callers take turns. It follows the X-RateLimit-Remaining and X-RateLimit-Reset headers of the responses, slowing down to spread what's left of the quota and pausing when it's used up. A nil Limiter lets every call through.
+type Limiter struct {
+	perSecond float64 // rate of the plan, when the headers don't ask for less
+	burst     float64
+	now       func() time.Time
+	sleep     func(ctx context.Context, d time.Duration) error
+
+	mu     sync.Mutex
+	tokens float64   // below 0 when callers are queued up
+	last   time.Time // when tokens was last refilled, in the future while paused
+
+	adaptedRate float64 // lower rate asked for by the headers, until adaptedTill
+	adaptedTill time.Time
+}
+
+// NewLimiter allows perSecond calls a second on average and burst at once, it returns nil when perSecond isn't positive
+func NewLimiter(perSecond float64, burst int) *Limiter {
+	if perSecond <= 0 {
+		return nil
+	}
+	burst = max(burst, 1)
+	return &Limiter{
+		perSecond: perSecond,
+		burst:     float64(burst),
+		now:       time.Now,
+		sleep:     sleepContext,
+		tokens:    float64(burst),
+		last:      time.Now(),
+	}
+}
+
+// Wait blocks until the caller may send a request or ctx is done
+func (l *Limiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	wait := l.reserve()
+	if wait <= 0 {
+		return nil
+	}
+	if err := l.sleep(ctx, wait); err != nil {
+		// the request won't be sent, whoever queued after us can have the token
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// reserve takes a token and returns how long to wait until it's due
+func (l *Limiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	rate := l.rate(now)
+	if now.After(l.last) {
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*rate)
+		l.last = now
+	}
+	l.tokens--
+
+	// while paused, last is when the quota comes back
+	wait := l.last.Sub(now)
+	if l.tokens < 0 {
+		wait += time.Duration(-l.tokens / rate * float64(time.Second))
+	}
+	return wait
+}
+
+func (l *Limiter) rate(now time.Time) float64 {
+	if now.Before(l.adaptedTill) {
+		return l.adaptedRate
+	}
+	return l.perSecond
+}
+
+// observe adapts to the quota left according to the response headers, responses without them change nothing
+func (l *Limiter) observe(header http.Header) {
+	if l == nil {
+		return
+	}
+	remaining, err := strconv.Atoi(strings.TrimSpace(header.Get("X-RateLimit-Remaining")))
+	if err != nil || remaining < 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	reset, ok := parseRateLimitReset(header.Get("X-RateLimit-Reset"), now)
+	if !ok {
+		// no idea when it comes back, at least don't burst past it
+		l.tokens = min(l.tokens, float64(remaining))
+		return
+	}
+
+	if remaining == 0 {
+		// nothing left until the reset, the queue moves on from there
+		resetAt := now.Add(reset)
+		if resetAt.After(l.last) {
+			l.tokens = min(l.tokens, 0)
+			l.last = resetAt
+		}
+		return
+	}
+
+	l.tokens = min(l.tokens, float64(remaining))
+	if spread := float64(remaining) / max(reset.Seconds(), 1); spread < l.perSecond {
+		l.adaptedRate = spread
+		l.adaptedTill = now.Add(reset)
+	}
+}
+
+// parseRateLimitReset reads X-RateLimit-Reset, seconds until the quota resets or, for large values, the Unix time it does
+func parseRateLimitReset(value string, now time.Time) (time.Duration, bool) {
+	seconds, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	// no window is a decade long, anything bigger is a timestamp
+	if seconds > 10*365*24*60*60 {
+		return max(time.Unix(seconds, 0).Sub(now), 0), true
+	}
+	return time.Duration(seconds) * time.Second, true
+}
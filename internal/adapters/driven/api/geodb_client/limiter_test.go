@@ -0,0 +1,131 @@
+package geodbclient
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestLimiter returns a limiter on a clock that only moves when told to
+func newTestLimiter(perSecond float64, burst int) (*Limiter, *time.Time) {
+	l := NewLimiter(perSecond, burst)
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+	l.last = now
+	return l, &now
+}
+
+func header(remaining, reset string) http.Header {
+	h := http.Header{}
+	h.Set("X-RateLimit-Remaining", remaining)
+	h.Set("X-RateLimit-Reset", reset)
+	return h
+}
+
+func TestLimiterReserve(t *testing.T) {
+	tests := map[string]struct {
+		header http.Header // observed before reserving, nil for none
+		want   []time.Duration
+	}{
+		"burst then the rate":         {want: []time.Duration{0, 0, 500 * time.Millisecond, time.Second}},
+		"quota used up pauses":        {header: header("0", "10"), want: []time.Duration{10*time.Second + 500*time.Millisecond, 11 * time.Second}},
+		"little left slows down":      {header: header("2", "10"), want: []time.Duration{0, 0, 5 * time.Second, 10 * time.Second}},
+		"plenty left changes nothing": {header: header("100", "10"), want: []time.Duration{0, 0, 500 * time.Millisecond, time.Second}},
+		"no reset caps the burst":     {header: http.Header{"X-Ratelimit-Remaining": {"1"}}, want: []time.Duration{0, 500 * time.Millisecond}},
+		"reset as a timestamp": {
+			header: header("0", "1792411205"), // 5 seconds after the test clock
+			want:   []time.Duration{5*time.Second + 500*time.Millisecond},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			l, _ := newTestLimiter(2, 2)
+			if tc.header != nil {
+				l.observe(tc.header)
+			}
+			for i, want := range tc.want {
+				if got := l.reserve(); got != want {
+					t.Errorf("reservation %d waits %s, want %s", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLimiterRefills(t *testing.T) {
+	l, now := newTestLimiter(2, 2)
+	l.reserve()
+	l.reserve()
+
+	*now = now.Add(time.Second)
+	for i := range 2 {
+		if got := l.reserve(); got != 0 {
+			t.Errorf("reservation %d after a second waits %s, want none", i, got)
+		}
+	}
+
+	// the slower rate asked for by the headers only lasts until the reset
+	l.observe(header("1", "10"))
+	if got := l.reserve(); got != 10*time.Second {
+		t.Errorf("slowed down reservation waits %s, want 10s", got)
+	}
+	*now = now.Add(20 * time.Second)
+	if got := l.reserve(); got != 0 {
+		t.Errorf("reservation after the reset waits %s, want none", got)
+	}
+}
+
+func TestLimiterWaitCancelled(t *testing.T) {
+	l, _ := newTestLimiter(1, 1)
+	l.sleep = func(ctx context.Context, _ time.Duration) error { return context.Canceled }
+
+	l.reserve()
+	if err := l.Wait(context.Background()); err != context.Canceled {
+		t.Fatalf("Wait err = %v, want context.Canceled", err)
+	}
+	// the cancelled caller gave its token back
+	if got := l.reserve(); got != time.Second {
+		t.Errorf("next reservation waits %s, want 1s", got)
+	}
+}
+
+func TestLimiterShared(t *testing.T) {
+	l, _ := newTestLimiter(10, 1)
+	var mu sync.Mutex
+	var total time.Duration
+	l.sleep = func(_ context.Context, d time.Duration) error {
+		mu.Lock()
+		total = max(total, d)
+		mu.Unlock()
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.Wait(context.Background())
+		}()
+	}
+	wg.Wait()
+
+	// 10 callers at 10 a second, the last one goes out 0.9s after the first
+	if total != 900*time.Millisecond {
+		t.Errorf("longest wait = %s, want 900ms", total)
+	}
+}
+
+func TestNilLimiter(t *testing.T) {
+	l := NewLimiter(0, 1)
+	if l != nil {
+		t.Fatal("NewLimiter(0, 1) should switch limiting off")
+	}
+	l.observe(header("0", "10"))
+	if err := l.Wait(context.Background()); err != nil {
+		t.Errorf("Wait on a nil limiter = %v", err)
+	}
+}
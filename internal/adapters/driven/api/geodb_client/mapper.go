@@ -0,0 +1,117 @@
+package geodbclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"louder/internal/core/domain"
+	"louder/internal/core/errkind"
+	"louder/pkg/logging"
+)
+
+// mapDTOToDomainCountry converts an API DTO (CountryDTO) to a domain.Country object
+func (p *Provider) mapDTOToDomainCountry(ctx context.Context, dto CountryDTO) (*domain.Country, error) {
+	logger := logging.FromContext(ctx)
+
+	if dto.CountryCode == "" {
+		return nil, errors.New("mapDTO: API DTO has empty country code")
+	}
+
+	if dto.CountryName == "" {
+		return nil, errors.New("mapDTO: API DTO has empty country name")
+	}
+
+	countryCode, err := domain.NewCountryCode(dto.CountryCode)
+	if err != nil {
+		return nil, fmt.Errorf("mapDTO: invalid country code '%s' from API: %w", dto.CountryCode, err)
+	}
+
+	// wikidataid can be null. For now...
+	var wikiID domain.WikiCode
+	if dto.WikiDataId != "" {
+		wikiID, err = domain.NewWikiCode(dto.WikiDataId)
+
+		if err != nil {
+			logger.Warn("mapDTO: invalid WikiDataID format, using empty", "wikidata_id", dto.WikiDataId, "country", dto.CountryCode, "err", err)
+			wikiID = domain.WikiCode("")
+		}
+	}
+
+	var domainCurrencies []domain.Currency
+	for _, currencyCodeStr := range dto.CurrencyCodes {
+		if currencyCodeStr == "" {
+			logger.Warn("mapDTO: empty currency code received, skipping", "country", dto.CountryCode)
+			continue
+		}
+
+		cc, err := domain.NewCurrencyCode(currencyCodeStr)
+		if err != nil {
+			logger.Warn("mapDTO: invalid currency code, skipping this currency", "currency", currencyCodeStr, "country", dto.CountryCode, "err", err)
+			continue
+		}
+
+		// get the currency from the DB if exists
+		currency, err := p.currencyRepo.GetByID(ctx, cc)
+		// the repos wrap their own not found errors, the kind is what they share
+		if errkind.Of(err) == errkind.NotFound {
+			logger.Debug("mapDTO: currency not in local DB yet, named after ISO 4217", "currency", cc.String(), "country", dto.CountryCode)
+
+			newCurrency, ncErr := domain.NewCurrency(cc, currencyName(cc))
+			if ncErr != nil {
+				logger.Error("mapDTO: could not create placeholder domain.Currency", "currency", cc.String(), "err", ncErr)
+				continue
+			}
+			domainCurrencies = append(domainCurrencies, *newCurrency)
+
+		} else if err != nil {
+			logger.Error("mapDTO: failed to lookup currency, skipping this currency", "currency", cc.String(), "country", dto.CountryCode, "err", err)
+			continue
+
+		} else if currency != nil { // meaning found in db
+			domainCurrencies = append(domainCurrencies, *currency)
+		}
+	}
+
+	// GeoDB does not return regions, an empty one leaves whatever is stored untouched
+	return domain.NewCountry(countryCode, dto.CountryName, "", domainCurrencies, wikiID)
+}
+
+// mapCurrencyDTO names the currency after ISO 4217, GeoDB sends none
+func mapCurrencyDTO(_ context.Context, dto CurrencyDTO) (*domain.Currency, error) {
+	cc, err := domain.NewCurrencyCode(dto.Code)
+	if err != nil {
+		return nil, fmt.Errorf("mapDTO: invalid currency code '%s' from API: %w", dto.Code, err)
+	}
+	return domain.NewCurrency(cc, currencyName(cc))
+}
+
+// mapRegionDTO keys the region by its ISO code, or its FIPS code for the regions that have none
+func mapRegionDTO(_ context.Context, dto RegionDTO) (*domain.Region, error) {
+	countryCode, err := domain.NewCountryCode(dto.CountryCode)
+	if err != nil {
+		return nil, fmt.Errorf("mapDTO: invalid country code '%s' of region %s: %w", dto.CountryCode, dto.Name, err)
+	}
+
+	code := dto.ISOCode
+	if code == "" {
+		code = dto.FIPSCode
+	}
+	return domain.NewRegion(countryCode, code, dto.Name, optionalWikiCode(dto.WikiDataId))
+}
+
+func mapCityDTO(_ context.Context, dto CityDTO) (*domain.City, error) {
+	countryCode, err := domain.NewCountryCode(dto.CountryCode)
+	if err != nil {
+		return nil, fmt.Errorf("mapDTO: invalid country code '%s' of city %d: %w", dto.CountryCode, dto.ID, err)
+	}
+	return domain.NewCity(domain.CityID(dto.ID), dto.Name, countryCode, dto.RegionCode, dto.Latitude, dto.Longitude, dto.Population, optionalWikiCode(dto.WikiDataId))
+}
+
+// optionalWikiCode is empty when GeoDB has no Wikidata entry
+func optionalWikiCode(raw string) domain.WikiCode {
+	if raw == "" {
+		return ""
+	}
+	wikiID, _ := domain.NewWikiCode(raw)
+	return wikiID
+}
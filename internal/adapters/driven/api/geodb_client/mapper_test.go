@@ -0,0 +1,74 @@
+package geodbclient
+
+import (
+	"context"
+	dbcommon "louder/internal/adapters/driven/db/dbcommon"
+	"louder/internal/core/domain"
+	"louder/internal/core/service/currencycore"
+	"testing"
+)
+
+// knownCurrencies has only the euro, under a name of its own
+type knownCurrencies struct {
+	currencycore.Repository
+}
+
+func (knownCurrencies) GetByID(_ context.Context, cc domain.CurrencyCode) (*domain.Currency, error) {
+	if cc == "EUR" {
+		return domain.NewCurrency(cc, "Euro (stored)")
+	}
+	return nil, dbcommon.ErrSQLxNotFound
+}
+
+func TestMapCountryCurrencies(t *testing.T) {
+	p := &Provider{currencyRepo: knownCurrencies{}}
+	country, err := p.mapDTOToDomainCountry(context.Background(), CountryDTO{CountryCode: "zw", CountryName: "Zimbabwe", CurrencyCodes: []string{"EUR", "ZWG", "XYZ", "TOOLONG"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[domain.CurrencyCode]string{"EUR": "Euro (stored)", "ZWG": "Zimbabwe Gold", "XYZ": "Currency XYZ"}
+	if len(country.Currencies()) != len(want) {
+		t.Fatalf("got %d currencies, want %d", len(country.Currencies()), len(want))
+	}
+	for _, c := range country.Currencies() {
+		if c.Name() != want[c.Code()] {
+			t.Errorf("currency %s named %q, want %q", c.Code(), c.Name(), want[c.Code()])
+		}
+	}
+}
+
+func TestMapRegionAndCityDTOs(t *testing.T) {
+	tests := map[string]struct {
+		region   RegionDTO
+		wantCode string
+		wantErr  bool
+	}{
+		"ISO code":         {region: RegionDTO{CountryCode: "IT", ISOCode: "62", FIPSCode: "07", Name: "Lazio"}, wantCode: "62"},
+		"FIPS code if not": {region: RegionDTO{CountryCode: "IT", FIPSCode: "07", Name: "Lazio"}, wantCode: "07"},
+		"no code":          {region: RegionDTO{CountryCode: "IT", Name: "Lazio"}, wantErr: true},
+		"bad country":      {region: RegionDTO{CountryCode: "ITA", ISOCode: "62", Name: "Lazio"}, wantErr: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			region, err := mapRegionDTO(context.Background(), tc.region)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("err = %v, want error: %v", err, tc.wantErr)
+			}
+			if err == nil && region.Code() != tc.wantCode {
+				t.Errorf("region code = %s, want %s", region.Code(), tc.wantCode)
+			}
+		})
+	}
+
+	city, err := mapCityDTO(context.Background(), CityDTO{ID: 3543, Name: "Rome", CountryCode: "it", RegionCode: "62", Latitude: 41.893, Longitude: 12.483, Population: 2_750_000, WikiDataId: "Q220"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if city.ID() != 3543 || city.CountryCode() != "IT" || city.WikiId() != "Q220" {
+		t.Errorf("got %+v", city)
+	}
+	if _, err := mapCityDTO(context.Background(), CityDTO{ID: 1, Name: "Atlantis", CountryCode: "GR", Latitude: 91}); err == nil {
+		t.Error("a city beyond the pole was mapped")
+	}
+}
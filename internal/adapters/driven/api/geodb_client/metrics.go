@@ -0,0 +1,39 @@
+package geodbclient
+
+import (
+	"louder/pkg/metrics"
+	"time"
+)
+
+var (
+	geodbRequests = metrics.Default.NewCounterVec("louder_geodb_requests_total",
+		"Calls made to the GeoDB API, by endpoint and response status code, \"none\" when no response came back.", "endpoint", "status")
+	geodbDuration = metrics.Default.NewHistogramVec("louder_geodb_request_duration_seconds",
+		"Time taken by GeoDB API calls, body read and decoded included.", metrics.DefBuckets, "endpoint")
+	geodbErrors = metrics.Default.NewCounterVec("louder_geodb_request_errors_total",
+		"GeoDB API calls that failed, by endpoint and reason: cancelled, timeout, transport, status, decode or circuit_open when the breaker refused the call.", "endpoint", "reason")
+	geodbRetries = metrics.Default.NewCounterVec("louder_geodb_retries_total",
+		"GeoDB API requests sent again after a failed attempt, by endpoint.", "endpoint")
+)
+
+// failure reasons, the errors themselves are too varied for a label
+const (
+	reasonCancelled = "cancelled"
+	reasonTimeout   = "timeout"
+	reasonTransport = "transport"
+	reasonStatus    = "status"
+	reasonDecode    = "decode"
+	reasonOpen      = "circuit_open"
+)
+
+// recordCall records one call to endpoint, status is "" when no response came back and reason "" when the call succeeded
+func recordCall(endpoint, status, reason string, took time.Duration) {
+	if status == "" {
+		status = "none"
+	}
+	geodbRequests.With(endpoint, status).Inc()
+	geodbDuration.With(endpoint).Observe(took.Seconds())
+	if reason != "" {
+		geodbErrors.With(endpoint, reason).Inc()
+	}
+}
@@ -2,28 +2,28 @@ package geodbclient
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"log"
-	"louder/internal/adapters/driven/db/dbcommon"
-	"louder/internal/core/domain"
+	"iter"
+	"louder/pkg/logging"
 	"net/url"
 	"strconv"
 )
 
-type paginator struct {
-	proc       *processor
+type paginator[T any] struct {
+	proc       *processor[T]
 	endpoint   string
+	filter     url.Values // query parameters sent with every page
 	offset     int
 	limit      int
 	totalCount int
 	hasNext    bool
 }
 
-func NewPaginator(proc *processor, endpoint string, limitPerPage int) *paginator {
-	return &paginator{
+func NewPaginator[T any](proc *processor[T], endpoint string, limitPerPage int, filter url.Values) *paginator[T] {
+	return &paginator[T]{
 		proc:       proc,
 		endpoint:   endpoint,
+		filter:     filter,
 		offset:     0,
 		limit:      limitPerPage,
 		totalCount: -1,
@@ -31,27 +31,25 @@ func NewPaginator(proc *processor, endpoint string, limitPerPage int) *paginator
 	}
 }
 
-func (p *paginator) HasNext() bool {
+func (p *paginator[T]) HasNext() bool {
 	return p.hasNext
 }
 
-func (p *paginator) TotalCount() int {
+func (p *paginator[T]) TotalCount() int {
 	return p.totalCount
 }
 
-func (p *paginator) NextPage(ctx context.Context) ([]CountryDTO, error) {
+func (p *paginator[T]) NextPage(ctx context.Context) ([]T, error) {
 	if !p.HasNext() {
 		return nil, nil // no pages, no errors
 	}
 
-	params := url.Values{}
-	params.Set("limit", strconv.Itoa(p.limit))
-	params.Add("offset", strconv.Itoa(p.offset))
-	log.Printf("Paginator: Requesting next page - endpoint: %s, offset: %d, limit: %d", p.endpoint, p.offset, p.limit)
+	params := p.pageParams(p.offset)
+	logging.FromContext(ctx).Debug("Paginator: requesting next page", "endpoint", p.endpoint, "offset", p.offset, "limit", p.limit)
 
 	resultChan := p.proc.execute(ctx, p.endpoint, params)
 
-	var apiResponse *GeoDBAPIResponse
+	var apiResponse *GeoDBAPIResponse[T]
 	var pageErr error
 
 	select {
@@ -68,16 +66,16 @@ func (p *paginator) NextPage(ctx context.Context) ([]CountryDTO, error) {
 			if p.totalCount == -1 && apiResponse != nil {
 				p.totalCount = apiResponse.Metadata.Count
 			}
-			log.Printf("Paginator: Discovered total API count for %s: %d", p.endpoint, p.totalCount)
+			logging.FromContext(ctx).Debug("Paginator: total API count", "endpoint", p.endpoint, "total", p.totalCount)
 
 			switch {
-			// an empty response or no countries received means there are no next pages
-			case apiResponse == nil || len(apiResponse.Countries) == 0:
+			// an empty response or no data received means there are no next pages
+			case apiResponse == nil || len(apiResponse.Data) == 0:
 				p.hasNext = false
 
 			default:
 				// increase the offset by the right amount
-				p.offset += len(apiResponse.Countries)
+				p.offset += len(apiResponse.Data)
 				// if this the first time we get and we've got the total count already (1 page only?)
 				if p.totalCount != -1 && p.offset >= p.totalCount {
 					p.hasNext = false
@@ -98,73 +96,110 @@ func (p *paginator) NextPage(ctx context.Context) ([]CountryDTO, error) {
 
 	// page was empty?
 	if apiResponse == nil {
-		return []CountryDTO{}, nil
+		return []T{}, nil
 	}
 
-	return apiResponse.Countries, nil
+	return apiResponse.Data, nil
 }
 
-// mapDTOToDomainCountry converts an API DTO (CountryDTO) to a domain.Country object
-func (p *Provider) mapDTOToDomainCountry(ctx context.Context, dto CountryDTO) (*domain.Country, error) {
-	if dto.CountryCode == "" {
-		return nil, errors.New("mapDTO: API DTO has empty country code")
-	}
-
-	if dto.CountryName == "" {
-		return nil, errors.New("mapDTO: API DTO has empty country name")
-	}
-
-	countryCode, err := domain.NewCountryCode(dto.CountryCode)
-	if err != nil {
-		return nil, fmt.Errorf("mapDTO: invalid country code '%s' from API: %w", dto.CountryCode, err)
+// pageParams are the query parameters for the page at offset
+func (p *paginator[T]) pageParams(offset int) url.Values {
+	params := url.Values{}
+	for k, v := range p.filter {
+		params[k] = v
 	}
+	params.Set("limit", strconv.Itoa(p.limit))
+	params.Set("offset", strconv.Itoa(offset))
+	return params
+}
 
-	// wikidataid can be null. For now...
-	var wikiID domain.WikiCode
-	if dto.WikiDataId != "" {
-		wikiID, err = domain.NewWikiCode(dto.WikiDataId)
-
-		if err != nil {
-			log.Printf("WARN mapDTO: Invalid WikiDataID format '%s' for country %s. Using empty. Error: %v", dto.WikiDataId, dto.CountryCode, err)
-			wikiID = domain.WikiCode("")
-		}
-	}
+// fetchedPage is a page of DTOs and where it sits in the results
+type fetchedPage[T any] struct {
+	offset     int
+	next       int // offset of the page after it
+	totalCount int // -1 when the API didn't say
+	items      []T
+}
 
-	var domainCurrencies []domain.Currency
-	for _, currencyCodeStr := range dto.CurrencyCodes {
-		if currencyCodeStr == "" {
-			log.Printf("WARN mapDTO: Empty currency code string received for country %s. Skipping.", dto.CountryCode)
-			continue
+// Pages yields the pages from the current offset on, in order. The first one is fetched alone to learn the total count, then up to concurrency requests are in flight, ahead of the consumer by as many pages at most; the client's limiter still paces them. Iteration stops at the first error.
+func (p *paginator[T]) Pages(ctx context.Context, concurrency int) iter.Seq2[fetchedPage[T], error] {
+	return func(yield func(fetchedPage[T], error) bool) {
+		// one page at a time until the total count is known, the offsets can't be planned before
+		for p.HasNext() && (p.totalCount == -1 || concurrency <= 1) {
+			offset := p.offset
+			items, err := p.NextPage(ctx)
+			if err != nil {
+				yield(fetchedPage[T]{}, err)
+				return
+			}
+			if !yield(fetchedPage[T]{offset: offset, next: p.offset, totalCount: p.totalCount, items: items}, nil) {
+				return
+			}
 		}
-
-		cc, err := domain.NewCurrencyCode(currencyCodeStr)
-		if err != nil {
-			log.Printf("WARN mapDTO: Invalid currency code string '%s' for country %s. Skipping this currency. Error: %v", currencyCodeStr, dto.CountryCode, err)
-			continue
+		if !p.HasNext() {
+			return
 		}
 
-		// get the currency from the DB if exists
-		currency, err := p.currencyRepo.GetByID(ctx, cc)
-		if errors.Is(err, dbcommon.ErrNotFound) {
-			log.Printf("WARN mapDTO: Currency %s for country %s not in local DB. Creating placeholder domain object for now.", cc.String(), dto.CountryCode)
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
 
-			placeholderName := fmt.Sprintf("Currency %s (Auto-from API sync)", cc.String())
+		type pending struct {
+			offset int
+			result <-chan processorResult[T]
+		}
+		// a slot is taken for each request sent and given back when the consumer gets its page
+		slots := make(chan struct{}, concurrency)
+		queue := make(chan pending, concurrency)
+
+		// the consumer moves p.offset, the producer plans from a copy
+		start, total := p.offset, p.totalCount
+		go func() {
+			defer close(queue)
+			for offset := start; offset < total; offset += p.limit {
+				select {
+				case slots <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+				logging.FromContext(ctx).Debug("Paginator: requesting page", "endpoint", p.endpoint, "offset", offset, "limit", p.limit)
+				queue <- pending{offset: offset, result: p.proc.execute(ctx, p.endpoint, p.pageParams(offset))}
+			}
+		}()
+
+		defer func() { p.hasNext = false }()
+		for next := range queue {
+			var result processorResult[T]
+			select {
+			case result = <-next.result:
+			case <-ctx.Done():
+				yield(fetchedPage[T]{}, fmt.Errorf("paginator: context cancelled for offset %d (endpoint %s): %w", next.offset, p.endpoint, ctx.Err()))
+				return
+			}
+			<-slots
 
-			newCurrency, ncErr := domain.NewCurrency(cc, placeholderName)
-			if ncErr != nil {
-				log.Printf("ERROR mapDTO: Could not create placeholder domain.Currency %s: %v", cc.String(), ncErr)
-				continue
+			if result.err != nil {
+				yield(fetchedPage[T]{}, fmt.Errorf("paginator: API call failed for offset %d (endpoint %s): %w", next.offset, p.endpoint, result.err))
+				return
+			}
+			// the offsets were planned for full pages, a short one would leave a hole
+			if next.offset != p.offset {
+				yield(fetchedPage[T]{}, fmt.Errorf("paginator: page before offset %d (endpoint %s) came back short, %d missing", next.offset, p.endpoint, next.offset-p.offset))
+				return
 			}
-			domainCurrencies = append(domainCurrencies, *newCurrency)
 
-		} else if err != nil {
-			log.Printf("ERROR mapDTO: Failed to lookup currency %s for country %s: %v. Skipping this currency.", cc.String(), dto.CountryCode, err)
-			continue
+			var items []T
+			if result.response != nil {
+				items = result.response.Data
+			}
+			if len(items) == 0 {
+				// fewer than the total count, the data changed since the first page
+				return
+			}
+			p.offset += len(items)
 
-		} else if currency != nil { // meaning found in db
-			domainCurrencies = append(domainCurrencies, *currency)
+			if !yield(fetchedPage[T]{offset: next.offset, next: p.offset, totalCount: p.totalCount, items: items}, nil) {
+				return
+			}
 		}
 	}
-
-	return domain.NewCountry(countryCode, dto.CountryName, domainCurrencies, wikiID)
 }
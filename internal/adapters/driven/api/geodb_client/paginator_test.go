@@ -0,0 +1,107 @@
+package geodbclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countryServer serves total countries in pages, slower for earlier pages so they come back out of order. It fails the page at failAt, -1 for none.
+func countryServer(t *testing.T, total, failAt int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		now := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxInFlight.Load()
+			if now <= seen || maxInFlight.CompareAndSwap(seen, now) {
+				break
+			}
+		}
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		if offset == failAt {
+			http.Error(w, "nope", http.StatusBadRequest)
+			return
+		}
+		time.Sleep(time.Duration(max(0, 20-offset)+rand.IntN(3)) * time.Millisecond)
+
+		var resp GeoDBAPIResponse[CountryDTO]
+		resp.Metadata.Count = total
+		resp.Metadata.Offset = offset
+		for i := offset; i < min(offset+limit, total); i++ {
+			resp.Data = append(resp.Data, CountryDTO{CountryCode: fmt.Sprintf("%03d", i)})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return server, &maxInFlight
+}
+
+func TestPagesInOrder(t *testing.T) {
+	tests := map[string]struct {
+		start       int
+		concurrency int
+		failAt      int
+		stopAfter   int // pages read before breaking out, 0 for all
+		wantCodes   int
+		wantErr     bool
+	}{
+		"sequential":              {concurrency: 1, failAt: -1, wantCodes: 23},
+		"concurrent":              {concurrency: 3, failAt: -1, wantCodes: 23},
+		"resumed from an offset":  {start: 10, concurrency: 3, failAt: -1, wantCodes: 13},
+		"error after some pages":  {concurrency: 3, failAt: 15, wantCodes: 15, wantErr: true},
+		"consumer stops early":    {concurrency: 3, failAt: -1, stopAfter: 2, wantCodes: 10},
+		"more workers than pages": {concurrency: 10, failAt: -1, wantCodes: 23},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			server, maxInFlight := countryServer(t, 23, tc.failAt)
+			pg := NewPaginator(newProcessor[CountryDTO](NewHTTPClient(server.URL, "key", Resilience{}, nil)), "/v1/geo/countries", 5, nil)
+			pg.offset = tc.start
+
+			want, pages := tc.start, 0
+			var gotErr error
+			for page, err := range pg.Pages(t.Context(), tc.concurrency) {
+				if err != nil {
+					gotErr = err
+					break
+				}
+				if page.offset != want {
+					t.Fatalf("page at offset %d, want %d", page.offset, want)
+				}
+				for _, dto := range page.items {
+					if dto.CountryCode != fmt.Sprintf("%03d", want) {
+						t.Fatalf("country %s at position %d", dto.CountryCode, want)
+					}
+					want++
+				}
+				if page.next != want || page.totalCount != 23 {
+					t.Errorf("page next = %d of %d, want %d of 23", page.next, page.totalCount, want)
+				}
+				if pages++; pages == tc.stopAfter {
+					break
+				}
+			}
+
+			if (gotErr != nil) != tc.wantErr {
+				t.Errorf("err = %v, want error: %v", gotErr, tc.wantErr)
+			}
+			if got := want - tc.start; got != tc.wantCodes {
+				t.Errorf("got %d countries, want %d", got, tc.wantCodes)
+			}
+			if got := int(maxInFlight.Load()); got > max(tc.concurrency, 1) {
+				t.Errorf("%d requests in flight at once, want at most %d", got, tc.concurrency)
+			}
+		})
+	}
+}
@@ -2,37 +2,42 @@ package geodbclient
 
 import (
 	"context"
-	"log"
+	"louder/pkg/logging"
 	"net/url"
 )
 
-type processorResult struct {
-	response *GeoDBAPIResponse
+type processorResult[T any] struct {
+	response *GeoDBAPIResponse[T]
 	err      error
 }
 
-type processor struct {
+type processor[T any] struct {
 	httpClient *httpClient
 }
 
-func newProcessor(client *httpClient) *processor {
-	return &processor{
+func newProcessor[T any](client *httpClient) *processor[T] {
+	return &processor[T]{
 		httpClient: client,
 	}
 }
 
 // execute performs the API call asynchronously and send the result to a channel
-func (p *processor) execute(ctx context.Context, endpoint string, params url.Values) <-chan processorResult {
-	resultChan := make(chan processorResult, 1)
+func (p *processor[T]) execute(ctx context.Context, endpoint string, params url.Values) <-chan processorResult[T] {
+	resultChan := make(chan processorResult[T], 1)
 
 	go func() {
 		defer close(resultChan)
-		log.Printf("Processor: Goroutine starting API call to endpoint '%s' with params: %v", endpoint, params)
+		logging.FromContext(ctx).Debug("Processor: goroutine starting API call", "endpoint", endpoint, "params", params.Encode())
 
-		response, err := p.httpClient.queryAPI(ctx, endpoint, params)
+		var response GeoDBAPIResponse[T]
+		err := p.httpClient.queryAPI(ctx, endpoint, params, &response)
 		// I won't handle the error here, instead send that in the channel for someone else to deal with
+		if err != nil {
+			resultChan <- processorResult[T]{err: err}
+			return
+		}
 
-		resultChan <- processorResult{response: response, err: err}
+		resultChan <- processorResult[T]{response: &response}
 	}()
 
 	return resultChan
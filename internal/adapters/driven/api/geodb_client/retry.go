@@ -0,0 +1,66 @@
+package geodbclient
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Resilience tunes how the client copes with a struggling GeoDB, the zero value makes a single attempt and never opens the breaker
+type Resilience struct {
+	MaxAttempts      int           // tries per call, retries happen on 429, 5xx and network errors
+	BaseDelay        time.Duration // backoff before the first retry, doubled for each one after it
+	MaxDelay         time.Duration // cap of a backoff, a longer Retry-After ends the retries instead of being waited for
+	FailureThreshold int           // consecutive failed requests that open the circuit breaker, 0 never opens it
+	OpenFor          time.Duration // how long an open breaker fails calls before letting a trial one through
+}
+
+// backoff returns how long to wait before retry number retry (1 for the first), drawn at random up to BaseDelay * 2^(retry-1) capped at MaxDelay ("full jitter") so many clients don't retry in step
+func (r Resilience) backoff(retry int) time.Duration {
+	ceiling := r.BaseDelay << min(retry-1, 30)
+	if ceiling <= 0 || (r.MaxDelay > 0 && ceiling > r.MaxDelay) {
+		ceiling = r.MaxDelay
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return rand.N(ceiling) + 1
+}
+
+// retryable reports whether another attempt might succeed after a response with status, 0 when the request failed without one
+func retryable(status int) bool {
+	return status == 0 || status == http.StatusTooManyRequests || status >= 500
+}
+
+// parseRetryAfter reads a Retry-After header, either seconds or an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// sleepContext waits for d unless ctx is done first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -1,6 +1,7 @@
 package bunadapter
 
 import (
+	"database/sql"
 	"fmt"
 	dbcommon "louder/internal/adapters/driven/db/dbcommon"
 	"louder/internal/core/domain"
@@ -17,6 +18,11 @@ type BunModelPerson struct {
 	LastName  string          `bun:"last_name"`
 	Email     string          `bun:"email"`
 	DOB       types.UTCTime   `bun:"dob"`
+
+	BirthCountryCode     sql.NullString `bun:"birth_country_code"`
+	ResidenceCountryCode sql.NullString `bun:"residence_country_code"`
+
+	DeletedAt sql.NullString `bun:"deleted_at"`
 }
 
 // mappers
@@ -31,6 +37,11 @@ func toBunModelPerson(p *domain.Person) *BunModelPerson {
 		LastName:  p.LastName(),
 		Email:     p.Email(),
 		DOB:       p.DOB(),
+
+		BirthCountryCode:     dbcommon.NullCountryCode(p.BirthCountry()),
+		ResidenceCountryCode: dbcommon.NullCountryCode(p.ResidenceCountry()),
+
+		DeletedAt: dbcommon.NullTime(p.DeletedAt()),
 	}
 }
 
@@ -39,6 +50,12 @@ func (m *BunModelPerson) toDomainPerson() (*domain.Person, error) {
 		return nil, fmt.Errorf("%w", dbcommon.ErrHydrateWithNil)
 	}
 
+	deletedAt, err := dbcommon.TimeFromNull(m.DeletedAt)
+	if err != nil {
+		return nil, fmt.Errorf("parsing deleted_at of person %s: %w", m.ID.String(), err)
+	}
+
 	return domain.HydratePerson(
-		m.ID, m.FirstName, m.LastName, m.Email, m.DOB), nil
+		m.ID, m.FirstName, m.LastName, m.Email, m.DOB,
+		dbcommon.CountryCodeFromNull(m.BirthCountryCode), dbcommon.CountryCodeFromNull(m.ResidenceCountryCode), deletedAt), nil
 }
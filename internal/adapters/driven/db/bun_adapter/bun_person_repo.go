@@ -5,10 +5,12 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"log"
 	dbcommon "louder/internal/adapters/driven/db/dbcommon"
 	"louder/internal/core/domain"
+	"louder/internal/core/service/auditcore"
 	"louder/internal/core/service/personcore"
+	"louder/pkg/logging"
+	"time"
 
 	"github.com/gofrs/uuid/v5"
 	"github.com/uptrace/bun"
@@ -28,23 +30,52 @@ var _ personcore.PersonRepository = (*BunPersonRepo)(nil)
 
 func NewBunPersonRepo(sqldb *sql.DB) (*BunPersonRepo, error) {
 	db := bun.NewDB(sqldb, sqlitedialect.New())
+	db.AddQueryHook(queryTracer{})
 	return &BunPersonRepo{
 		db: db,
 	}, nil
 }
 
+// Save inserts or updates the person and records the change in the audit trail, in one transaction
 func (bpr *BunPersonRepo) Save(ctx context.Context, person *domain.Person) (*domain.Person, error) {
 
 	// convert from domain.Person to BunPersonModel first
 	bunModel := toBunModelPerson(person)
 
-	fmt.Println(bunModel)
 	if bunModel == nil {
 		return nil, dbcommon.ErrConvertNilPerson
 	}
-	result, err := bpr.db.NewInsert().Model(bunModel).On("CONFLICT (id) DO UPDATE").Exec(ctx)
+
+	var result sql.Result
+	err := bpr.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var before domain.AuditSnapshot
+		existing, err := getByID(ctx, tx, person.ID(), true)
+		switch {
+		case err == nil:
+			before = existing.AuditSnapshot()
+		case !errors.Is(err, dbcommon.ErrNotFound):
+			return err
+		}
+
+		result, err = tx.NewInsert().Model(bunModel).On("CONFLICT (id) DO UPDATE").Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		entry, err := auditcore.EntryFor(ctx, domain.AuditPerson, person.ID().String(), before, person.AuditSnapshot())
+		if err != nil {
+			return err
+		}
+		if err := dbcommon.InsertAuditEntries(ctx, tx, entry); err != nil {
+			return err
+		}
+		if person.Erased() {
+			return dbcommon.RedactAuditFields(ctx, tx, domain.AuditPerson, person.ID().String(), domain.PersonalDataFields...)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("%w (ID:%s): %w", ErrBunSavePerson, person.ID().String(), err)
+		return nil, fmt.Errorf("%w (ID:%s): %w", ErrBunSavePerson, person.ID().String(), dbcommon.TranslateSQLiteError(err))
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -52,14 +83,14 @@ func (bpr *BunPersonRepo) Save(ctx context.Context, person *domain.Person) (*dom
 
 	switch {
 	case err != nil:
-		log.Printf("Bun: Warning - couldn't get rows affected for ID: %s, %v", person.ID().String(), err)
+		logging.FromContext(ctx).Warn("Bun: couldn't get rows affected", "person_id", person.ID().String(), "err", err)
 
 	case rowsAffected == 0:
-		log.Printf("Bun: Info - 0 rows affected for ID: %s. Identical to record?\n", person.ID().String())
+		logging.FromContext(ctx).Info("Bun: 0 rows affected, identical to record?", "person_id", person.ID().String())
 
 	default:
-		log.Printf("Bun: Successfully saved/updated person ID %s. Fetching current state.", person.ID().String())
-		createdPerson, err = bpr.GetByID(ctx, person.ID())
+		logging.FromContext(ctx).Debug("Bun: saved/updated person, fetching current state", "person_id", person.ID().String())
+		createdPerson, err = bpr.GetByIDIncludingDeleted(ctx, person.ID())
 		if err != nil {
 			return nil, fmt.Errorf("%w, ID: %s, %w", dbcommon.ErrSavedButNotInDB, person.ID().String(), err)
 		}
@@ -68,6 +99,16 @@ func (bpr *BunPersonRepo) Save(ctx context.Context, person *domain.Person) (*dom
 }
 
 func (bpr *BunPersonRepo) GetByID(ctx context.Context, pid domain.PersonID) (*domain.Person, error) {
+	return getByID(ctx, bpr.db, pid, false)
+}
+
+// GetByIDIncludingDeleted is GetByID for soft deleted people too
+func (bpr *BunPersonRepo) GetByIDIncludingDeleted(ctx context.Context, pid domain.PersonID) (*domain.Person, error) {
+	return getByID(ctx, bpr.db, pid, true)
+}
+
+// getByID reads a person with db, which can be a transaction
+func getByID(ctx context.Context, db bun.IDB, pid domain.PersonID, includeDeleted bool) (*domain.Person, error) {
 	// check if pid is empty
 	if uuid.UUID(pid).IsNil() {
 		return nil, dbcommon.ErrEmptyID
@@ -85,7 +126,11 @@ func (bpr *BunPersonRepo) GetByID(ctx context.Context, pid domain.PersonID) (*do
 
 	bunModel := new(BunModelPerson)
 
-	err := bpr.db.NewSelect().Model(bunModel).Where("id = ?", pid).Scan(ctx)
+	q := db.NewSelect().Model(bunModel).Where("id = ?", pid)
+	if !includeDeleted {
+		q = q.Where("deleted_at IS NULL")
+	}
+	err := q.Scan(ctx)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -96,17 +141,37 @@ func (bpr *BunPersonRepo) GetByID(ctx context.Context, pid domain.PersonID) (*do
 
 	retrievedPerson, err := bunModel.toDomainPerson()
 	if err != nil {
-		log.Printf("error BunPersonRepo.GetByID - Failed to convert BunModelPerson to domain.Person for ID '%s'. Model: %+v. Error: %v", pid.String(), bunModel, err)
+		logging.FromContext(ctx).Error("BunPersonRepo.GetByID: failed to convert BunModelPerson to domain.Person", "person_id", pid.String(), "model", fmt.Sprintf("%+v", bunModel), "err", err)
 		return nil, fmt.Errorf("%w, ID: %s, %w", dbcommon.ErrConvertToPerson, pid.String(), err)
 	}
 
 	return retrievedPerson, nil
 }
 
+// GetByEmail returns the person with the given (already normalised) email
+func (bpr *BunPersonRepo) GetByEmail(ctx context.Context, email string) (*domain.Person, error) {
+	bunModel := new(BunModelPerson)
+
+	err := bpr.db.NewSelect().Model(bunModel).Where("email = ?", email).Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w for email '%s'", dbcommon.ErrNotFound, email)
+		}
+		return nil, fmt.Errorf("%w email: %s, %w", dbcommon.ErrDBQueryFailed, email, err)
+	}
+
+	retrievedPerson, err := bunModel.toDomainPerson()
+	if err != nil {
+		return nil, fmt.Errorf("%w, email: %s, %w", dbcommon.ErrConvertToPerson, email, err)
+	}
+
+	return retrievedPerson, nil
+}
+
 func (bpr *BunPersonRepo) GetAll(ctx context.Context) ([]domain.Person, error) {
 	var dbModels []BunModelPerson
 
-	err := bpr.db.NewSelect().Model(&dbModels).Scan(ctx)
+	err := bpr.db.NewSelect().Model(&dbModels).Where("deleted_at IS NULL").Scan(ctx)
 
 	if err != nil {
 		return nil, fmt.Errorf("GetAllPersons: %w: %w", dbcommon.ErrDBQueryFailed, err)
@@ -119,9 +184,9 @@ func (bpr *BunPersonRepo) GetAll(ctx context.Context) ([]domain.Person, error) {
 
 		switch {
 		case err != nil:
-			log.Printf("GetAllPersons: %v (ID: %s): %v. Skipping.", dbcommon.ErrConvertToPerson, dbModels[i].ID.String(), err)
+			logging.FromContext(ctx).Warn("GetAllPersons: skipping row", "person_id", dbModels[i].ID.String(), "err", fmt.Errorf("%w: %w", dbcommon.ErrConvertToPerson, err))
 		case domainPerson == nil:
-			log.Printf("GetAllPersons: %v (ID: %s). Skipping.", dbcommon.ErrNilDomainPerson, dbModels[i].ID.String())
+			logging.FromContext(ctx).Warn("GetAllPersons: skipping row", "person_id", dbModels[i].ID.String(), "err", dbcommon.ErrNilDomainPerson)
 		default:
 			allPersons = append(allPersons, *domainPerson)
 		}
@@ -129,3 +194,111 @@ func (bpr *BunPersonRepo) GetAll(ctx context.Context) ([]domain.Person, error) {
 
 	return allPersons, nil
 }
+
+// ListIDs returns the IDs of every person matching the filter, in storage order
+func (bpr *BunPersonRepo) ListIDs(ctx context.Context, filter domain.PersonFilter) ([]domain.PersonID, error) {
+	var ids []domain.PersonID
+
+	q := bpr.db.NewSelect().Model((*BunModelPerson)(nil)).Column("id").OrderExpr("rowid")
+	if filter.BirthCountryCode != "" {
+		q = q.Where("birth_country_code = ?", filter.BirthCountryCode.String())
+	}
+	if filter.ResidenceCountryCode != "" {
+		q = q.Where("residence_country_code = ?", filter.ResidenceCountryCode.String())
+	}
+	if !filter.IncludeDeleted {
+		q = q.Where("deleted_at IS NULL")
+	}
+
+	if err := q.Scan(ctx, &ids); err != nil {
+		return nil, fmt.Errorf("ListIDs: %w: %w", dbcommon.ErrDBQueryFailed, err)
+	}
+
+	return ids, nil
+}
+
+// SaveBatch inserts all the given people in a single transaction along with their audit entries, either all of them are saved or none are
+func (bpr *BunPersonRepo) SaveBatch(ctx context.Context, people []*domain.Person) error {
+	bunModels := make([]*BunModelPerson, 0, len(people))
+	entries := make([]*domain.AuditEntry, 0, len(people))
+	for _, person := range people {
+		bunModel := toBunModelPerson(person)
+		if bunModel == nil {
+			return dbcommon.ErrConvertNilPerson
+		}
+		bunModels = append(bunModels, bunModel)
+
+		entry, err := auditcore.EntryFor(ctx, domain.AuditPerson, person.ID().String(), nil, person.AuditSnapshot())
+		if err != nil {
+			return fmt.Errorf("%w (%d people): %w", dbcommon.ErrSaveBatch, len(people), err)
+		}
+		entries = append(entries, entry)
+	}
+
+	err := bpr.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(&bunModels).Exec(ctx); err != nil {
+			return err
+		}
+		return dbcommon.InsertAuditEntries(ctx, tx, entries...)
+	})
+	if err != nil {
+		return fmt.Errorf("%w (%d people): %w", dbcommon.ErrSaveBatch, len(people), dbcommon.TranslateSQLiteError(err))
+	}
+
+	return nil
+}
+
+// PurgeDeleted hard deletes everyone soft deleted before deletedBefore, recording each removal in the audit trail within the same transaction
+func (bpr *BunPersonRepo) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int, error) {
+	var purged int
+
+	err := bpr.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var bunModels []BunModelPerson
+		err := tx.NewSelect().Model(&bunModels).
+			Where("deleted_at IS NOT NULL").
+			Where("deleted_at < ?", dbcommon.FormatTime(deletedBefore)).
+			Scan(ctx)
+		if err != nil {
+			return err
+		}
+		if len(bunModels) == 0 {
+			return nil
+		}
+
+		ids := make([]domain.PersonID, 0, len(bunModels))
+		entries := make([]*domain.AuditEntry, 0, len(bunModels))
+		for i := range bunModels {
+			person, err := bunModels[i].toDomainPerson()
+			if err != nil {
+				return fmt.Errorf("%w, ID: %s, %w", dbcommon.ErrConvertToPerson, bunModels[i].ID.String(), err)
+			}
+
+			entry, err := auditcore.EntryFor(ctx, domain.AuditPerson, person.ID().String(), person.AuditSnapshot(), nil)
+			if err != nil {
+				return err
+			}
+			ids = append(ids, person.ID())
+			entries = append(entries, entry)
+		}
+
+		result, err := tx.NewDelete().Model((*BunModelPerson)(nil)).Where("id IN (?)", bun.In(ids)).Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if err := dbcommon.InsertAuditEntries(ctx, tx, entries...); err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		purged = int(affected)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", dbcommon.ErrPurgePeople, dbcommon.TranslateSQLiteError(err))
+	}
+
+	return purged, nil
+}
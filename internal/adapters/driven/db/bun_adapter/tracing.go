@@ -0,0 +1,44 @@
+package bunadapter
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"louder/pkg/tracing"
+
+	"github.com/uptrace/bun"
+)
+
+// queryTracer is a bun hook giving each query a span. Bun builds its queries rather than reading named ones, so spans go by operation and table, "bun SELECT person".
+//
+// The span is kept in the event rather than the returned context: bun holds on to the context of BEGIN for the whole transaction, the statements would otherwise nest under it.
+type queryTracer struct{}
+
+type querySpanKey struct{}
+
+var _ bun.QueryHook = queryTracer{}
+
+func (queryTracer) BeforeQuery(ctx context.Context, event *bun.QueryEvent) context.Context {
+	name := "bun " + event.Operation()
+	if q, ok := event.IQuery.(interface{ GetTableName() string }); ok && q.GetTableName() != "" {
+		name += " " + q.GetTableName()
+	}
+
+	_, span := tracing.StartWithKind(ctx, tracing.KindClient, name,
+		tracing.String("db.system", "sqlite"),
+		tracing.String("db.operation.name", event.Operation()),
+	)
+	if event.Stash == nil {
+		event.Stash = make(map[any]any)
+	}
+	event.Stash[querySpanKey{}] = span
+	return ctx
+}
+
+func (queryTracer) AfterQuery(_ context.Context, event *bun.QueryEvent) {
+	span, _ := event.Stash[querySpanKey{}].(*tracing.Span)
+	if !errors.Is(event.Err, sql.ErrNoRows) {
+		span.RecordError(event.Err)
+	}
+	span.End()
+}
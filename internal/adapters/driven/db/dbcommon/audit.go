@@ -0,0 +1,71 @@
+package dbcommon
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"louder/internal/core/domain"
+	"strings"
+)
+
+// both repos write audit entries, so the statement lives here rather than with either adapter's queries
+const insertAuditEntryQuery = `INSERT INTO audit_log (id, occurred_at, actor, action, entity_type, entity_id, changes, request_id)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+
+// Execer runs a statement, sqlx and Bun transactions both are
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// InsertAuditEntries writes the entries with tx, the transaction making the change they record. Nil entries (nothing changed) are skipped.
+func InsertAuditEntries(ctx context.Context, tx Execer, entries ...*domain.AuditEntry) error {
+	for _, entry := range entries {
+		if entry == nil {
+			continue
+		}
+
+		changes, err := json.Marshal(entry.Changes())
+		if err != nil {
+			return fmt.Errorf("%w for %s %s: %w", ErrSaveAuditEntry, entry.EntityType(), entry.EntityID(), err)
+		}
+
+		requestID := sql.NullString{String: entry.RequestID(), Valid: entry.RequestID() != ""}
+		qctx, done := TraceQuery(ctx, "InsertAuditEntry")
+		_, err = tx.ExecContext(qctx, insertAuditEntryQuery,
+			entry.ID(), FormatTime(entry.OccurredAt()), entry.Actor(), string(entry.Action()),
+			string(entry.EntityType()), entry.EntityID(), string(changes), requestID)
+		done(err)
+		if err != nil {
+			return fmt.Errorf("%w for %s %s: %w", ErrSaveAuditEntry, entry.EntityType(), entry.EntityID(), TranslateSQLiteError(err))
+		}
+	}
+	return nil
+}
+
+// RedactAuditFields overwrites the values of fields in every audit entry of an entity with domain.AuditRedacted, so an erasure isn't undone by reading its history. It runs in tx like the erasure itself.
+func RedactAuditFields(ctx context.Context, tx Execer, entityType domain.AuditEntityType, entityID string, fields ...string) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	// json_replace leaves paths that don't exist alone, a field missing from an entry stays missing
+	paths := make([]string, 0, 2*len(fields))
+	args := make([]any, 0, 2*len(fields)+2)
+	for _, field := range fields {
+		for _, side := range []string{"before", "after"} {
+			paths = append(paths, "?, ?")
+			args = append(args, "$."+field+"."+side, domain.AuditRedacted)
+		}
+	}
+	args = append(args, string(entityType), entityID)
+
+	query := `UPDATE audit_log SET changes = json_replace(changes, ` + strings.Join(paths, ", ") + `) WHERE entity_type = ? AND entity_id = ?`
+	qctx, done := TraceQuery(ctx, "RedactAuditFields")
+	_, err := tx.ExecContext(qctx, query, args...)
+	done(err)
+	if err != nil {
+		return fmt.Errorf("%w: redacting %s %s: %w", ErrSaveAuditEntry, entityType, entityID, TranslateSQLiteError(err))
+	}
+	return nil
+}
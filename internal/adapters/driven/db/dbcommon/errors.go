@@ -1,21 +1,26 @@
 package dbcommon
 
-import "errors"
+import (
+	"errors"
+	"louder/internal/core/errkind"
+)
 
 // errors for person
 var (
 	ErrHydrateWithNil   = errors.New("error attempted to hydrate person without data")
 	ErrSaveNilPerson    = errors.New("error cannot save nil person to DB model")
 	ErrConvertNilPerson = errors.New("error convert nil person to DB model")
-	ErrEmptyID          = errors.New("error given ID is empty")
-	ErrInvalidID        = errors.New("error invalid person ID format")
+	ErrEmptyID          = errkind.New(errkind.Invalid, "error given ID is empty")
+	ErrInvalidID        = errkind.New(errkind.Invalid, "error invalid person ID format")
 	ErrNilDomainPerson  = errors.New("error conversion returned nil domain person without error")
 	ErrConvertToPerson  = errors.New("error converting SQLx/Bun data to a person")
+	ErrSaveBatch        = errors.New("error saving batch of people")
+	ErrPurgePeople      = errors.New("error purging deleted people")
 )
 
 // common db errors
 var (
-	ErrNotFound             = errors.New("error cannot find this ID in DB")
+	ErrNotFound             = errkind.New(errkind.NotFound, "error cannot find this ID in DB")
 	ErrSavedButNotInDB      = errors.New("error SQLx/Bun person saved but can't find in DB")
 	ErrDBQueryFailed        = errors.New("error query has failed")
 	ErrSQLxSavedButNotInDB  = errors.New("error SQLx entity saved but could not get from DB")
@@ -25,6 +30,7 @@ var (
 	ErrSQLxQueryFailed      = errors.New("error SQLx failed to run the query")
 	ErrSQLxNoRowsAffected   = errors.New("error SQLx could not get rows affected")
 	ErrSQLxZeroRowsAffected = errors.New("error SQLx got 0 rows affected. Upsert?")
+	ErrDuplicate            = errkind.New(errkind.Conflict, "error value already exists in DB")
 )
 
 // errors for Country
@@ -45,6 +51,32 @@ var (
 	ErrConvertNilCurrency = errors.New("error converting nil currency to DB model")
 	ErrSaveCurrency       = errors.New("error could not save currency to DB model")
 	ErrNoCurrencyCode     = errors.New("error currency code must be provided")
-	ErrSQLxNotFound       = errors.New("error SQLx value not in DB")
+	ErrSQLxNotFound       = errkind.New(errkind.NotFound, "error SQLx value not in DB")
 	ErrConvertToCurrency  = errors.New("error converting DB data to currency model")
 )
+
+// errors for API keys
+var (
+	ErrConvertNilAPIKey = errors.New("error converting nil API key to DB model")
+	ErrSaveAPIKey       = errors.New("error could not save API key to DB")
+)
+
+// errors for sync jobs
+var (
+	ErrConvertNilSyncJob = errors.New("error converting nil sync job to DB model")
+	ErrSaveSyncJob       = errors.New("error could not save sync job to DB")
+)
+
+// errors for regions and cities
+var (
+	ErrConvertNilRegion = errors.New("error converting nil region to DB model")
+	ErrSaveRegion       = errors.New("error could not save region to DB")
+	ErrConvertNilCity   = errors.New("error converting nil city to DB model")
+	ErrSaveCity         = errors.New("error could not save city to DB")
+)
+
+// errors for the audit trail
+var (
+	ErrSaveAuditEntry    = errors.New("error could not save audit entry to DB")
+	ErrConvertAuditEntry = errors.New("error converting DB data to an audit entry")
+)
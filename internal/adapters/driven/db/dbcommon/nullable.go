@@ -0,0 +1,41 @@
+package dbcommon
+
+import (
+	"database/sql"
+	"louder/internal/core/domain"
+	"time"
+)
+
+// NullCountryCode maps an optional country code to a nullable column, an empty code is stored as NULL
+func NullCountryCode(cc domain.CountryCode) sql.NullString {
+	return sql.NullString{String: cc.String(), Valid: cc != ""}
+}
+
+// CountryCodeFromNull maps a nullable column back to a country code, NULL becomes an empty code
+func CountryCodeFromNull(ns sql.NullString) domain.CountryCode {
+	if !ns.Valid {
+		return ""
+	}
+	return domain.CountryCode(ns.String)
+}
+
+// FormatTime writes times the way the CHECK constraints expect them, RFC3339 in UTC
+func FormatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// NullTime stores a zero time as NULL
+func NullTime(t time.Time) sql.NullString {
+	if t.IsZero() {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: FormatTime(t), Valid: true}
+}
+
+// TimeFromNull maps a nullable time column back to a time, NULL becomes the zero time
+func TimeFromNull(ns sql.NullString) (time.Time, error) {
+	if !ns.Valid {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, ns.String)
+}
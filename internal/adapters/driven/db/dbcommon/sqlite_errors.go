@@ -0,0 +1,26 @@
+package dbcommon
+
+import (
+	"errors"
+	"fmt"
+	"louder/internal/core/errkind"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// TranslateSQLiteError maps SQLite constraint violations to the dbcommon errors and a busy/locked DB to an Unavailable error, so callers don't need to know about the driver. Other errors are returned untouched.
+func TranslateSQLiteError(err error) error {
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) {
+		return err
+	}
+
+	switch {
+	case sqliteErr.Code == sqlite3.ErrBusy, sqliteErr.Code == sqlite3.ErrLocked:
+		return errkind.Wrap(errkind.Unavailable, err)
+	case sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique, sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey:
+		return fmt.Errorf("%w: %w", ErrDuplicate, err)
+	}
+
+	return err
+}
@@ -0,0 +1,22 @@
+package dbcommon
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"louder/pkg/tracing"
+)
+
+// TraceQuery starts a span for running the named query, sqlx queries go by their GetQuery name. Calling done with the query's error ends it, sql.ErrNoRows is a normal outcome and doesn't fail the span.
+func TraceQuery(ctx context.Context, name string) (_ context.Context, done func(error)) {
+	ctx, span := tracing.StartWithKind(ctx, tracing.KindClient, name,
+		tracing.String("db.system", "sqlite"),
+		tracing.String("db.query.name", name),
+	)
+	return ctx, func(err error) {
+		if !errors.Is(err, sql.ErrNoRows) {
+			span.RecordError(err)
+		}
+		span.End()
+	}
+}
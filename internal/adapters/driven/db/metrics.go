@@ -0,0 +1,32 @@
+package sqlitedbadapter
+
+import (
+	"database/sql"
+	"louder/pkg/metrics"
+)
+
+// RegisterPoolMetrics exposes db's connection pool stats on the default registry, read from db.Stats at scrape time. Call it once, the metric names can only be taken once.
+func RegisterPoolMetrics(db *sql.DB) {
+	stat := func(read func(sql.DBStats) float64) func() float64 {
+		return func() float64 { return read(db.Stats()) }
+	}
+
+	metrics.Default.NewGaugeFunc("louder_db_max_open_connections", "Maximum number of open connections to the database, 0 for no limit.",
+		stat(func(s sql.DBStats) float64 { return float64(s.MaxOpenConnections) }))
+	metrics.Default.NewGaugeFunc("louder_db_open_connections", "Connections to the database, in use or idle.",
+		stat(func(s sql.DBStats) float64 { return float64(s.OpenConnections) }))
+	metrics.Default.NewGaugeFunc("louder_db_in_use_connections", "Connections to the database currently in use.",
+		stat(func(s sql.DBStats) float64 { return float64(s.InUse) }))
+	metrics.Default.NewGaugeFunc("louder_db_idle_connections", "Idle connections to the database.",
+		stat(func(s sql.DBStats) float64 { return float64(s.Idle) }))
+	metrics.Default.NewCounterFunc("louder_db_wait_count_total", "Times a caller had to wait for a database connection.",
+		stat(func(s sql.DBStats) float64 { return float64(s.WaitCount) }))
+	metrics.Default.NewCounterFunc("louder_db_wait_duration_seconds_total", "Time spent waiting for database connections.",
+		stat(func(s sql.DBStats) float64 { return s.WaitDuration.Seconds() }))
+	metrics.Default.NewCounterFunc("louder_db_max_idle_closed_total", "Connections closed because of the idle connection limit.",
+		stat(func(s sql.DBStats) float64 { return float64(s.MaxIdleClosed) }))
+	metrics.Default.NewCounterFunc("louder_db_max_idle_time_closed_total", "Connections closed because they sat idle for too long.",
+		stat(func(s sql.DBStats) float64 { return float64(s.MaxIdleTimeClosed) }))
+	metrics.Default.NewCounterFunc("louder_db_max_lifetime_closed_total", "Connections closed because they reached their maximum lifetime.",
+		stat(func(s sql.DBStats) float64 { return float64(s.MaxLifetimeClosed) }))
+}
@@ -1,15 +1,18 @@
 package sqlitedbadapter
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
-	"log"
+	"io/fs"
+	"log/slog"
 	"os"
 	"path/filepath"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	"github.com/golang-migrate/migrate/v4/source"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	_ "github.com/mattn/go-sqlite3" // SQLite driver
 )
@@ -21,6 +24,8 @@ var (
 	ErrDBPing          = errors.New("failed to ping sqlite3 DB")
 	ErrMigrationDriver = errors.New("failed to create migration driver")
 	ErrMigrationRun    = errors.New("failed to run migrations")
+	ErrMigrationSource = errors.New("failed to read migration files")
+	ErrSchemaOutdated  = errors.New("database schema is not at the latest migration")
 )
 
 func Init(dbFilePath string) (*sql.DB, error) {
@@ -32,7 +37,7 @@ func Init(dbFilePath string) (*sql.DB, error) {
 			return nil, fmt.Errorf("%w %s: %w", ErrDBFolder, dbDir, err)
 		}
 	}
-	log.Printf("DB directory '%s' created", dbDir)
+	slog.Info("DB directory ready", "dir", dbDir)
 
 	// sqlite pragma options: fkeys on and wal on
 	dsn := fmt.Sprintf("file:%s?cache=shared&_pragma=foreign_keys(1)&_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)", dbFilePath)
@@ -45,7 +50,7 @@ func Init(dbFilePath string) (*sql.DB, error) {
 		return nil, fmt.Errorf("%w %w", ErrDBPing, err)
 	}
 
-	log.Printf("Successfully connected to sqlite3 DB: %s", dbFilePath)
+	slog.Info("connected to sqlite3 DB", "path", dbFilePath)
 	return db, nil
 }
 
@@ -63,16 +68,74 @@ func RunMigrations(db *sql.DB, migrationsPath string) error {
 		return fmt.Errorf("%w: failed to create migration instance: %w", ErrMigrationDriver, err)
 	}
 
-	log.Printf("Running migrations from: %s", migrationsPath)
+	slog.Info("running migrations", "path", migrationsPath)
 
 	if err = m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
 		return fmt.Errorf("%w: %w", ErrMigrationRun, err)
 	}
 
-	log.Println("Database migration check completed successfully.")
+	slog.Info("database migration check completed")
 	return nil
 }
 
+// Ping checks the DB still answers, for readiness probes
+func Ping(ctx context.Context, db *sql.DB) error {
+	if err := db.PingContext(ctx); err != nil {
+		return fmt.Errorf("%w %w", ErrDBPing, err)
+	}
+	return nil
+}
+
+// NewMigrationCheck returns a readiness check that the schema is at the newest migration in migrationsPath and not left dirty by a failed one. The files are read once here, they don't change while the app runs.
+func NewMigrationCheck(db *sql.DB, migrationsPath string) (func(ctx context.Context) error, error) {
+	latest, err := latestMigration(migrationsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) error {
+		var version uint
+		var dirty bool
+		// golang-migrate's own table, there is a single row
+		err := db.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1;`).Scan(&version, &dirty)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return fmt.Errorf("%w: no migration applied, want %d", ErrSchemaOutdated, latest)
+		case err != nil:
+			return fmt.Errorf("reading schema version: %w", err)
+		case dirty:
+			return fmt.Errorf("%w: migration %d failed half way, the schema is dirty", ErrSchemaOutdated, version)
+		case version != latest:
+			return fmt.Errorf("%w: at %d, want %d", ErrSchemaOutdated, version, latest)
+		}
+		return nil
+	}, nil
+}
+
+// latestMigration returns the version of the newest migration file
+func latestMigration(migrationsPath string) (uint, error) {
+	src, err := source.Open("file://" + migrationsPath)
+	if err != nil {
+		return 0, fmt.Errorf("%w %s: %w", ErrMigrationSource, migrationsPath, err)
+	}
+	defer src.Close()
+
+	version, err := src.First()
+	if err != nil {
+		return 0, fmt.Errorf("%w %s: %w", ErrMigrationSource, migrationsPath, err)
+	}
+	for {
+		next, err := src.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("%w %s: %w", ErrMigrationSource, migrationsPath, err)
+		}
+		version = next
+	}
+}
+
 // func CreateSchema(db *sql.DB) error {
 // 	personSchema := `
 // 		CREATE TABLE IF NOT EXISTS person (
@@ -88,7 +151,7 @@ func RunMigrations(db *sql.DB, migrationsPath string) error {
 // 		return fmt.Errorf("%w: %w", ErrSchema, err)
 // 	}
 
-// 	log.Println("'person' schema created successfully")
+// 	slog.Info("'person' schema created successfully")
 
 // 	return nil
 // }
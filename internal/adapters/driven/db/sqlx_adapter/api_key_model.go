@@ -0,0 +1,59 @@
+package sqlxadapter
+
+import (
+	"database/sql"
+	"fmt"
+	dbcommon "louder/internal/adapters/driven/db/dbcommon"
+	"louder/internal/core/domain"
+	"time"
+)
+
+// APIKeyModel is the data structure used for interacting with the 'api_key' table using SQLx
+type APIKeyModel struct {
+	ID         string         `db:"id"`
+	Name       string         `db:"name"`
+	Role       string         `db:"role"`
+	Prefix     string         `db:"prefix"`
+	Hash       []byte         `db:"hash"`
+	CreatedAt  string         `db:"created_at"`
+	LastUsedAt sql.NullString `db:"last_used_at"`
+	RevokedAt  sql.NullString `db:"revoked_at"`
+}
+
+func toModelAPIKey(k *domain.APIKey) *APIKeyModel {
+	if k == nil {
+		return nil
+	}
+
+	return &APIKeyModel{
+		ID:         string(k.ID()),
+		Name:       k.Name(),
+		Role:       k.Role().String(),
+		Prefix:     k.Prefix(),
+		Hash:       k.Hash(),
+		CreatedAt:  dbcommon.FormatTime(k.CreatedAt()),
+		LastUsedAt: dbcommon.NullTime(k.LastUsed()),
+		RevokedAt:  dbcommon.NullTime(k.RevokedAt()),
+	}
+}
+
+func (m *APIKeyModel) toDomainAPIKey() (*domain.APIKey, error) {
+	role, err := domain.ParseRole(m.Role)
+	if err != nil {
+		return nil, fmt.Errorf("parsing role of API key %s: %w", m.ID, err)
+	}
+	createdAt, err := time.Parse(time.RFC3339, m.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("parsing created_at of API key %s: %w", m.ID, err)
+	}
+	lastUsed, err := dbcommon.TimeFromNull(m.LastUsedAt)
+	if err != nil {
+		return nil, fmt.Errorf("parsing last_used_at of API key %s: %w", m.ID, err)
+	}
+	revokedAt, err := dbcommon.TimeFromNull(m.RevokedAt)
+	if err != nil {
+		return nil, fmt.Errorf("parsing revoked_at of API key %s: %w", m.ID, err)
+	}
+
+	return domain.HydrateAPIKey(domain.APIKeyID(m.ID), m.Name, role, m.Prefix, m.Hash, createdAt, lastUsed, revokedAt), nil
+}
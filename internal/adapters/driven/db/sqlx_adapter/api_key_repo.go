@@ -0,0 +1,124 @@
+package sqlxadapter
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	dbcommon "louder/internal/adapters/driven/db/dbcommon"
+	"louder/internal/core/domain"
+	"louder/internal/core/service/authcore"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type APIKeyRepo struct {
+	db *sqlx.DB
+}
+
+// ensure APIKeyRepo implements the Port (safety check)
+var _ authcore.APIKeyRepository = (*APIKeyRepo)(nil)
+
+// return an interface here, not a instance of APIKeyRepo
+func NewAPIKeyRepo(sqldb *sql.DB) (authcore.APIKeyRepository, error) {
+	db := sqlx.NewDb(sqldb, "sqlite3")
+	return &APIKeyRepo{db: db}, nil
+}
+
+func (r *APIKeyRepo) Save(ctx context.Context, key *domain.APIKey) error {
+	model := toModelAPIKey(key)
+	if model == nil {
+		return dbcommon.ErrConvertNilAPIKey
+	}
+
+	query, err := GetQuery("SaveAPIKey")
+	if err != nil {
+		return fmt.Errorf("SaveAPIKey query retrieval: %w", err)
+	}
+
+	qctx, done := dbcommon.TraceQuery(ctx, "SaveAPIKey")
+	_, err = r.db.NamedExecContext(qctx, query, model)
+	done(err)
+	if err != nil {
+		return fmt.Errorf("%w (ID:%s): %w", dbcommon.ErrSaveAPIKey, key.ID(), dbcommon.TranslateSQLiteError(err))
+	}
+	return nil
+}
+
+func (r *APIKeyRepo) GetByHash(ctx context.Context, hash []byte) (*domain.APIKey, error) {
+	query, err := GetQuery("GetAPIKeyByHash")
+	if err != nil {
+		return nil, fmt.Errorf("GetAPIKeyByHash query retrieval: %w", err)
+	}
+
+	var model APIKeyModel
+	qctx, done := dbcommon.TraceQuery(ctx, "GetAPIKeyByHash")
+	err = r.db.GetContext(qctx, &model, query, hash)
+	done(err)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: no API key with this hash", dbcommon.ErrSQLxNotFound)
+		}
+		return nil, fmt.Errorf("%w: %w", dbcommon.ErrSQLxQueryFailed, dbcommon.TranslateSQLiteError(err))
+	}
+
+	return model.toDomainAPIKey()
+}
+
+func (r *APIKeyRepo) List(ctx context.Context) ([]*domain.APIKey, error) {
+	query, err := GetQuery("ListAPIKeys")
+	if err != nil {
+		return nil, fmt.Errorf("ListAPIKeys query retrieval: %w", err)
+	}
+
+	var models []APIKeyModel
+	qctx, done := dbcommon.TraceQuery(ctx, "ListAPIKeys")
+	err = r.db.SelectContext(qctx, &models, query)
+	done(err)
+	if err != nil {
+		return nil, fmt.Errorf("%w: listing API keys: %w", dbcommon.ErrSQLxQueryFailed, dbcommon.TranslateSQLiteError(err))
+	}
+
+	keys := make([]*domain.APIKey, 0, len(models))
+	for _, model := range models {
+		key, err := model.toDomainAPIKey()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (r *APIKeyRepo) Revoke(ctx context.Context, id domain.APIKeyID, at time.Time) error {
+	return r.updateTime(ctx, "RevokeAPIKey", id, at)
+}
+
+func (r *APIKeyRepo) TouchLastUsed(ctx context.Context, id domain.APIKeyID, at time.Time) error {
+	return r.updateTime(ctx, "TouchAPIKeyLastUsed", id, at)
+}
+
+// updateTime runs one of the "SET <column> = ? WHERE id = ?" queries, reporting a missing key as not found
+func (r *APIKeyRepo) updateTime(ctx context.Context, queryName string, id domain.APIKeyID, at time.Time) error {
+	query, err := GetQuery(queryName)
+	if err != nil {
+		return fmt.Errorf("%s query retrieval: %w", queryName, err)
+	}
+
+	qctx, done := dbcommon.TraceQuery(ctx, queryName)
+	res, err := r.db.ExecContext(qctx, query, dbcommon.FormatTime(at), string(id))
+	done(err)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", dbcommon.ErrSQLxQueryFailed, queryName, dbcommon.TranslateSQLiteError(err))
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: %v", dbcommon.ErrSQLxNoRowsAffected, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%w for API key %s", dbcommon.ErrSQLxNotFound, id)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package sqlxadapter
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	dbcommon "louder/internal/adapters/driven/db/dbcommon"
+	"louder/internal/core/domain"
+	"time"
+)
+
+// AuditEntryModel is the data structure used for reading the 'audit_log' table using SQLx, entries are written by dbcommon.InsertAuditEntries
+type AuditEntryModel struct {
+	ID         string         `db:"id"`
+	OccurredAt string         `db:"occurred_at"`
+	Actor      string         `db:"actor"`
+	Action     string         `db:"action"`
+	EntityType string         `db:"entity_type"`
+	EntityID   string         `db:"entity_id"`
+	Changes    string         `db:"changes"`
+	RequestID  sql.NullString `db:"request_id"`
+}
+
+func (m *AuditEntryModel) toDomainAuditEntry() (*domain.AuditEntry, error) {
+	occurredAt, err := time.Parse(time.RFC3339, m.OccurredAt)
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: parsing occurred_at: %w", dbcommon.ErrConvertAuditEntry, m.ID, err)
+	}
+
+	var changes map[string]domain.FieldChange
+	if err := json.Unmarshal([]byte(m.Changes), &changes); err != nil {
+		return nil, fmt.Errorf("%w %s: parsing changes: %w", dbcommon.ErrConvertAuditEntry, m.ID, err)
+	}
+
+	return domain.HydrateAuditEntry(m.ID, occurredAt, m.Actor, domain.AuditAction(m.Action), domain.AuditEntityType(m.EntityType), m.EntityID, changes, m.RequestID.String), nil
+}
+
+// auditSnapshot turns what a get returned into the state of an entity before or after a save, nil when it doesn't exist
+func auditSnapshot[T interface{ AuditSnapshot() domain.AuditSnapshot }](entity T, err error) (domain.AuditSnapshot, error) {
+	if err != nil {
+		if errors.Is(err, dbcommon.ErrSQLxNotFound) || errors.Is(err, dbcommon.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return entity.AuditSnapshot(), nil
+}
@@ -0,0 +1,61 @@
+package sqlxadapter
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	dbcommon "louder/internal/adapters/driven/db/dbcommon"
+	"louder/internal/core/domain"
+	"louder/internal/core/service/auditcore"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type AuditRepo struct {
+	db *sqlx.DB
+}
+
+// ensure AuditRepo implements the Port (safety check)
+var _ auditcore.Repository = (*AuditRepo)(nil)
+
+// return an interface here, not a instance of AuditRepo
+func NewAuditRepo(sqldb *sql.DB) (auditcore.Repository, error) {
+	db := sqlx.NewDb(sqldb, "sqlite3")
+	return &AuditRepo{db: db}, nil
+}
+
+// List returns the entries matching filter, newest first
+func (r *AuditRepo) List(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditEntry, error) {
+	query, err := GetQuery("ListAuditEntries")
+	if err != nil {
+		return nil, fmt.Errorf("ListAuditEntries query retrieval: %w", err)
+	}
+
+	entityType := string(filter.EntityType)
+	from, to := "", ""
+	if !filter.From.IsZero() {
+		from = dbcommon.FormatTime(filter.From)
+	}
+	if !filter.To.IsZero() {
+		to = dbcommon.FormatTime(filter.To)
+	}
+
+	var models []AuditEntryModel
+	qctx, done := dbcommon.TraceQuery(ctx, "ListAuditEntries")
+	err = r.db.SelectContext(qctx, &models, query,
+		entityType, entityType, filter.EntityID, filter.EntityID, filter.Actor, filter.Actor, from, from, to, to, filter.Limit)
+	done(err)
+	if err != nil {
+		return nil, fmt.Errorf("%w: listing audit entries: %w", dbcommon.ErrSQLxQueryFailed, dbcommon.TranslateSQLiteError(err))
+	}
+
+	entries := make([]*domain.AuditEntry, 0, len(models))
+	for _, model := range models {
+		entry, err := model.toDomainAuditEntry()
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
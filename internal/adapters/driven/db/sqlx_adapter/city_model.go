@@ -0,0 +1,60 @@
+package sqlxadapter
+
+import (
+	"database/sql"
+	"louder/internal/core/domain"
+)
+
+// RegionModel is the data structure used for interacting with the 'region' table using SQLx
+type RegionModel struct {
+	CountryCode string         `db:"country_code"`
+	Code        string         `db:"code"`
+	Name        string         `db:"name"`
+	WikiDataID  sql.NullString `db:"wikidataid"`
+}
+
+// CityModel is the data structure used for interacting with the 'city' table using SQLx
+type CityModel struct {
+	ID          int64          `db:"id"`
+	Name        string         `db:"name"`
+	CountryCode string         `db:"country_code"`
+	RegionCode  sql.NullString `db:"region_code"`
+	Latitude    float64        `db:"latitude"`
+	Longitude   float64        `db:"longitude"`
+	Population  int            `db:"population"`
+	WikiDataID  sql.NullString `db:"wikidataid"`
+}
+
+func toModelRegion(r *domain.Region) *RegionModel {
+	if r == nil {
+		return nil
+	}
+
+	return &RegionModel{
+		CountryCode: r.CountryCode().String(),
+		Code:        r.Code(),
+		Name:        r.Name(),
+		WikiDataID:  sql.NullString{String: string(r.WikiId()), Valid: r.WikiId() != ""},
+	}
+}
+
+func toModelCity(c *domain.City) *CityModel {
+	if c == nil {
+		return nil
+	}
+
+	return &CityModel{
+		ID:          int64(c.ID()),
+		Name:        c.Name(),
+		CountryCode: c.CountryCode().String(),
+		RegionCode:  sql.NullString{String: c.RegionCode(), Valid: c.RegionCode() != ""},
+		Latitude:    c.Latitude(),
+		Longitude:   c.Longitude(),
+		Population:  c.Population(),
+		WikiDataID:  sql.NullString{String: string(c.WikiId()), Valid: c.WikiId() != ""},
+	}
+}
+
+func (m *CityModel) toDomainCity() *domain.City {
+	return domain.HydrateCity(domain.CityID(m.ID), m.Name, domain.CountryCode(m.CountryCode), m.RegionCode.String, m.Latitude, m.Longitude, m.Population, domain.WikiCode(m.WikiDataID.String))
+}
@@ -0,0 +1,86 @@
+package sqlxadapter
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	dbcommon "louder/internal/adapters/driven/db/dbcommon"
+	"louder/internal/core/domain"
+	"louder/internal/core/service/citycore"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type CityRepo struct {
+	db *sqlx.DB
+}
+
+// ensure CityRepo implements the Port (safety check)
+var _ citycore.Repository = (*CityRepo)(nil)
+
+// return an interface here, not a instance of CityRepo
+func NewCityRepo(sqldb *sql.DB) (citycore.Repository, error) {
+	db := sqlx.NewDb(sqldb, "sqlite3")
+	return &CityRepo{db: db}, nil
+}
+
+func (r *CityRepo) SaveRegion(ctx context.Context, region *domain.Region) error {
+	model := toModelRegion(region)
+	if model == nil {
+		return dbcommon.ErrConvertNilRegion
+	}
+
+	query, err := GetQuery("SaveRegion")
+	if err != nil {
+		return fmt.Errorf("SaveRegion query retrieval: %w", err)
+	}
+
+	qctx, done := dbcommon.TraceQuery(ctx, "SaveRegion")
+	_, err = r.db.NamedExecContext(qctx, query, model)
+	done(err)
+	if err != nil {
+		return fmt.Errorf("%w (%s-%s): %w", dbcommon.ErrSaveRegion, region.CountryCode(), region.Code(), dbcommon.TranslateSQLiteError(err))
+	}
+	return nil
+}
+
+func (r *CityRepo) SaveCity(ctx context.Context, city *domain.City) error {
+	model := toModelCity(city)
+	if model == nil {
+		return dbcommon.ErrConvertNilCity
+	}
+
+	query, err := GetQuery("SaveCity")
+	if err != nil {
+		return fmt.Errorf("SaveCity query retrieval: %w", err)
+	}
+
+	qctx, done := dbcommon.TraceQuery(ctx, "SaveCity")
+	_, err = r.db.NamedExecContext(qctx, query, model)
+	done(err)
+	if err != nil {
+		return fmt.Errorf("%w (ID:%d): %w", dbcommon.ErrSaveCity, city.ID(), dbcommon.TranslateSQLiteError(err))
+	}
+	return nil
+}
+
+func (r *CityRepo) GetCity(ctx context.Context, id domain.CityID) (*domain.City, error) {
+	query, err := GetQuery("GetCityByID")
+	if err != nil {
+		return nil, fmt.Errorf("GetCityByID query retrieval: %w", err)
+	}
+
+	var model CityModel
+	qctx, done := dbcommon.TraceQuery(ctx, "GetCityByID")
+	err = r.db.GetContext(qctx, &model, query, int64(id))
+	done(err)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: no city with ID %d", dbcommon.ErrNotFound, id)
+		}
+		return nil, fmt.Errorf("%w: %w", dbcommon.ErrSQLxQueryFailed, dbcommon.TranslateSQLiteError(err))
+	}
+
+	return model.toDomainCity(), nil
+}
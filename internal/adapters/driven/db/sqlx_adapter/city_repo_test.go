@@ -0,0 +1,48 @@
+package sqlxadapter_test
+
+import (
+	"context"
+	"errors"
+	"louder/internal/adapters/driven/db/dbcommon"
+	sqlxadapter "louder/internal/adapters/driven/db/sqlx_adapter"
+	"louder/internal/core/domain"
+	"testing"
+)
+
+func TestSaveAndGetCity(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	cityRepo, err := sqlxadapter.NewCityRepo(db.DB)
+	if err != nil {
+		t.Fatalf("failed to create city repo: %v", err)
+	}
+	ctx := context.Background()
+	if _, err := db.ExecContext(ctx, "INSERT INTO country (code, name, wikidataid) VALUES ('IT', 'Italy', 'Q38')"); err != nil {
+		t.Fatalf("inserting the country: %v", err)
+	}
+
+	region, _ := domain.NewRegion("IT", "62", "Lazio", "Q1282")
+	if err := cityRepo.SaveRegion(ctx, region); err != nil {
+		t.Fatalf("saving the region: %v", err)
+	}
+	// saves are upserts, syncing the same city again updates it
+	for _, population := range []int{2_700_000, 2_750_000} {
+		rome, _ := domain.NewCity(3543, "Rome", "IT", "62", 41.893, 12.483, population, "Q220")
+		if err := cityRepo.SaveCity(ctx, rome); err != nil {
+			t.Fatalf("saving the city: %v", err)
+		}
+	}
+
+	got, err := cityRepo.GetCity(ctx, 3543)
+	if err != nil {
+		t.Fatalf("getting the city: %v", err)
+	}
+	if got.Name() != "Rome" || got.CountryCode() != "IT" || got.RegionCode() != "62" || got.Population() != 2_750_000 || got.Latitude() != 41.893 || got.WikiId() != "Q220" {
+		t.Errorf("got %+v", got)
+	}
+
+	if _, err := cityRepo.GetCity(ctx, 1); !errors.Is(err, dbcommon.ErrNotFound) {
+		t.Errorf("GetCity on an unknown ID: err = %v, want ErrNotFound", err)
+	}
+}
@@ -1,15 +1,17 @@
 package sqlxadapter
 
 import (
+	"database/sql"
 	"fmt"
 	dbcommon "louder/internal/adapters/driven/db/dbcommon"
 	"louder/internal/core/domain"
 )
 
 type CountryModel struct {
-	Code       domain.CountryCode `db:"country_code"`
+	Code       domain.CountryCode `db:"code"`
 	Name       string             `db:"name"`
 	WikiDataID domain.WikiCode    `db:"wikidataid"`
+	Region     sql.NullString     `db:"region"`
 }
 
 // toModelCountry takes a Country domain entity and returns its equivalent SQLx model
@@ -22,6 +24,7 @@ func toModelCountry(c *domain.Country) *CountryModel {
 		Code:       c.Code(),
 		Name:       c.Name(),
 		WikiDataID: c.WikiId(),
+		Region:     sql.NullString{String: c.Region(), Valid: c.Region() != ""},
 	}
 }
 
@@ -49,7 +52,7 @@ func (m *CountryModel) toDomainCountry(currencyModels []CurrencyModel) (*domain.
 		domainCurrencies = append(domainCurrencies, *tempDomainCurrency)
 	}
 
-	createdCountry, err := domain.NewCountry(m.Code, m.Name, domainCurrencies, m.WikiDataID)
+	createdCountry, err := domain.NewCountry(m.Code, m.Name, m.Region.String, domainCurrencies, m.WikiDataID)
 	if err != nil {
 		return nil, fmt.Errorf("%w: while creating domain country from model (code: %s): %v", dbcommon.ErrDomainCreation, m.Code.String(), err)
 	}
@@ -5,10 +5,11 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"log"
 	"louder/internal/adapters/driven/db/dbcommon"
 	"louder/internal/core/domain"
+	"louder/internal/core/service/auditcore"
 	"louder/internal/core/service/countrycore"
+	"louder/pkg/logging"
 
 	"github.com/jmoiron/sqlx"
 )
@@ -27,81 +28,75 @@ func NewCountryRepo(sqldb *sql.DB) (countrycore.Repository, error) {
 	return &CountryRepo{db: db}, nil
 }
 
-// Save writes a (domain object) Country into the DB returning the result of a DB get of the written instance
-func (r *CountryRepo) Save(ctx context.Context, country *domain.Country) (*domain.Country, error) {
+// Save writes a (domain object) Country and its currencies into the DB returning the result of a DB get of the written instance. Every change is recorded in the audit trail in the same transaction.
+func (r *CountryRepo) Save(ctx context.Context, country *domain.Country) (_ *domain.Country, err error) {
 
 	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("%w: beginning transaction for saving country %s: %v", dbcommon.ErrTransactionBegin, country.Code(), err)
 	}
 
-	// defer a rollback
+	// defer a rollback, err is the named result so every failed return below triggers it
 	defer func() {
 		// if there was a panic
 		if p := recover(); p != nil {
-			if rbErr := tx.Rollback(); rbErr != nil {
-				log.Printf("ERROR: transaction rollback failed for country %s after error %v: %v", country.Code(), err, rbErr)
+			if rbErr := tx.Rollback(); rbErr != nil && !errors.Is(rbErr, sql.ErrTxDone) {
+				logging.FromContext(ctx).Error("transaction rollback failed after panic", "country", country.Code().String(), "panic", p, "err", rbErr)
 			}
 			panic(p) // repanic anyway
 		}
 		// if an error occurred, rollback
 		if err != nil {
-			if rbErr := tx.Rollback(); rbErr != nil {
-				log.Printf("ERROR: transaction rollback failed for country %s after error %v: %v", country.Code(), err, rbErr)
+			if rbErr := tx.Rollback(); rbErr != nil && !errors.Is(rbErr, sql.ErrTxDone) {
+				logging.FromContext(ctx).Error("transaction rollback failed", "country", country.Code().String(), "cause", err, "err", rbErr)
 			}
 		}
 	}()
 
 	countryModel := toModelCountry(country)
 	if countryModel == nil {
-		err = dbcommon.ErrConvertNilCountry
-		return nil, err
+		return nil, dbcommon.ErrConvertNilCountry
 	}
 
 	saveCountryQuery, err := GetQuery("SaveCountry")
 	if err != nil {
-		err = fmt.Errorf("SaveCurrency query retrieval failed: %w", err)
-		return nil, err
+		return nil, fmt.Errorf("SaveCountry query retrieval failed: %w", err)
 	}
 
-	_, err = tx.NamedExecContext(ctx, saveCountryQuery, countryModel)
+	before, err := auditSnapshot(getCountry(ctx, tx, country.Code()))
 	if err != nil {
-		err = fmt.Errorf("%w for country code %s: %s: %v", dbcommon.ErrSQLxSaveCountry, country.Code(), country.Name(), err)
-		return nil, err
+		return nil, fmt.Errorf("%w for country code %s: reading previous state: %v", dbcommon.ErrSQLxSaveCountry, country.Code(), err)
+	}
+
+	qctx, done := dbcommon.TraceQuery(ctx, "SaveCountry")
+	_, err = tx.NamedExecContext(qctx, saveCountryQuery, countryModel)
+	done(err)
+	if err != nil {
+		return nil, fmt.Errorf("%w for country code %s: %s: %v", dbcommon.ErrSQLxSaveCountry, country.Code(), country.Name(), err)
 	}
 
 	// delete previous country associations and recreate
 	deleteCountryCurrencyJoinsQuery, err := GetQuery("DeleteCountryCurrencyJoins")
 	if err != nil {
-		err = fmt.Errorf("DeleteCountryCurrencyJoins query retrieval failed: %w", err)
-		return nil, err
+		return nil, fmt.Errorf("DeleteCountryCurrencyJoins query retrieval failed: %w", err)
 	}
 
-	_, err = tx.NamedExecContext(ctx, deleteCountryCurrencyJoinsQuery, countryModel)
+	qctx, done = dbcommon.TraceQuery(ctx, "DeleteCountryCurrencyJoins")
+	_, err = tx.NamedExecContext(qctx, deleteCountryCurrencyJoinsQuery, countryModel)
+	done(err)
 	if err != nil {
-		err = fmt.Errorf("%w for country code %s: %v", dbcommon.ErrSQLxDeleteJoins, country.Name(), err)
-		return nil, err
+		return nil, fmt.Errorf("%w for country code %s: %v", dbcommon.ErrSQLxDeleteJoins, country.Name(), err)
 	}
 
 	if len(country.Currencies()) > 0 {
 
-		saveCurrencyQuery, err := GetQuery("SaveCurrency")
-		if err != nil {
-			return nil, fmt.Errorf("SaveCurrency query retrieval failed: %w", err)
-		}
-
 		saveCountryCurrencyPairQuery, err := GetQuery("SaveCountryCurrencyPair")
 		if err != nil {
 			return nil, fmt.Errorf("SaveCountryCurrencyPair query retrieval failed: %w", err)
 		}
 
 		for _, c := range country.Currencies() {
-			currencyRow := toModelCurrency(&c)
-
-			_, err := tx.NamedExecContext(ctx, saveCurrencyQuery, currencyRow)
-
-			if err != nil {
-				err = fmt.Errorf("%w for currency %s: %v", dbcommon.ErrSaveCurrency, c.Code().String(), err)
+			if err := saveCurrency(ctx, tx, &c); err != nil {
 				return nil, err
 			}
 
@@ -112,31 +107,51 @@ func (r *CountryRepo) Save(ctx context.Context, country *domain.Country) (*domai
 				CountryCode:  country.Code(),
 				CurrencyCode: c.Code(),
 			}
-			_, err = tx.NamedExecContext(ctx, saveCountryCurrencyPairQuery, row)
-
+			qctx, done := dbcommon.TraceQuery(ctx, "SaveCountryCurrencyPair")
+			_, err := tx.NamedExecContext(qctx, saveCountryCurrencyPairQuery, row)
+			done(err)
 			if err != nil {
-				err = fmt.Errorf("%w for country/currency pair %s: %s: %v", dbcommon.ErrSQLxSaveCountryCurrency, country.Code(), c.Code().String(), err)
-				return nil, err
+				return nil, fmt.Errorf("%w for country/currency pair %s: %s: %v", dbcommon.ErrSQLxSaveCountryCurrency, country.Code(), c.Code().String(), err)
 			}
 		}
 	}
 
+	// read back rather than trusting country, the upsert keeps a known region when the new one is missing
+	after, err := auditSnapshot(getCountry(ctx, tx, country.Code()))
+	if err != nil {
+		return nil, fmt.Errorf("%w for country code %s: reading new state: %v", dbcommon.ErrSQLxSaveCountry, country.Code(), err)
+	}
+
+	entry, err := auditcore.EntryFor(ctx, domain.AuditCountry, country.Code().String(), before, after)
+	if err != nil {
+		return nil, err
+	}
+	if err = dbcommon.InsertAuditEntries(ctx, tx, entry); err != nil {
+		return nil, err
+	}
+
 	if err = tx.Commit(); err != nil {
 		// if commit fails a rollback will be attempted from defer
 		return nil, fmt.Errorf("%w: committing transaction for country %s: %v", dbcommon.ErrTransactionCommit, country.Code(), err)
 	}
 
-	createdCountry, err := r.GetByID(ctx, country.Code())
-	if err != nil {
-		return nil, fmt.Errorf("%w for country %s: %v", dbcommon.ErrSQLxSavedButNotInDB, country.Name(), err)
+	// committed, the deferred rollback does nothing from here on
+	createdCountry, getErr := r.GetByID(ctx, country.Code())
+	if getErr != nil {
+		return nil, fmt.Errorf("%w for country %s: %v", dbcommon.ErrSQLxSavedButNotInDB, country.Name(), getErr)
 	}
 
-	log.Printf("Country %s and its currencies saved/updated successfully", country.Code())
+	logging.FromContext(ctx).Debug("country and its currencies saved/updated", "country", country.Code().String())
 	return createdCountry, nil
 }
 
 // GetByID returns a Country instance if its country code exists
 func (r *CountryRepo) GetByID(ctx context.Context, cc domain.CountryCode) (*domain.Country, error) {
+	return getCountry(ctx, r.db, cc)
+}
+
+// getCountry reads a country and its currencies with q, which can be a transaction
+func getCountry(ctx context.Context, q sqlx.QueryerContext, cc domain.CountryCode) (*domain.Country, error) {
 	ccStr := cc.String()
 	if ccStr == "" {
 		return nil, dbcommon.ErrNoCountryCode
@@ -152,7 +167,9 @@ func (r *CountryRepo) GetByID(ctx context.Context, cc domain.CountryCode) (*doma
 	}
 
 	var countryModel CountryModel
-	err = r.db.GetContext(ctx, &countryModel, countryQuery, ccStr)
+	qctx, done := dbcommon.TraceQuery(ctx, "GetCountryByCode")
+	err = sqlx.GetContext(qctx, q, &countryModel, countryQuery, ccStr)
+	done(err)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			// no results
@@ -164,7 +181,9 @@ func (r *CountryRepo) GetByID(ctx context.Context, cc domain.CountryCode) (*doma
 
 	var cModels []CurrencyModel
 	// SelectContext needs a pointer to a slice...
-	err = r.db.SelectContext(ctx, &cModels, currenciesQuery, ccStr)
+	qctx, done = dbcommon.TraceQuery(ctx, "GetCurrenciesForCountry")
+	err = sqlx.SelectContext(qctx, q, &cModels, currenciesQuery, ccStr)
+	done(err)
 
 	if err != nil {
 		return nil, fmt.Errorf("%w: fetching currencies for country '%s': %v", dbcommon.ErrSQLxQueryFailed, ccStr, err)
@@ -186,7 +205,9 @@ func (r *CountryRepo) CountAll(ctx context.Context) (int, error) {
 	}
 
 	var count int
-	err = r.db.GetContext(ctx, &count, countAllCountriesQuery)
+	qctx, done := dbcommon.TraceQuery(ctx, "CountAllCountries")
+	err = r.db.GetContext(qctx, &count, countAllCountriesQuery)
+	done(err)
 
 	if err != nil {
 		return 0, fmt.Errorf("%w: counting all countries: %v", dbcommon.ErrSQLxQueryFailed, err)
@@ -203,7 +224,9 @@ func (r *CountryRepo) GetRandom(ctx context.Context) (*domain.Country, error) {
 	}
 
 	var cModel CountryModel // not a pointer
-	err = r.db.GetContext(ctx, &cModel, getRandomCountryQuery)
+	qctx, done := dbcommon.TraceQuery(ctx, "GetRandomCountry")
+	err = r.db.GetContext(qctx, &cModel, getRandomCountryQuery)
+	done(err)
 	if err != nil {
 		return nil, fmt.Errorf("%w: getting random country: %v", dbcommon.ErrSQLxQueryFailed, err)
 	}
@@ -216,7 +239,9 @@ func (r *CountryRepo) GetRandom(ctx context.Context) (*domain.Country, error) {
 	ccStr := cModel.Code.String()
 	var cModels []CurrencyModel
 	// SelectContext needs a pointer to a slice...
-	err = r.db.SelectContext(ctx, &cModels, currenciesQuery, ccStr)
+	qctx, done = dbcommon.TraceQuery(ctx, "GetCurrenciesForCountry")
+	err = r.db.SelectContext(qctx, &cModels, currenciesQuery, ccStr)
+	done(err)
 
 	if err != nil {
 		return nil, fmt.Errorf("%w: fetching currencies for country '%s': %v", dbcommon.ErrSQLxQueryFailed, ccStr, err)
@@ -230,3 +255,23 @@ func (r *CountryRepo) GetRandom(ctx context.Context) (*domain.Country, error) {
 
 	return result, nil
 }
+
+// ListCodes returns the codes of every country matching the filter, in storage order
+func (r *CountryRepo) ListCodes(ctx context.Context, filter domain.CountryFilter) ([]domain.CountryCode, error) {
+	listCountryCodesQuery, err := GetQuery("ListCountryCodes")
+	if err != nil {
+		return nil, fmt.Errorf("ListCountryCodes query retrieval: %w", err)
+	}
+
+	currencyCode := filter.CurrencyCode.String()
+
+	var codes []domain.CountryCode
+	qctx, done := dbcommon.TraceQuery(ctx, "ListCountryCodes")
+	err = r.db.SelectContext(qctx, &codes, listCountryCodesQuery, filter.Region, filter.Region, currencyCode, currencyCode)
+	done(err)
+	if err != nil {
+		return nil, fmt.Errorf("%w: listing country codes: %v", dbcommon.ErrSQLxQueryFailed, err)
+	}
+
+	return codes, nil
+}
@@ -5,10 +5,11 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"log"
 	dbcommon "louder/internal/adapters/driven/db/dbcommon"
 	"louder/internal/core/domain"
+	"louder/internal/core/service/auditcore"
 	"louder/internal/core/service/currencycore"
+	"louder/pkg/logging"
 
 	"github.com/jmoiron/sqlx"
 )
@@ -26,40 +27,86 @@ func NewCurrencyRepo(sqldb *sql.DB) (currencycore.Repository, error) {
 	return &CurrencyRepo{db: db}, nil
 }
 
-func (r *CurrencyRepo) Save(ctx context.Context, currency *domain.Currency) (*domain.Currency, error) {
+// Save inserts or updates the currency and records the change in the audit trail, in one transaction
+func (r *CurrencyRepo) Save(ctx context.Context, currency *domain.Currency) (_ *domain.Currency, err error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: beginning transaction for saving currency %s: %v", dbcommon.ErrTransactionBegin, currency.Code(), err)
+	}
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil && !errors.Is(rbErr, sql.ErrTxDone) {
+				logging.FromContext(ctx).Error("transaction rollback failed", "currency", currency.Code().String(), "cause", err, "err", rbErr)
+			}
+		}
+	}()
+
+	if err = saveCurrency(ctx, tx, currency); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%w: committing transaction for currency %s: %v", dbcommon.ErrTransactionCommit, currency.Code(), err)
+	}
+
+	// checking for rows affected == 0 might not be great here as the query has a DO UPDATE SET, so if an upsert occurs, rowsaffected would have returned 0 and that is not an erorr
+
+	// committed, the deferred rollback does nothing from here on
+	createdCurrency, getErr := r.GetByID(ctx, currency.Code())
+	if getErr != nil {
+		return nil, fmt.Errorf("%w for currency code %s: %v", dbcommon.ErrSQLxSavedButNotInDB, currency.Code(), getErr)
+	}
+	logging.FromContext(ctx).Debug("currency inserted/updated", "currency", currency.Code().String())
+
+	return createdCurrency, nil
+}
+
+// saveCurrency upserts currency and its audit entry with tx, CountryRepo.Save saves a country's currencies with it too
+func saveCurrency(ctx context.Context, tx *sqlx.Tx, currency *domain.Currency) error {
 	// convert from domain model to sqlx model
 	sqlxModel := toModelCurrency(currency)
 
 	// check if it's nil
 	if sqlxModel == nil {
-		return nil, dbcommon.ErrConvertNilCurrency
+		return dbcommon.ErrConvertNilCurrency
 	}
 
 	query, err := GetQuery("SaveCurrency")
 	if err != nil {
-		return nil, fmt.Errorf("SaveCurrency query retrieval: %w", err)
+		return fmt.Errorf("SaveCurrency query retrieval: %w", err)
 	}
 
-	// run the query and get the result (and check for errors)
-	_, err = r.db.NamedExecContext(ctx, query, sqlxModel)
+	before, err := auditSnapshot(getCurrency(ctx, tx, currency.Code()))
 	if err != nil {
-		return nil, fmt.Errorf("%w for currency code %s: %s: %v", dbcommon.ErrSaveCurrency, currency.Code(), currency.Name(), err)
+		return fmt.Errorf("%w for currency code %s: reading previous state: %v", dbcommon.ErrSaveCurrency, currency.Code(), err)
 	}
 
-	// checking for rows affected == 0 might not be great here as the query has a DO UPDATE SET, so if an upsert occurs, rowsaffected would have returned 0 and that is not an erorr
-
-	var createdCurrency *domain.Currency
+	// run the query and get the result (and check for errors)
+	qctx, done := dbcommon.TraceQuery(ctx, "SaveCurrency")
+	_, err = tx.NamedExecContext(qctx, query, sqlxModel)
+	done(err)
+	if err != nil {
+		return fmt.Errorf("%w for currency code %s: %s: %v", dbcommon.ErrSaveCurrency, currency.Code(), currency.Name(), err)
+	}
 
-	createdCurrency, err = r.GetByID(ctx, currency.Code())
+	after, err := auditSnapshot(getCurrency(ctx, tx, currency.Code()))
 	if err != nil {
-		return nil, fmt.Errorf("%w for currency code %s: %v", dbcommon.ErrSQLxSavedButNotInDB, currency.Code(), err)
+		return fmt.Errorf("%w for currency code %s: reading new state: %v", dbcommon.ErrSaveCurrency, currency.Code(), err)
 	}
-	log.Printf("Currency %s inserted/updated with success", currency.Code())
 
-	return createdCurrency, nil
+	entry, err := auditcore.EntryFor(ctx, domain.AuditCurrency, currency.Code().String(), before, after)
+	if err != nil {
+		return err
+	}
+	return dbcommon.InsertAuditEntries(ctx, tx, entry)
 }
 
 func (r *CurrencyRepo) GetByID(ctx context.Context, cc domain.CurrencyCode) (*domain.Currency, error) {
+	return getCurrency(ctx, r.db, cc)
+}
+
+// getCurrency reads a currency with q, which can be a transaction
+func getCurrency(ctx context.Context, q sqlx.QueryerContext, cc domain.CurrencyCode) (*domain.Currency, error) {
 	givenCode := string(cc)
 	if string(givenCode) == "" {
 		return nil, dbcommon.ErrNoCurrencyCode
@@ -72,7 +119,9 @@ func (r *CurrencyRepo) GetByID(ctx context.Context, cc domain.CurrencyCode) (*do
 
 	var sqlxModel CurrencyModel
 
-	err = r.db.GetContext(ctx, &sqlxModel, query, givenCode)
+	qctx, done := dbcommon.TraceQuery(ctx, "GetCurrencyByCode")
+	err = sqlx.GetContext(qctx, q, &sqlxModel, query, givenCode)
+	done(err)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			// no results
@@ -98,7 +147,9 @@ func (r *CurrencyRepo) CountAll(ctx context.Context) (int, error) {
 	}
 
 	var count int
-	err = r.db.GetContext(ctx, &count, query)
+	qctx, done := dbcommon.TraceQuery(ctx, "CountAllCurrencies")
+	err = r.db.GetContext(qctx, &count, query)
+	done(err)
 
 	if err != nil {
 		return 0, fmt.Errorf("%w: counting all currencies: %v", dbcommon.ErrSQLxQueryFailed, err)
@@ -114,7 +165,9 @@ func (r *CurrencyRepo) GetRandom(ctx context.Context) (*domain.Currency, error)
 	}
 
 	var row CurrencyModel // not a pointer
-	err = r.db.GetContext(ctx, &row, query)
+	qctx, done := dbcommon.TraceQuery(ctx, "GetRandomCurrency")
+	err = r.db.GetContext(qctx, &row, query)
+	done(err)
 	if err != nil {
 		return nil, fmt.Errorf("%w: getting random currency: %v", dbcommon.ErrSQLxQueryFailed, err)
 	}
@@ -128,6 +181,26 @@ func (r *CurrencyRepo) GetRandom(ctx context.Context) (*domain.Currency, error)
 	return result, nil
 }
 
+// ListCodes returns the codes of every currency matching the filter, in storage order
+func (r *CurrencyRepo) ListCodes(ctx context.Context, filter domain.CurrencyFilter) ([]domain.CurrencyCode, error) {
+	query, err := GetQuery("ListCurrencyCodes")
+	if err != nil {
+		return nil, fmt.Errorf("ListCurrencyCodes query retrieval: %w", err)
+	}
+
+	countryCode := filter.CountryCode.String()
+
+	var codes []domain.CurrencyCode
+	qctx, done := dbcommon.TraceQuery(ctx, "ListCurrencyCodes")
+	err = r.db.SelectContext(qctx, &codes, query, countryCode, countryCode)
+	done(err)
+	if err != nil {
+		return nil, fmt.Errorf("%w: listing currency codes: %v", dbcommon.ErrSQLxQueryFailed, err)
+	}
+
+	return codes, nil
+}
+
 // TODO implement remaining port methods
 // GetByName(ctx context.Context, name string) (*domain.Currency, error)
 
@@ -8,6 +8,7 @@ import (
 	"log"
 	sqlxadapter "louder/internal/adapters/driven/db/sqlx_adapter"
 	"louder/internal/core/domain"
+	"louder/pkg/logging"
 	"os"
 	"path"
 	"path/filepath"
@@ -169,3 +170,54 @@ func TestSaveAndGetByID(t *testing.T) {
 		})
 	}
 }
+
+func TestSaveWritesAuditTrail(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	currencyRepo, err := sqlxadapter.NewCurrencyRepo(db.DB)
+	if err != nil {
+		t.Fatalf("failed to create currency repo: %v", err)
+	}
+	auditRepo, err := sqlxadapter.NewAuditRepo(db.DB)
+	if err != nil {
+		t.Fatalf("failed to create audit repo: %v", err)
+	}
+
+	ctx := logging.WithRequestID(context.Background(), "req-1")
+
+	// created, saved again unchanged, then renamed
+	for _, name := range []string{"Euro", "Euro", "Euros"} {
+		c, _ := domain.NewCurrency("EUR", name)
+		if _, err := currencyRepo.Save(ctx, c); err != nil {
+			t.Fatalf("saving %q: %v", name, err)
+		}
+	}
+
+	entries, err := auditRepo.List(ctx, domain.AuditFilter{EntityType: domain.AuditCurrency, EntityID: "EUR", Limit: 10})
+	if err != nil {
+		t.Fatalf("listing audit entries: %v", err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("got %d audit entries, want 2 (the unchanged save records nothing)", len(entries))
+	}
+	update, create := entries[0], entries[1] // newest first
+	if create.Action() != domain.AuditCreate || update.Action() != domain.AuditUpdate {
+		t.Errorf("actions = %s, %s, want create then update", create.Action(), update.Action())
+	}
+	if got := update.Changes()["name"]; got.Before != "Euro" || got.After != "Euros" {
+		t.Errorf("name change = %+v, want Euro to Euros", got)
+	}
+	if update.RequestID() != "req-1" || update.Actor() != domain.SystemActor {
+		t.Errorf("request ID %q, actor %q, want req-1 and %s", update.RequestID(), update.Actor(), domain.SystemActor)
+	}
+
+	none, err := auditRepo.List(ctx, domain.AuditFilter{Actor: "someone/else", Limit: 10})
+	if err != nil {
+		t.Fatalf("listing audit entries: %v", err)
+	}
+	if len(none) != 0 {
+		t.Errorf("filtering by another actor returned %d entries", len(none))
+	}
+}
@@ -1,6 +1,7 @@
 package sqlxadapter
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
 	dbcommon "louder/internal/adapters/driven/db/dbcommon"
@@ -20,6 +21,11 @@ type SQLxModelPerson struct {
 	LastName  string          `db:"last_name"`
 	Email     string          `db:"email"`
 	DOB       types.UTCTime   `db:"dob"`
+
+	BirthCountryCode     sql.NullString `db:"birth_country_code"`
+	ResidenceCountryCode sql.NullString `db:"residence_country_code"`
+
+	DeletedAt sql.NullString `db:"deleted_at"`
 }
 
 // mappers
@@ -34,6 +40,11 @@ func toSQLxModelPerson(p *domain.Person) *SQLxModelPerson {
 		LastName:  p.LastName(),
 		Email:     p.Email(),
 		DOB:       p.DOB(),
+
+		BirthCountryCode:     dbcommon.NullCountryCode(p.BirthCountry()),
+		ResidenceCountryCode: dbcommon.NullCountryCode(p.ResidenceCountry()),
+
+		DeletedAt: dbcommon.NullTime(p.DeletedAt()),
 	}
 }
 
@@ -42,6 +53,12 @@ func (m *SQLxModelPerson) toDomainPerson() (*domain.Person, error) {
 		return nil, fmt.Errorf("%w", dbcommon.ErrHydrateWithNil)
 	}
 
+	deletedAt, err := dbcommon.TimeFromNull(m.DeletedAt)
+	if err != nil {
+		return nil, fmt.Errorf("parsing deleted_at of person %s: %w", m.ID.String(), err)
+	}
+
 	return domain.HydratePerson(
-		m.ID, m.FirstName, m.LastName, m.Email, m.DOB), nil
+		m.ID, m.FirstName, m.LastName, m.Email, m.DOB,
+		dbcommon.CountryCodeFromNull(m.BirthCountryCode), dbcommon.CountryCodeFromNull(m.ResidenceCountryCode), deletedAt), nil
 }
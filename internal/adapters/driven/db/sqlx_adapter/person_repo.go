@@ -5,10 +5,12 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"log"
 	dbcommon "louder/internal/adapters/driven/db/dbcommon"
 	"louder/internal/core/domain"
+	"louder/internal/core/service/auditcore"
 	"louder/internal/core/service/personcore"
+	"louder/pkg/logging"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/jmoiron/sqlx"
@@ -20,6 +22,22 @@ var (
 	// ErrSaveNoRowsAffected = errors.New("error SQLx can't get rows affected")
 )
 
+const (
+	savePersonQuery = `
+		INSERT INTO person (id, first_name, last_name, email, dob, birth_country_code, residence_country_code, deleted_at)
+		VALUES (:id, :first_name, :last_name, :email, :dob, :birth_country_code, :residence_country_code, :deleted_at)
+		ON CONFLICT(id) DO UPDATE SET
+			first_name = excluded.first_name,
+			last_name = excluded.last_name,
+			email = excluded.email,
+			dob = excluded.dob,
+			birth_country_code = excluded.birth_country_code,
+			residence_country_code = excluded.residence_country_code,
+			deleted_at = excluded.deleted_at;`
+
+	selectPersonColumns = `id, first_name, last_name, email, dob, birth_country_code, residence_country_code, deleted_at`
+)
+
 type PersonRepo struct {
 	db *sqlx.DB
 }
@@ -33,7 +51,8 @@ func NewSQLxPersonRepo(sqldb *sql.DB) (*PersonRepo, error) {
 	return &PersonRepo{db: db}, nil
 }
 
-func (spr *PersonRepo) Save(ctx context.Context, person *domain.Person) (*domain.Person, error) {
+// Save inserts or updates the person and records the change in the audit trail, in one transaction
+func (spr *PersonRepo) Save(ctx context.Context, person *domain.Person) (_ *domain.Person, err error) {
 
 	// convert from domain.Person to SQLxPersonModel first
 	sqlxModel := toSQLxModelPerson(person)
@@ -42,41 +61,80 @@ func (spr *PersonRepo) Save(ctx context.Context, person *domain.Person) (*domain
 		return nil, dbcommon.ErrConvertNilPerson
 	}
 
-	query := `
-		INSERT INTO person (id, first_name, last_name, email, dob)
-		VALUES (:id, :first_name, :last_name, :email, :dob)
-		ON CONFLICT(id) DO UPDATE SET
-			first_name = excluded.first_name,
-			last_name = excluded.last_name,
-			email = excluded.email,
-			dob = excluded.dob;`
+	tx, err := spr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: beginning transaction for saving person %s: %v", dbcommon.ErrTransactionBegin, person.ID().String(), err)
+	}
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil && !errors.Is(rbErr, sql.ErrTxDone) {
+				logging.FromContext(ctx).Error("transaction rollback failed", "person_id", person.ID().String(), "cause", err, "err", rbErr)
+			}
+		}
+	}()
 
-	result, err := spr.db.NamedExecContext(ctx, query, sqlxModel)
+	before, err := auditSnapshot(getPerson(ctx, tx, person.ID(), true))
 	if err != nil {
-		return nil, fmt.Errorf("%w (ID:%s): %w", ErrSqlxSavePerson, person.ID().String(), err)
+		return nil, fmt.Errorf("%w (ID:%s): reading previous state: %w", ErrSqlxSavePerson, person.ID().String(), err)
+	}
+
+	qctx, done := dbcommon.TraceQuery(ctx, "SavePerson")
+	result, err := tx.NamedExecContext(qctx, savePersonQuery, sqlxModel)
+	done(err)
+	if err != nil {
+		return nil, fmt.Errorf("%w (ID:%s): %w", ErrSqlxSavePerson, person.ID().String(), dbcommon.TranslateSQLiteError(err))
+	}
+
+	entry, err := auditcore.EntryFor(ctx, domain.AuditPerson, person.ID().String(), before, person.AuditSnapshot())
+	if err != nil {
+		return nil, err
+	}
+	if err = dbcommon.InsertAuditEntries(ctx, tx, entry); err != nil {
+		return nil, err
+	}
+	if person.Erased() {
+		if err = dbcommon.RedactAuditFields(ctx, tx, domain.AuditPerson, person.ID().String(), domain.PersonalDataFields...); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%w: committing transaction for person %s: %v", dbcommon.ErrTransactionCommit, person.ID().String(), err)
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	// committed, the deferred rollback does nothing from here on
+	rowsAffected, raErr := result.RowsAffected()
 
 	var createdPerson *domain.Person
 	switch {
-	case err != nil:
-		log.Printf("SQLx: Warning - couldn't get rows affected for ID: %s, %v", person.ID().String(), err)
+	case raErr != nil:
+		logging.FromContext(ctx).Warn("SQLx: couldn't get rows affected", "person_id", person.ID().String(), "err", raErr)
 
 	case rowsAffected == 0:
-		log.Printf("SQLx: Info - 0 rows affected for ID: %s. Existing record?\n", person.ID().String())
+		logging.FromContext(ctx).Info("SQLx: 0 rows affected, existing record?", "person_id", person.ID().String())
 
 	default:
-		log.Printf("SQLx: Successfully saved/updated person ID %s. Fetching current state.", person.ID().String())
-		createdPerson, err = spr.GetByID(ctx, person.ID())
-		if err != nil {
-			return nil, fmt.Errorf("%w, ID: %s, %w", dbcommon.ErrSavedButNotInDB, person.ID().String(), err)
+		logging.FromContext(ctx).Debug("SQLx: saved/updated person, fetching current state", "person_id", person.ID().String())
+		var getErr error
+		createdPerson, getErr = spr.GetByIDIncludingDeleted(ctx, person.ID())
+		if getErr != nil {
+			return nil, fmt.Errorf("%w, ID: %s, %w", dbcommon.ErrSavedButNotInDB, person.ID().String(), getErr)
 		}
 	}
 	return createdPerson, nil
 }
 
 func (spr *PersonRepo) GetByID(ctx context.Context, pid domain.PersonID) (*domain.Person, error) {
+	return getPerson(ctx, spr.db, pid, false)
+}
+
+// GetByIDIncludingDeleted is GetByID for soft deleted people too
+func (spr *PersonRepo) GetByIDIncludingDeleted(ctx context.Context, pid domain.PersonID) (*domain.Person, error) {
+	return getPerson(ctx, spr.db, pid, true)
+}
+
+// getPerson reads a person with q, which can be a transaction
+func getPerson(ctx context.Context, q sqlx.QueryerContext, pid domain.PersonID, includeDeleted bool) (*domain.Person, error) {
 
 	if uuid.UUID(pid).IsNil() {
 		return nil, dbcommon.ErrEmptyID
@@ -89,13 +147,15 @@ func (spr *PersonRepo) GetByID(ctx context.Context, pid domain.PersonID) (*domai
 	// }
 
 	query := `
-		SELECT id, first_name, last_name, email, dob
+		SELECT ` + selectPersonColumns + `
 		FROM person
-		WHERE id = ?;`
+		WHERE id = ? AND (? OR deleted_at IS NULL);`
 
 	var sqlxModel SQLxModelPerson
 
-	err := spr.db.GetContext(ctx, &sqlxModel, query, pid)
+	qctx, done := dbcommon.TraceQuery(ctx, "GetPersonByID")
+	err := sqlx.GetContext(qctx, q, &sqlxModel, query, pid, includeDeleted)
+	done(err)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("%w ID: %s", dbcommon.ErrNotFound, pid.String())
@@ -112,14 +172,44 @@ func (spr *PersonRepo) GetByID(ctx context.Context, pid domain.PersonID) (*domai
 	return retrievedPerson, nil
 }
 
+// GetByEmail returns the person with the given (already normalised) email
+func (spr *PersonRepo) GetByEmail(ctx context.Context, email string) (*domain.Person, error) {
+	query := `
+		SELECT ` + selectPersonColumns + `
+		FROM person
+		WHERE email = ?;`
+
+	var sqlxModel SQLxModelPerson
+
+	qctx, done := dbcommon.TraceQuery(ctx, "GetPersonByEmail")
+	err := spr.db.GetContext(qctx, &sqlxModel, query, email)
+	done(err)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w email: %s", dbcommon.ErrNotFound, email)
+		}
+		return nil, fmt.Errorf("%w email: %s, %w", dbcommon.ErrDBQueryFailed, email, err)
+	}
+
+	retrievedPerson, err := sqlxModel.toDomainPerson()
+	if err != nil {
+		return nil, fmt.Errorf("%w, email: %s, %w", dbcommon.ErrConvertToPerson, email, err)
+	}
+
+	return retrievedPerson, nil
+}
+
 func (spr *PersonRepo) GetAll(ctx context.Context) ([]domain.Person, error) {
 	query := `
-		SELECT id, first_name, last_name, email, dob
-		FROM person;`
+		SELECT ` + selectPersonColumns + `
+		FROM person
+		WHERE deleted_at IS NULL;`
 
 	var dbModels []SQLxModelPerson
 
-	err := spr.db.SelectContext(ctx, &dbModels, query)
+	qctx, done := dbcommon.TraceQuery(ctx, "GetAllPersons")
+	err := spr.db.SelectContext(qctx, &dbModels, query)
+	done(err)
 	if err != nil {
 		return nil, fmt.Errorf("GetAllPersons: %w: %w", dbcommon.ErrDBQueryFailed, err)
 	}
@@ -131,9 +221,9 @@ func (spr *PersonRepo) GetAll(ctx context.Context) ([]domain.Person, error) {
 
 		switch {
 		case err != nil:
-			log.Printf("GetAllPersons: %v (ID: %s): %v. Skipping.", dbcommon.ErrConvertToPerson, dbModels[i].ID.String(), err)
+			logging.FromContext(ctx).Warn("GetAllPersons: skipping row", "person_id", dbModels[i].ID.String(), "err", fmt.Errorf("%w: %w", dbcommon.ErrConvertToPerson, err))
 		case domainPerson == nil:
-			log.Printf("GetAllPersons: %v (ID: %s). Skipping.", dbcommon.ErrNilDomainPerson, dbModels[i].ID.String())
+			logging.FromContext(ctx).Warn("GetAllPersons: skipping row", "person_id", dbModels[i].ID.String(), "err", dbcommon.ErrNilDomainPerson)
 		default:
 			allPersons = append(allPersons, *domainPerson)
 		}
@@ -141,3 +231,135 @@ func (spr *PersonRepo) GetAll(ctx context.Context) ([]domain.Person, error) {
 
 	return allPersons, nil
 }
+
+// ListIDs returns the IDs of every person matching the filter, in storage order
+func (spr *PersonRepo) ListIDs(ctx context.Context, filter domain.PersonFilter) ([]domain.PersonID, error) {
+	query := `
+		SELECT id
+		FROM person
+		WHERE (? = '' OR birth_country_code = ?)
+		  AND (? = '' OR residence_country_code = ?)
+		  AND (? OR deleted_at IS NULL)
+		ORDER BY rowid;`
+
+	birth := filter.BirthCountryCode.String()
+	residence := filter.ResidenceCountryCode.String()
+
+	var ids []domain.PersonID
+	qctx, done := dbcommon.TraceQuery(ctx, "ListPersonIDs")
+	err := spr.db.SelectContext(qctx, &ids, query, birth, birth, residence, residence, filter.IncludeDeleted)
+	done(err)
+	if err != nil {
+		return nil, fmt.Errorf("ListIDs: %w: %w", dbcommon.ErrDBQueryFailed, err)
+	}
+
+	return ids, nil
+}
+
+// SaveBatch inserts all the given people in a single transaction along with their audit entries, either all of them are saved or none are
+func (spr *PersonRepo) SaveBatch(ctx context.Context, people []*domain.Person) (err error) {
+	tx, err := spr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%w: beginning transaction for %d people: %v", dbcommon.ErrTransactionBegin, len(people), err)
+	}
+
+	// if an error occurred, rollback
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil && !errors.Is(rbErr, sql.ErrTxDone) {
+				logging.FromContext(ctx).Error("transaction rollback failed for batch of people", "people", len(people), "cause", err, "err", rbErr)
+			}
+		}
+	}()
+
+	// one prepared statement for the whole batch, sqlite is fast at this inside a transaction
+	stmt, err := tx.PrepareNamedContext(ctx, savePersonQuery)
+	if err != nil {
+		return fmt.Errorf("%w: preparing batch insert: %v", dbcommon.ErrSaveBatch, err)
+	}
+	defer stmt.Close()
+
+	for _, person := range people {
+		sqlxModel := toSQLxModelPerson(person)
+		if sqlxModel == nil {
+			return dbcommon.ErrConvertNilPerson
+		}
+
+		qctx, done := dbcommon.TraceQuery(ctx, "SavePerson")
+		_, err = stmt.ExecContext(qctx, sqlxModel)
+		done(err)
+		if err != nil {
+			return fmt.Errorf("%w (ID:%s): %w", dbcommon.ErrSaveBatch, person.ID().String(), dbcommon.TranslateSQLiteError(err))
+		}
+
+		entry, err := auditcore.EntryFor(ctx, domain.AuditPerson, person.ID().String(), nil, person.AuditSnapshot())
+		if err != nil {
+			return err
+		}
+		if err := dbcommon.InsertAuditEntries(ctx, tx, entry); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%w: committing batch of %d people: %v", dbcommon.ErrTransactionCommit, len(people), err)
+	}
+
+	return nil
+}
+
+// PurgeDeleted hard deletes everyone soft deleted before deletedBefore, recording each removal in the audit trail within the same transaction
+func (spr *PersonRepo) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (_ int, err error) {
+	tx, err := spr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("%w: beginning transaction for purging people: %v", dbcommon.ErrTransactionBegin, err)
+	}
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil && !errors.Is(rbErr, sql.ErrTxDone) {
+				logging.FromContext(ctx).Error("transaction rollback failed for purge of people", "cause", err, "err", rbErr)
+			}
+		}
+	}()
+
+	query := `
+		SELECT ` + selectPersonColumns + `
+		FROM person
+		WHERE deleted_at IS NOT NULL AND deleted_at < ?;`
+
+	var dbModels []SQLxModelPerson
+	qctx, done := dbcommon.TraceQuery(ctx, "ListPurgeablePeople")
+	err = tx.SelectContext(qctx, &dbModels, query, dbcommon.FormatTime(deletedBefore))
+	done(err)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w: %w", dbcommon.ErrPurgePeople, dbcommon.ErrDBQueryFailed, err)
+	}
+
+	for i := range dbModels {
+		person, err := dbModels[i].toDomainPerson()
+		if err != nil {
+			return 0, fmt.Errorf("%w, ID: %s, %w", dbcommon.ErrConvertToPerson, dbModels[i].ID.String(), err)
+		}
+
+		qctx, done := dbcommon.TraceQuery(ctx, "PurgePerson")
+		_, err = tx.ExecContext(qctx, `DELETE FROM person WHERE id = ?;`, person.ID())
+		done(err)
+		if err != nil {
+			return 0, fmt.Errorf("%w (ID:%s): %w", dbcommon.ErrPurgePeople, person.ID().String(), dbcommon.TranslateSQLiteError(err))
+		}
+
+		entry, err := auditcore.EntryFor(ctx, domain.AuditPerson, person.ID().String(), person.AuditSnapshot(), nil)
+		if err != nil {
+			return 0, err
+		}
+		if err := dbcommon.InsertAuditEntries(ctx, tx, entry); err != nil {
+			return 0, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%w: committing purge of %d people: %v", dbcommon.ErrTransactionCommit, len(dbModels), err)
+	}
+
+	return len(dbModels), nil
+}
@@ -0,0 +1,222 @@
+package sqlxadapter_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"louder/internal/adapters/driven/db/dbcommon"
+	sqlxadapter "louder/internal/adapters/driven/db/sqlx_adapter"
+	"louder/internal/core/domain"
+	"louder/pkg/types"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSoftDeleteAndPurge(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	personRepo, err := sqlxadapter.NewSQLxPersonRepo(db.DB)
+	if err != nil {
+		t.Fatalf("failed to create person repo: %v", err)
+	}
+	auditRepo, err := sqlxadapter.NewAuditRepo(db.DB)
+	if err != nil {
+		t.Fatalf("failed to create audit repo: %v", err)
+	}
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+	dob := types.NewUTCTime(time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC))
+
+	// one active, one deleted within the retention period and one deleted long before it
+	people := make(map[string]*domain.Person)
+	for name, deletedAt := range map[string]time.Time{"active": {}, "recent": now.Add(-time.Hour), "old": now.Add(-48 * time.Hour)} {
+		p, err := domain.NewPerson(name, "Doe", name+"@example.com", dob, "", "")
+		if err != nil {
+			t.Fatalf("creating %s: %v", name, err)
+		}
+		if !deletedAt.IsZero() {
+			p = p.Delete(deletedAt)
+		}
+		if _, err := personRepo.Save(ctx, p); err != nil {
+			t.Fatalf("saving %s: %v", name, err)
+		}
+		people[name] = p
+	}
+
+	if _, err := personRepo.GetByID(ctx, people["recent"].ID()); !errors.Is(err, dbcommon.ErrNotFound) {
+		t.Errorf("GetByID on a deleted person: err = %v, want ErrNotFound", err)
+	}
+	if p, err := personRepo.GetByIDIncludingDeleted(ctx, people["recent"].ID()); err != nil || !p.Deleted() {
+		t.Errorf("GetByIDIncludingDeleted on a deleted person: err = %v", err)
+	}
+
+	for includeDeleted, want := range map[bool]int{false: 1, true: 3} {
+		ids, err := personRepo.ListIDs(ctx, domain.PersonFilter{IncludeDeleted: includeDeleted})
+		if err != nil {
+			t.Fatalf("listing IDs: %v", err)
+		}
+		if len(ids) != want {
+			t.Errorf("ListIDs with IncludeDeleted %v returned %d people, want %d", includeDeleted, len(ids), want)
+		}
+	}
+
+	purged, err := personRepo.PurgeDeleted(ctx, now.Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("purging: %v", err)
+	}
+	if purged != 1 {
+		t.Errorf("purged %d people, want 1", purged)
+	}
+	if _, err := personRepo.GetByIDIncludingDeleted(ctx, people["old"].ID()); !errors.Is(err, dbcommon.ErrNotFound) {
+		t.Errorf("the purged person is still there: err = %v", err)
+	}
+	if _, err := personRepo.GetByIDIncludingDeleted(ctx, people["recent"].ID()); err != nil {
+		t.Errorf("the recently deleted person was purged: err = %v", err)
+	}
+
+	entries, err := auditRepo.List(ctx, domain.AuditFilter{EntityType: domain.AuditPerson, EntityID: people["old"].ID().String(), Limit: 10})
+	if err != nil {
+		t.Fatalf("listing audit entries: %v", err)
+	}
+	if len(entries) == 0 || entries[0].Action() != domain.AuditDelete {
+		t.Errorf("the purge left no delete entry in the audit trail, got %d entries", len(entries))
+	}
+
+	// a soft delete through Save is audited as a delete and a restore as an update
+	if _, err := personRepo.Save(ctx, people["active"].Delete(now)); err != nil {
+		t.Fatalf("deleting: %v", err)
+	}
+	if _, err := personRepo.Save(ctx, people["active"]); err != nil {
+		t.Fatalf("restoring: %v", err)
+	}
+	entries, err = auditRepo.List(ctx, domain.AuditFilter{EntityType: domain.AuditPerson, EntityID: people["active"].ID().String(), Limit: 10})
+	if err != nil {
+		t.Fatalf("listing audit entries: %v", err)
+	}
+	var actions []domain.AuditAction
+	for _, entry := range entries {
+		actions = append(actions, entry.Action())
+	}
+	if want := []domain.AuditAction{domain.AuditUpdate, domain.AuditDelete, domain.AuditCreate}; !slices.Equal(actions, want) {
+		t.Errorf("audit actions = %v, want %v (newest first)", actions, want)
+	}
+
+	// erasing leaves nothing of the old name and email in the audit trail either
+	if _, err := personRepo.Save(ctx, people["active"].Erase(now)); err != nil {
+		t.Fatalf("erasing: %v", err)
+	}
+	entries, err = auditRepo.List(ctx, domain.AuditFilter{EntityType: domain.AuditPerson, EntityID: people["active"].ID().String(), Limit: 10})
+	if err != nil {
+		t.Fatalf("listing audit entries: %v", err)
+	}
+	if len(entries) != 4 {
+		t.Fatalf("got %d audit entries for the erased person, want 4", len(entries))
+	}
+	for _, entry := range entries {
+		for field, change := range entry.Changes() {
+			if strings.Contains(fmt.Sprint(change.Before, change.After), "active") {
+				t.Errorf("%s entry still holds %s %v", entry.Action(), field, change)
+			}
+		}
+	}
+}
+
+func TestSaveBatch(t *testing.T) {
+	dob := types.NewUTCTime(time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC))
+	newPerson := func(t *testing.T, email string) *domain.Person {
+		t.Helper()
+		p, err := domain.NewPerson("Jane", "Doe", email, dob, "PT", "ES")
+		if err != nil {
+			t.Fatalf("creating %s: %v", email, err)
+		}
+		return p
+	}
+
+	tests := map[string]struct {
+		existing []string // saved before the batch
+		batch    []string
+		wantErr  error
+	}{
+		"all new": {
+			batch: []string{"a@example.com", "b@example.com", "c@example.com"},
+		},
+		"duplicate within the batch": {
+			batch:   []string{"a@example.com", "b@example.com", "a@example.com"},
+			wantErr: dbcommon.ErrDuplicate,
+		},
+		"duplicate of someone saved before": {
+			existing: []string{"c@example.com"},
+			batch:    []string{"a@example.com", "b@example.com", "c@example.com"},
+			wantErr:  dbcommon.ErrDuplicate,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			db, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			personRepo, err := sqlxadapter.NewSQLxPersonRepo(db.DB)
+			if err != nil {
+				t.Fatalf("failed to create person repo: %v", err)
+			}
+			auditRepo, err := sqlxadapter.NewAuditRepo(db.DB)
+			if err != nil {
+				t.Fatalf("failed to create audit repo: %v", err)
+			}
+			ctx := context.Background()
+
+			for _, email := range tc.existing {
+				if _, err := personRepo.Save(ctx, newPerson(t, email)); err != nil {
+					t.Fatalf("saving %s: %v", email, err)
+				}
+			}
+			batch := make([]*domain.Person, 0, len(tc.batch))
+			for _, email := range tc.batch {
+				batch = append(batch, newPerson(t, email))
+			}
+
+			err = personRepo.SaveBatch(ctx, batch)
+
+			if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tc.wantErr)
+			}
+			if tc.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			wantPeople := len(tc.existing)
+			if tc.wantErr == nil {
+				wantPeople += len(tc.batch)
+			}
+			ids, err := personRepo.ListIDs(ctx, domain.PersonFilter{IncludeDeleted: true})
+			if err != nil {
+				t.Fatalf("listing IDs: %v", err)
+			}
+			if len(ids) != wantPeople {
+				t.Errorf("%d people saved, want %d", len(ids), wantPeople)
+			}
+			entries, err := auditRepo.List(ctx, domain.AuditFilter{EntityType: domain.AuditPerson, Limit: 100})
+			if err != nil {
+				t.Fatalf("listing audit entries: %v", err)
+			}
+			if len(entries) != wantPeople {
+				t.Errorf("%d audit entries, want one per saved person (%d)", len(entries), wantPeople)
+			}
+
+			for _, p := range batch {
+				_, err := personRepo.GetByIDIncludingDeleted(ctx, p.ID())
+				if tc.wantErr == nil && err != nil {
+					t.Errorf("%s from the batch isn't there: %v", p.Email(), err)
+				}
+				if tc.wantErr != nil && !errors.Is(err, dbcommon.ErrNotFound) {
+					t.Errorf("%s from the rolled back batch was persisted: err = %v", p.Email(), err)
+				}
+			}
+		})
+	}
+}
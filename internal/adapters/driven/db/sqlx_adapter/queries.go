@@ -1,6 +1,7 @@
 package sqlxadapter
 
 import (
+	"context"
 	"embed"
 	"fmt"
 	"io/fs"
@@ -91,6 +92,19 @@ func parseContentIntoQueries(content string) {
 	}
 }
 
+// CheckQueries reports whether the embedded queries were loaded, for readiness probes
+func CheckQueries(_ context.Context) error {
+	initQueries()
+
+	if initErr != nil {
+		return fmt.Errorf("failed to initialize queries: %w", initErr)
+	}
+	if len(queries) == 0 {
+		return fmt.Errorf("no sql queries found in sqlx_adapter")
+	}
+	return nil
+}
+
 // GetQuery retrieves a named query. It ensures queries are initialized.
 func GetQuery(name string) (string, error) {
 	initQueries()
@@ -0,0 +1,20 @@
+-- name: SaveAPIKey
+-- Inserts a new API key
+INSERT INTO api_key (id, name, role, prefix, hash, created_at, last_used_at, revoked_at)
+VALUES (:id, :name, :role, :prefix, :hash, :created_at, :last_used_at, :revoked_at);
+
+-- name: GetAPIKeyByHash
+-- Returns the API key whose secret hashes to the given value
+SELECT id, name, role, prefix, hash, created_at, last_used_at, revoked_at FROM api_key WHERE hash = ?;
+
+-- name: ListAPIKeys
+-- Returns every API key, newest first
+SELECT id, name, role, prefix, hash, created_at, last_used_at, revoked_at FROM api_key ORDER BY created_at DESC, id DESC;
+
+-- name: RevokeAPIKey
+-- Revokes an API key, keeping the original time if it was already revoked
+UPDATE api_key SET revoked_at = COALESCE(revoked_at, ?) WHERE id = ?;
+
+-- name: TouchAPIKeyLastUsed
+-- Records when an API key was last used
+UPDATE api_key SET last_used_at = ? WHERE id = ?;
@@ -0,0 +1,10 @@
+-- name: ListAuditEntries
+-- Returns the newest audit entries matching the optional entity, actor and time range filters. Times are RFC3339 UTC text so they compare as strings. A negative limit (auditcore.NoLimit) returns every entry, SQLite has no upper bound then.
+SELECT id, occurred_at, actor, action, entity_type, entity_id, changes, request_id FROM audit_log
+WHERE (? = '' OR entity_type = ?)
+  AND (? = '' OR entity_id = ?)
+  AND (? = '' OR actor = ?)
+  AND (? = '' OR occurred_at >= ?)
+  AND (? = '' OR occurred_at < ?)
+ORDER BY occurred_at DESC, id DESC
+LIMIT ?;
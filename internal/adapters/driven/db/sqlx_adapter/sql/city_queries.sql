@@ -0,0 +1,26 @@
+-- name: SaveRegion
+-- Inserts a region or updates it if the country and code match
+INSERT INTO region (country_code, code, name, wikidataid)
+VALUES (:country_code, :code, :name, :wikidataid)
+ON CONFLICT(country_code, code) DO UPDATE SET
+    name = excluded.name,
+    wikidataid = excluded.wikidataid;
+
+-- name: SaveCity
+-- Inserts a city or updates it if GeoDB's ID matches
+INSERT INTO city (id, name, country_code, region_code, latitude, longitude, population, wikidataid)
+VALUES (:id, :name, :country_code, :region_code, :latitude, :longitude, :population, :wikidataid)
+ON CONFLICT(id) DO UPDATE SET
+    name = excluded.name,
+    country_code = excluded.country_code,
+    region_code = excluded.region_code,
+    latitude = excluded.latitude,
+    longitude = excluded.longitude,
+    population = excluded.population,
+    wikidataid = excluded.wikidataid;
+
+-- name: GetCityByID
+-- Selects a city by GeoDB's ID
+SELECT id, name, country_code, region_code, latitude, longitude, population, wikidataid
+FROM city
+WHERE id = ?;
@@ -1,30 +1,42 @@
 -- name: SaveCountry
--- Inserts a new country or updates an existing one if the code matches.
-INSERT INTO country (code, name, wikidataid)
-VALUES (:code, :name, :wikidataid)
+-- Inserts a new country or updates an existing one if the code matches. A missing region never overwrites a known one.
+INSERT INTO country (code, name, wikidataid, region)
+VALUES (:code, :name, :wikidataid, :region)
 ON CONFLICT(code) DO UPDATE SET
     name = excluded.name,
-    wikidataid = excluded.wikidataid;
+    wikidataid = excluded.wikidataid,
+    region = COALESCE(excluded.region, country.region);
 
 -- name: GetCountryByCode
--- Returns a Country given its 3 digit ISO code
-SELECT code, name, wikidataid FROM country WHERE code = ?;
+-- Returns a Country given its 2 letter ISO code
+SELECT code, name, wikidataid, region FROM country WHERE code = ?;
 
 -- name: GetCurrenciesForCountry
 -- Returns all currencies for a given country code
-SELECT currency_code FROM country_currency WHERE country_code = ?;
+SELECT c.code, c.name
+FROM country_currency cc
+JOIN currency c ON c.code = cc.currency_code
+WHERE cc.country_code = ?;
 
 -- name: CountAllCountries
 -- Return the count of all existing countries
 SELECT COUNT(*) FROM country;
 
--- name GetRandomCountry
--- Returns one country at random
-SELECT code, name, wikidataid FROM country ORDER BY RANDOM() LIMIT 1;
+-- name: GetRandomCountry
+-- Returns one country at random. Skips to a random offset instead of sorting the whole table by RANDOM()
+SELECT code, name, wikidataid, region FROM country
+LIMIT 1 OFFSET (abs(random()) % max((SELECT COUNT(*) FROM country), 1));
 
--- name ListAllCountries
+-- name: ListCountryCodes
+-- Returns the codes of all countries matching the optional region and currency filters, in storage (rowid) order so no sort is needed
+SELECT code FROM country
+WHERE (? = '' OR region = ? COLLATE NOCASE)
+  AND (? = '' OR code IN (SELECT country_code FROM country_currency WHERE currency_code = ?))
+ORDER BY rowid;
+
+-- name: ListAllCountries
 -- Returns all countries
-SELECT code, name, wikidataid FROM country ORDER BY name;
+SELECT code, name, wikidataid, region FROM country ORDER BY name;
 
 -- name: SaveCountryCurrencyPair
 -- Inserts a new country/currency pair or updates an existing one if the code matches.
@@ -32,6 +44,6 @@ INSERT INTO country_currency (country_code, currency_code)
 VALUES (:country_code, :currency_code)
 ON CONFLICT (country_code, currency_code) DO NOTHING;
 
--- name DeleteCountryCurrencyJoins
+-- name: DeleteCountryCurrencyJoins
 -- Deletes rows representing all currencies associated with a Country
-DELETE FROM country_currencies WHERE country_code = :country_code;
\ No newline at end of file
+DELETE FROM country_currency WHERE country_code = :code;
@@ -16,8 +16,15 @@ WHERE code = ?;
 SELECT COUNT(*) FROM currency;
 
 -- name: GetRandomCurrency
--- Selects a random currency from the table (SQLite specific).
-SELECT code, name FROM currency ORDER BY RANDOM() LIMIT 1;
+-- Selects a random currency from the table. Skips to a random offset instead of sorting the whole table by RANDOM()
+SELECT code, name FROM currency
+LIMIT 1 OFFSET (abs(random()) % max((SELECT COUNT(*) FROM currency), 1));
+
+-- name: ListCurrencyCodes
+-- Returns the codes of all currencies, optionally only those used by a country, in storage (rowid) order so no sort is needed
+SELECT code FROM currency
+WHERE (? = '' OR code IN (SELECT currency_code FROM country_currency WHERE country_code = ?))
+ORDER BY rowid;
 
 -- name: ListAllCurrencies
 -- Selects all currencies.
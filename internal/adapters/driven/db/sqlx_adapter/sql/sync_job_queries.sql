@@ -0,0 +1,17 @@
+-- name: SaveSyncJob
+-- Inserts a sync job or updates its checkpoint
+INSERT INTO sync_job (id, resource, status, page_offset, total_count, saved, last_error, started_at, updated_at, finished_at)
+VALUES (:id, :resource, :status, :page_offset, :total_count, :saved, :last_error, :started_at, :updated_at, :finished_at)
+ON CONFLICT(id) DO UPDATE SET
+    status = excluded.status,
+    page_offset = excluded.page_offset,
+    total_count = excluded.total_count,
+    saved = excluded.saved,
+    last_error = excluded.last_error,
+    updated_at = excluded.updated_at,
+    finished_at = excluded.finished_at;
+
+-- name: GetLatestSyncJob
+-- Returns the most recently started sync job of a resource
+SELECT id, resource, status, page_offset, total_count, saved, last_error, started_at, updated_at, finished_at
+FROM sync_job WHERE resource = ? ORDER BY started_at DESC, id DESC LIMIT 1;
@@ -0,0 +1,59 @@
+package sqlxadapter
+
+import (
+	"database/sql"
+	"fmt"
+	dbcommon "louder/internal/adapters/driven/db/dbcommon"
+	"louder/internal/core/domain"
+	"time"
+)
+
+// SyncJobModel is the data structure used for interacting with the 'sync_job' table using SQLx
+type SyncJobModel struct {
+	ID         string         `db:"id"`
+	Resource   string         `db:"resource"`
+	Status     string         `db:"status"`
+	Offset     int            `db:"page_offset"`
+	TotalCount int            `db:"total_count"`
+	Saved      int            `db:"saved"`
+	LastError  sql.NullString `db:"last_error"`
+	StartedAt  string         `db:"started_at"`
+	UpdatedAt  string         `db:"updated_at"`
+	FinishedAt sql.NullString `db:"finished_at"`
+}
+
+func toModelSyncJob(j *domain.SyncJob) *SyncJobModel {
+	if j == nil {
+		return nil
+	}
+
+	return &SyncJobModel{
+		ID:         j.ID(),
+		Resource:   string(j.Resource()),
+		Status:     string(j.Status()),
+		Offset:     j.Offset(),
+		TotalCount: j.TotalCount(),
+		Saved:      j.Saved(),
+		LastError:  sql.NullString{String: j.LastError(), Valid: j.LastError() != ""},
+		StartedAt:  dbcommon.FormatTime(j.StartedAt()),
+		UpdatedAt:  dbcommon.FormatTime(j.UpdatedAt()),
+		FinishedAt: dbcommon.NullTime(j.FinishedAt()),
+	}
+}
+
+func (m *SyncJobModel) toDomainSyncJob() (*domain.SyncJob, error) {
+	startedAt, err := time.Parse(time.RFC3339, m.StartedAt)
+	if err != nil {
+		return nil, fmt.Errorf("parsing started_at of sync job %s: %w", m.ID, err)
+	}
+	updatedAt, err := time.Parse(time.RFC3339, m.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("parsing updated_at of sync job %s: %w", m.ID, err)
+	}
+	finishedAt, err := dbcommon.TimeFromNull(m.FinishedAt)
+	if err != nil {
+		return nil, fmt.Errorf("parsing finished_at of sync job %s: %w", m.ID, err)
+	}
+
+	return domain.HydrateSyncJob(m.ID, domain.SyncResource(m.Resource), domain.SyncStatus(m.Status), m.Offset, m.TotalCount, m.Saved, m.LastError.String, startedAt, updatedAt, finishedAt), nil
+}
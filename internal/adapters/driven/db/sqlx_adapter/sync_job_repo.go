@@ -0,0 +1,66 @@
+package sqlxadapter
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	dbcommon "louder/internal/adapters/driven/db/dbcommon"
+	"louder/internal/core/domain"
+	"louder/internal/core/service/datasync"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type SyncJobRepo struct {
+	db *sqlx.DB
+}
+
+// ensure SyncJobRepo implements the Port (safety check)
+var _ datasync.JobRepository = (*SyncJobRepo)(nil)
+
+// return an interface here, not a instance of SyncJobRepo
+func NewSyncJobRepo(sqldb *sql.DB) (datasync.JobRepository, error) {
+	db := sqlx.NewDb(sqldb, "sqlite3")
+	return &SyncJobRepo{db: db}, nil
+}
+
+func (r *SyncJobRepo) Save(ctx context.Context, job *domain.SyncJob) error {
+	model := toModelSyncJob(job)
+	if model == nil {
+		return dbcommon.ErrConvertNilSyncJob
+	}
+
+	query, err := GetQuery("SaveSyncJob")
+	if err != nil {
+		return fmt.Errorf("SaveSyncJob query retrieval: %w", err)
+	}
+
+	qctx, done := dbcommon.TraceQuery(ctx, "SaveSyncJob")
+	_, err = r.db.NamedExecContext(qctx, query, model)
+	done(err)
+	if err != nil {
+		return fmt.Errorf("%w (ID:%s): %w", dbcommon.ErrSaveSyncJob, job.ID(), dbcommon.TranslateSQLiteError(err))
+	}
+	return nil
+}
+
+func (r *SyncJobRepo) GetLatest(ctx context.Context, resource domain.SyncResource) (*domain.SyncJob, error) {
+	query, err := GetQuery("GetLatestSyncJob")
+	if err != nil {
+		return nil, fmt.Errorf("GetLatestSyncJob query retrieval: %w", err)
+	}
+
+	var model SyncJobModel
+	qctx, done := dbcommon.TraceQuery(ctx, "GetLatestSyncJob")
+	err = r.db.GetContext(qctx, &model, query, string(resource))
+	done(err)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: no sync job for %s", dbcommon.ErrNotFound, resource)
+		}
+		return nil, fmt.Errorf("%w: %w", dbcommon.ErrSQLxQueryFailed, dbcommon.TranslateSQLiteError(err))
+	}
+
+	return model.toDomainSyncJob()
+}
@@ -0,0 +1,143 @@
+package fakedata
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"louder/internal/core/domain"
+	"louder/internal/core/service/personcore"
+	"louder/pkg/types"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed names.json
+var namesJSON []byte
+
+const (
+	fallbackLocale = "en"
+	emailDomain    = "example.com" // reserved for documentation/testing, mail never goes anywhere
+
+	minAge             = 18
+	maxAge             = 80
+	sameResidenceRatio = 0.8 // most people still live where they were born
+)
+
+var (
+	ErrNoCountries   = errors.New("error fake person needs at least one country")
+	ErrLoadNamesData = errors.New("error loading embedded names data")
+)
+
+// localeNames is one entry of names.json
+type localeNames struct {
+	Countries  []string `json:"countries"`
+	FirstNames []string `json:"first_names"`
+	LastNames  []string `json:"last_names"`
+}
+
+// PersonFaker builds realistic fake people. Names are picked from the locale of the person's birth country.
+type PersonFaker struct {
+	locales         map[string]localeNames
+	localeByCountry map[domain.CountryCode]string
+}
+
+var _ personcore.PersonFaker = (*PersonFaker)(nil)
+
+// NewPersonFaker loads the embedded name lists
+func NewPersonFaker() (*PersonFaker, error) {
+	var locales map[string]localeNames
+	if err := json.Unmarshal(namesJSON, &locales); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrLoadNamesData, err)
+	}
+
+	if _, ok := locales[fallbackLocale]; !ok {
+		return nil, fmt.Errorf("%w: fallback locale '%s' missing", ErrLoadNamesData, fallbackLocale)
+	}
+
+	localeByCountry := make(map[domain.CountryCode]string)
+	for locale, names := range locales {
+		if len(names.FirstNames) == 0 || len(names.LastNames) == 0 {
+			return nil, fmt.Errorf("%w: locale '%s' has no names", ErrLoadNamesData, locale)
+		}
+		for _, cc := range names.Countries {
+			localeByCountry[domain.CountryCode(cc)] = locale
+		}
+	}
+
+	return &PersonFaker{
+		locales:         locales,
+		localeByCountry: localeByCountry,
+	}, nil
+}
+
+// FakePerson creates a fake person born in (and usually living in) one of the given countries
+func (f *PersonFaker) FakePerson(countries []domain.CountryCode) (*domain.Person, error) {
+	if len(countries) == 0 {
+		return nil, ErrNoCountries
+	}
+
+	birthCountry := countries[rand.IntN(len(countries))]
+	residenceCountry := birthCountry
+	if rand.Float64() >= sameResidenceRatio {
+		residenceCountry = countries[rand.IntN(len(countries))]
+	}
+
+	names := f.namesFor(birthCountry)
+	firstName := names.FirstNames[rand.IntN(len(names.FirstNames))]
+	lastName := names.LastNames[rand.IntN(len(names.LastNames))]
+
+	return domain.NewPerson(firstName, lastName, fakeEmail(firstName, lastName), fakeDOB(), birthCountry, residenceCountry)
+}
+
+// namesFor returns the names for the country's locale or the fallback locale
+func (f *PersonFaker) namesFor(cc domain.CountryCode) localeNames {
+	if locale, ok := f.localeByCountry[cc]; ok {
+		return f.locales[locale]
+	}
+	return f.locales[fallbackLocale]
+}
+
+// fakeEmail builds first.last.<token>@example.com. The random token carries ~41 bits so collisions are very unlikely, the caller still checks.
+func fakeEmail(firstName, lastName string) string {
+	token := strconv.FormatUint(rand.Uint64N(1<<41), 36)
+	return fmt.Sprintf("%s.%s.%s@%s", asciiFold(firstName), asciiFold(lastName), token, emailDomain)
+}
+
+// fakeDOB returns a birth date for an adult between minAge and maxAge. The range is counted in calendar years, a day short at the young end so a Feb 29 today can't make anyone minAge-1.
+func fakeDOB() types.UTCTime {
+	now := time.Now().UTC()
+	youngest := now.AddDate(-minAge, 0, -1).Truncate(24 * time.Hour)
+	oldest := now.AddDate(-maxAge, 0, 0)
+
+	days := int(youngest.Sub(oldest) / (24 * time.Hour))
+	timeOfDay := time.Duration(rand.IntN(24*3600)) * time.Second
+	return types.NewUTCTime(youngest.AddDate(0, 0, -rand.IntN(days)).Add(timeOfDay))
+}
+
+var diacritics = strings.NewReplacer(
+	"à", "a", "á", "a", "â", "a", "ã", "a", "ä", "a", "å", "a", "ą", "a",
+	"æ", "ae", "ç", "c", "ć", "c",
+	"è", "e", "é", "e", "ê", "e", "ë", "e", "ę", "e",
+	"ì", "i", "í", "i", "î", "i", "ï", "i",
+	"ł", "l", "ñ", "n", "ń", "n",
+	"ò", "o", "ó", "o", "ô", "o", "õ", "o", "ö", "o", "ø", "o", "œ", "oe",
+	"ś", "s", "ß", "ss",
+	"ù", "u", "ú", "u", "û", "u", "ü", "u",
+	"ý", "y", "ÿ", "y", "ź", "z", "ż", "z",
+)
+
+// asciiFold lowercases a name and strips it down to [a-z0-9] so it can be used in an email's local part
+func asciiFold(name string) string {
+	folded := diacritics.Replace(strings.ToLower(name))
+
+	var b strings.Builder
+	for _, r := range folded {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
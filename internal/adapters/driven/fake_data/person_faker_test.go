@@ -0,0 +1,106 @@
+package fakedata
+
+import (
+	"errors"
+	"louder/internal/core/domain"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestFakePersonNamesComeFromTheLocale(t *testing.T) {
+	faker, err := NewPersonFaker()
+	if err != nil {
+		t.Fatalf("NewPersonFaker: %v", err)
+	}
+
+	tests := map[string]struct {
+		country    domain.CountryCode
+		wantLocale string
+	}{
+		"english":            {country: "GB", wantLocale: "en"},
+		"french":             {country: "FR", wantLocale: "fr"},
+		"another of french":  {country: "BE", wantLocale: "fr"},
+		"unknown falls back": {country: "ZZ", wantLocale: fallbackLocale},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			names := faker.locales[tc.wantLocale]
+			for range 200 {
+				person, err := faker.FakePerson([]domain.CountryCode{tc.country})
+				if err != nil {
+					t.Fatalf("FakePerson: %v", err)
+				}
+				if !slices.Contains(names.FirstNames, person.FirstName()) || !slices.Contains(names.LastNames, person.LastName()) {
+					t.Fatalf("%s %s is not a %s name", person.FirstName(), person.LastName(), tc.wantLocale)
+				}
+				if person.BirthCountry() != tc.country || person.ResidenceCountry() != tc.country {
+					t.Fatalf("born in %s living in %s, want %s", person.BirthCountry(), person.ResidenceCountry(), tc.country)
+				}
+			}
+		})
+	}
+}
+
+func TestFakePersonBatch(t *testing.T) {
+	faker, err := NewPersonFaker()
+	if err != nil {
+		t.Fatalf("NewPersonFaker: %v", err)
+	}
+	countries := []domain.CountryCode{"PT", "ES", "FR", "GB", "DE", "ZZ"}
+	now := time.Now()
+
+	const batch = 20000
+	emails := make(map[string]struct{}, batch)
+	for range batch {
+		person, err := faker.FakePerson(countries)
+		if err != nil {
+			t.Fatalf("FakePerson: %v", err)
+		}
+
+		if _, seen := emails[person.Email()]; seen {
+			t.Fatalf("email %s generated twice", person.Email())
+		}
+		emails[person.Email()] = struct{}{}
+
+		if _, err := domain.NormaliseEmail(person.Email()); err != nil {
+			t.Fatalf("email %s: %v", person.Email(), err)
+		}
+		if _, err := domain.NewDOB(person.DOB().Time, now); err != nil {
+			t.Fatalf("dob %v: %v", person.DOB().Time, err)
+		}
+		if age := person.Age(now); age < minAge || age > maxAge {
+			t.Fatalf("age %d is outside %d-%d", age, minAge, maxAge)
+		}
+		if !slices.Contains(countries, person.ResidenceCountry()) {
+			t.Fatalf("lives in %s, not one of the given countries", person.ResidenceCountry())
+		}
+	}
+}
+
+func TestFakePersonWithoutCountries(t *testing.T) {
+	faker, err := NewPersonFaker()
+	if err != nil {
+		t.Fatalf("NewPersonFaker: %v", err)
+	}
+
+	if _, err := faker.FakePerson(nil); !errors.Is(err, ErrNoCountries) {
+		t.Errorf("error = %v, want %v", err, ErrNoCountries)
+	}
+}
+
+func TestAsciiFold(t *testing.T) {
+	tests := map[string]string{
+		"Raphaël":     "raphael",
+		"Łukasz":      "lukasz",
+		"Müller-SSon": "mullersson",
+		"O'Brien":     "obrien",
+	}
+
+	for in, want := range tests {
+		if got := asciiFold(in); got != want {
+			t.Errorf("asciiFold(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
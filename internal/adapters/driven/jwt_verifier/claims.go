@@ -0,0 +1,49 @@
+package jwtverifier
+
+import (
+	"encoding/json"
+	"slices"
+)
+
+// Claims are the registered JWT claims this API looks at, plus its own private ones. Times are NumericDate, seconds since the epoch.
+type Claims struct {
+	Issuer    string   `json:"iss"`
+	Subject   string   `json:"sub"`
+	Audience  Audience `json:"aud,omitempty"`
+	ExpiresAt int64    `json:"exp"`
+	NotBefore int64    `json:"nbf,omitempty"`
+	IssuedAt  int64    `json:"iat,omitempty"`
+
+	Role     string `json:"role,omitempty"`      // admin, editor or reader, reader when missing
+	PersonID string `json:"person_id,omitempty"` // the person the token was issued to, for ownership checks
+}
+
+// Audience is the aud claim, which RFC 7519 allows to be either a single string or an array of them
+type Audience []string
+
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = Audience{single}
+		return nil
+	}
+
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+// MarshalJSON writes a single audience as a plain string, the most common form
+func (a Audience) MarshalJSON() ([]byte, error) {
+	if len(a) == 1 {
+		return json.Marshal(a[0])
+	}
+	return json.Marshal([]string(a))
+}
+
+func (a Audience) contains(audience string) bool {
+	return slices.Contains(a, audience)
+}
@@ -0,0 +1,41 @@
+package jwtverifier
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// Sign issues a token, for local tooling and tests since real tokens come from the issuers. key is the HS256 secret or the 64 byte Ed25519 private key.
+func Sign(claims Claims, algorithm string, key []byte) (string, error) {
+	hdr, err := json.Marshal(header{Alg: algorithm, Typ: "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(hdr) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	var signature []byte
+	switch algorithm {
+	case AlgHS256:
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(signingInput))
+		signature = mac.Sum(nil)
+	case AlgEdDSA:
+		if len(key) != ed25519.PrivateKeySize {
+			return "", fmt.Errorf("Ed25519 private key must be %d bytes, got %d", ed25519.PrivateKeySize, len(key))
+		}
+		signature = ed25519.Sign(ed25519.PrivateKey(key), []byte(signingInput))
+	default:
+		return "", fmt.Errorf("unsupported algorithm %q", algorithm)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
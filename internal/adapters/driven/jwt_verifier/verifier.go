@@ -0,0 +1,200 @@
+// Package jwtverifier checks JWT bearer tokens signed with HS256 or EdDSA (Ed25519) using only the standard library. Keys are configured per issuer, nothing is fetched over the network.
+package jwtverifier
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"louder/internal/core/domain"
+	"louder/internal/core/service/authcore"
+	"strings"
+	"time"
+)
+
+// supported algorithms, the names used in the alg header
+const (
+	AlgHS256 = "HS256"
+	AlgEdDSA = "EdDSA"
+)
+
+// minimum HS256 secret, RFC 7518 requires a key at least as long as the hash output
+const minHMACKeyBytes = sha256.Size
+
+// tolerated clock difference between us and the issuers
+const leeway = 30 * time.Second
+
+var (
+	ErrMalformedToken    = errors.New("malformed token")
+	ErrUnknownIssuer     = errors.New("token issuer is not trusted")
+	ErrAlgorithmMismatch = errors.New("token algorithm does not match its issuer")
+	ErrBadSignature      = errors.New("token signature is invalid")
+	ErrExpired           = errors.New("token has expired")
+	ErrNotYetValid       = errors.New("token is not valid yet")
+	ErrMissingClaim      = errors.New("token is missing a required claim")
+	ErrWrongAudience     = errors.New("token is not meant for this API")
+	ErrInvalidClaim      = errors.New("token has an invalid claim")
+)
+
+// Issuer is a trusted token issuer. Key is the HS256 shared secret or the raw 32 byte Ed25519 public key.
+type Issuer struct {
+	Name      string // the iss claim
+	Algorithm string
+	Key       []byte
+}
+
+// Verifier checks tokens against a fixed set of issuers
+type Verifier struct {
+	issuers  map[string]Issuer
+	audience string
+	now      func() time.Time
+}
+
+// ensure Verifier implements the Port (safety check)
+var _ authcore.TokenVerifier = (*Verifier)(nil)
+
+// NewVerifier checks the issuers' keys up front. When audience isn't empty tokens must list it in their aud claim.
+func NewVerifier(issuers []Issuer, audience string) (*Verifier, error) {
+	byName := make(map[string]Issuer, len(issuers))
+
+	for _, iss := range issuers {
+		if iss.Name == "" {
+			return nil, errors.New("issuer name cannot be empty")
+		}
+		if _, dup := byName[iss.Name]; dup {
+			return nil, fmt.Errorf("issuer %q configured twice", iss.Name)
+		}
+
+		switch iss.Algorithm {
+		case AlgHS256:
+			if len(iss.Key) < minHMACKeyBytes {
+				return nil, fmt.Errorf("issuer %q: HS256 secret must be at least %d bytes, got %d", iss.Name, minHMACKeyBytes, len(iss.Key))
+			}
+		case AlgEdDSA:
+			if len(iss.Key) != ed25519.PublicKeySize {
+				return nil, fmt.Errorf("issuer %q: Ed25519 public key must be %d bytes, got %d", iss.Name, ed25519.PublicKeySize, len(iss.Key))
+			}
+		default:
+			return nil, fmt.Errorf("issuer %q: unsupported algorithm %q, expected %s or %s", iss.Name, iss.Algorithm, AlgHS256, AlgEdDSA)
+		}
+
+		byName[iss.Name] = iss
+	}
+
+	return &Verifier{issuers: byName, audience: audience, now: time.Now}, nil
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ,omitempty"`
+}
+
+// Verify checks the token's signature with its issuer's key, then its time and audience claims
+func (v *Verifier) Verify(_ context.Context, token string) (domain.Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return domain.Principal{}, ErrMalformedToken
+	}
+
+	var hdr header
+	if err := decodeSegment(parts[0], &hdr); err != nil {
+		return domain.Principal{}, fmt.Errorf("%w: header: %v", ErrMalformedToken, err)
+	}
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return domain.Principal{}, fmt.Errorf("%w: claims: %v", ErrMalformedToken, err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return domain.Principal{}, fmt.Errorf("%w: signature: %v", ErrMalformedToken, err)
+	}
+
+	// the issuer decides the algorithm, never the token, otherwise "none" or an HS256 token signed with a public key would get through
+	iss, ok := v.issuers[claims.Issuer]
+	if !ok {
+		return domain.Principal{}, fmt.Errorf("%w: %q", ErrUnknownIssuer, claims.Issuer)
+	}
+	if hdr.Alg != iss.Algorithm {
+		return domain.Principal{}, fmt.Errorf("%w: got %q, expected %q", ErrAlgorithmMismatch, hdr.Alg, iss.Algorithm)
+	}
+
+	signingInput := parts[0] + "." + parts[1]
+	if !verifySignature(iss, []byte(signingInput), signature) {
+		return domain.Principal{}, ErrBadSignature
+	}
+
+	if err := v.checkClaims(claims); err != nil {
+		return domain.Principal{}, err
+	}
+
+	return principalOf(claims)
+}
+
+// principalOf maps the claims to a principal, tokens without a role can only read
+func principalOf(claims Claims) (domain.Principal, error) {
+	role := domain.RoleReader
+	if claims.Role != "" {
+		var err error
+		if role, err = domain.ParseRole(claims.Role); err != nil {
+			return domain.Principal{}, fmt.Errorf("%w: role %q", ErrInvalidClaim, claims.Role)
+		}
+	}
+
+	principal, err := domain.NewPrincipal(claims.Subject, claims.Issuer, domain.AuthMethodJWT, role)
+	if err != nil {
+		return domain.Principal{}, err
+	}
+
+	if claims.PersonID != "" {
+		person, err := domain.PersonIDFromString(claims.PersonID)
+		if err != nil {
+			return domain.Principal{}, fmt.Errorf("%w: person_id %q", ErrInvalidClaim, claims.PersonID)
+		}
+		principal = principal.ActingAs(person)
+	}
+	return principal, nil
+}
+
+func (v *Verifier) checkClaims(claims Claims) error {
+	now := v.now()
+
+	switch {
+	case claims.Subject == "":
+		return fmt.Errorf("%w: sub", ErrMissingClaim)
+	case claims.ExpiresAt == 0:
+		// tokens that never expire can't be taken back, refuse them
+		return fmt.Errorf("%w: exp", ErrMissingClaim)
+	case now.After(time.Unix(claims.ExpiresAt, 0).Add(leeway)):
+		return ErrExpired
+	case claims.NotBefore != 0 && now.Before(time.Unix(claims.NotBefore, 0).Add(-leeway)):
+		return ErrNotYetValid
+	case v.audience != "" && !claims.Audience.contains(v.audience):
+		return ErrWrongAudience
+	}
+	return nil
+}
+
+func verifySignature(iss Issuer, signingInput, signature []byte) bool {
+	switch iss.Algorithm {
+	case AlgHS256:
+		mac := hmac.New(sha256.New, iss.Key)
+		mac.Write(signingInput)
+		return hmac.Equal(signature, mac.Sum(nil))
+	case AlgEdDSA:
+		return ed25519.Verify(ed25519.PublicKey(iss.Key), signingInput, signature)
+	default:
+		return false
+	}
+}
+
+func decodeSegment(segment string, dst any) error {
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, dst)
+}
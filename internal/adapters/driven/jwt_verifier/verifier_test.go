@@ -0,0 +1,149 @@
+package jwtverifier_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	jwtverifier "louder/internal/adapters/driven/jwt_verifier"
+	"louder/internal/core/domain"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	hmacSecret := []byte(strings.Repeat("s", 32))
+	edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, otherPrivate, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verifier, err := jwtverifier.NewVerifier([]jwtverifier.Issuer{
+		{Name: "https://hs.example.com", Algorithm: jwtverifier.AlgHS256, Key: hmacSecret},
+		{Name: "https://ed.example.com", Algorithm: jwtverifier.AlgEdDSA, Key: edPublic},
+	}, "louder")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	valid := func(iss string) jwtverifier.Claims {
+		return jwtverifier.Claims{
+			Issuer:    iss,
+			Subject:   "user-42",
+			Audience:  jwtverifier.Audience{"other", "louder"},
+			ExpiresAt: now.Add(time.Hour).Unix(),
+			IssuedAt:  now.Unix(),
+		}
+	}
+	sign := func(claims jwtverifier.Claims, alg string, key []byte) string {
+		token, err := jwtverifier.Sign(claims, alg, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+
+	expired := valid("https://hs.example.com")
+	expired.ExpiresAt = now.Add(-time.Hour).Unix()
+	notYet := valid("https://hs.example.com")
+	notYet.NotBefore = now.Add(time.Hour).Unix()
+	noExpiry := valid("https://hs.example.com")
+	noExpiry.ExpiresAt = 0
+	wrongAudience := valid("https://hs.example.com")
+	wrongAudience.Audience = jwtverifier.Audience{"someone-else"}
+	editor := valid("https://hs.example.com")
+	editor.Role = "editor"
+	unknownRole := valid("https://hs.example.com")
+	unknownRole.Role = "superuser"
+	badPerson := valid("https://hs.example.com")
+	badPerson.PersonID = "not-a-uuid"
+
+	tests := map[string]struct {
+		token    string
+		wantRole domain.Role // reader unless set
+		wantErr  error
+	}{
+		"valid HS256":       {token: sign(valid("https://hs.example.com"), jwtverifier.AlgHS256, hmacSecret)},
+		"valid EdDSA":       {token: sign(valid("https://ed.example.com"), jwtverifier.AlgEdDSA, edPrivate)},
+		"role claim":        {token: sign(editor, jwtverifier.AlgHS256, hmacSecret), wantRole: domain.RoleEditor},
+		"unknown role":      {token: sign(unknownRole, jwtverifier.AlgHS256, hmacSecret), wantErr: jwtverifier.ErrInvalidClaim},
+		"bad person ID":     {token: sign(badPerson, jwtverifier.AlgHS256, hmacSecret), wantErr: jwtverifier.ErrInvalidClaim},
+		"unknown issuer":    {token: sign(valid("https://evil.example.com"), jwtverifier.AlgHS256, hmacSecret), wantErr: jwtverifier.ErrUnknownIssuer},
+		"wrong HMAC secret": {token: sign(valid("https://hs.example.com"), jwtverifier.AlgHS256, []byte(strings.Repeat("x", 32))), wantErr: jwtverifier.ErrBadSignature},
+		"wrong Ed25519 key": {token: sign(valid("https://ed.example.com"), jwtverifier.AlgEdDSA, otherPrivate), wantErr: jwtverifier.ErrBadSignature},
+		"algorithm swapped": {token: sign(valid("https://ed.example.com"), jwtverifier.AlgHS256, edPublic), wantErr: jwtverifier.ErrAlgorithmMismatch},
+		"alg none":          {token: noneToken(t, valid("https://hs.example.com")), wantErr: jwtverifier.ErrAlgorithmMismatch},
+		"expired":           {token: sign(expired, jwtverifier.AlgHS256, hmacSecret), wantErr: jwtverifier.ErrExpired},
+		"not valid yet":     {token: sign(notYet, jwtverifier.AlgHS256, hmacSecret), wantErr: jwtverifier.ErrNotYetValid},
+		"no expiry":         {token: sign(noExpiry, jwtverifier.AlgHS256, hmacSecret), wantErr: jwtverifier.ErrMissingClaim},
+		"wrong audience":    {token: sign(wrongAudience, jwtverifier.AlgHS256, hmacSecret), wantErr: jwtverifier.ErrWrongAudience},
+		"not a JWT":         {token: "lk_abc", wantErr: jwtverifier.ErrMalformedToken},
+		"tampered claims":   {token: tamper(sign(valid("https://hs.example.com"), jwtverifier.AlgHS256, hmacSecret)), wantErr: jwtverifier.ErrBadSignature},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			principal, err := verifier.Verify(context.Background(), tc.token)
+
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if principal.Subject() != "user-42" {
+				t.Errorf("subject = %q, want user-42", principal.Subject())
+			}
+			wantRole := tc.wantRole
+			if wantRole == domain.RoleNone {
+				wantRole = domain.RoleReader
+			}
+			if principal.Role() != wantRole {
+				t.Errorf("role = %s, want %s", principal.Role(), wantRole)
+			}
+		})
+	}
+}
+
+func TestNewVerifierRejectsWeakKeys(t *testing.T) {
+	tests := map[string]jwtverifier.Issuer{
+		"short HMAC secret":     {Name: "a", Algorithm: jwtverifier.AlgHS256, Key: []byte("short")},
+		"truncated Ed25519 key": {Name: "a", Algorithm: jwtverifier.AlgEdDSA, Key: make([]byte, 16)},
+		"unsupported algorithm": {Name: "a", Algorithm: "RS256", Key: make([]byte, 32)},
+		"issuer without a name": {Algorithm: jwtverifier.AlgHS256, Key: make([]byte, 32)},
+	}
+
+	for name, issuer := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := jwtverifier.NewVerifier([]jwtverifier.Issuer{issuer}, ""); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+// noneToken builds an unsigned token, which must never be accepted
+func noneToken(t *testing.T, claims jwtverifier.Claims) string {
+	token, err := jwtverifier.Sign(claims, jwtverifier.AlgHS256, make([]byte, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.Split(token, ".")
+	return "eyJhbGciOiJub25lIn0." + parts[1] + "."
+}
+
+// tamper changes the subject while keeping the original signature
+func tamper(token string) string {
+	parts := strings.Split(token, ".")
+	forged, _ := jwtverifier.Sign(jwtverifier.Claims{Issuer: "https://hs.example.com", Subject: "admin", ExpiresAt: time.Now().Add(time.Hour).Unix(), Audience: jwtverifier.Audience{"louder"}}, jwtverifier.AlgHS256, []byte("whatever-whatever-whatever-whatever"))
+	return parts[0] + "." + strings.Split(forged, ".")[1] + "." + parts[2]
+}
@@ -4,7 +4,7 @@ package mockdbadapter
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 	"louder/internal/core/domain"
 	"time"
 )
@@ -19,7 +19,7 @@ type MockDBMessageRepository struct {
 func NewMockDBMessageRepository(startMessage string) *MockDBMessageRepository {
 	fakeMsgObj := newMsgWithTime(startMessage)
 
-	log.Println("Talking to mockDB message repo:", startMessage)
+	slog.Debug("Talking to mockDB message repo", "start_message", startMessage)
 
 	return &MockDBMessageRepository{
 		mockDB: fakeMsgObj,
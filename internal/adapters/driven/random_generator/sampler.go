@@ -0,0 +1,98 @@
+package randomgenerator
+
+import (
+	"container/heap"
+	"louder/internal/core/service/samplingcore"
+	"math"
+	"math/rand/v2"
+)
+
+var _ samplingcore.RandomSource = (*StdLibGenerator)(nil)
+
+// NewSeed returns a fresh random seed
+func (s *StdLibGenerator) NewSeed() uint64 {
+	return rand.Uint64()
+}
+
+// SampleIndices picks up to k distinct indices in [0, population) using a PCG source seeded with seed, so draws are reproducible
+func (s *StdLibGenerator) SampleIndices(population, k int, weights []float64, seed uint64) []int {
+	if population <= 0 || k <= 0 {
+		return []int{}
+	}
+
+	rng := rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))
+
+	if weights == nil {
+		return uniformSample(rng, population, min(k, population))
+	}
+	return weightedSample(rng, weights, k)
+}
+
+// uniformSample runs a partial Fisher-Yates shuffle over a virtual [0, n) slice. Only swapped positions are stored, so it costs O(k) no matter how big n is.
+func uniformSample(rng *rand.Rand, n, k int) []int {
+	swapped := make(map[int]int, k)
+	at := func(i int) int {
+		if v, ok := swapped[i]; ok {
+			return v
+		}
+		return i
+	}
+
+	picked := make([]int, k)
+	for i := range k {
+		j := i + rng.IntN(n-i)
+		picked[i] = at(j)
+		swapped[j] = at(i)
+	}
+
+	return picked
+}
+
+// weightedSample implements Efraimidis-Spirakis sampling without replacement: every index gets the key -ln(u)/w and the k smallest keys win. A bounded heap keeps it at O(n log k).
+func weightedSample(rng *rand.Rand, weights []float64, k int) []int {
+	h := &maxKeyHeap{}
+
+	for i, w := range weights {
+		if w <= 0 {
+			continue
+		}
+		// 1-Float64 is in (0, 1] so the log never blows up
+		key := -math.Log(1-rng.Float64()) / w
+
+		switch {
+		case h.Len() < k:
+			heap.Push(h, keyedIndex{index: i, key: key})
+		case key < (*h)[0].key:
+			(*h)[0] = keyedIndex{index: i, key: key}
+			heap.Fix(h, 0)
+		}
+	}
+
+	// popping the max-heap yields the largest key first, fill from the back so the smallest key is drawn first
+	picked := make([]int, h.Len())
+	for i := len(picked) - 1; i >= 0; i-- {
+		picked[i] = heap.Pop(h).(keyedIndex).index
+	}
+
+	return picked
+}
+
+type keyedIndex struct {
+	index int
+	key   float64
+}
+
+// maxKeyHeap keeps the largest key on top so it can be evicted when a smaller one turns up
+type maxKeyHeap []keyedIndex
+
+func (h maxKeyHeap) Len() int           { return len(h) }
+func (h maxKeyHeap) Less(i, j int) bool { return h[i].key > h[j].key }
+func (h maxKeyHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *maxKeyHeap) Push(x any)        { *h = append(*h, x.(keyedIndex)) }
+func (h *maxKeyHeap) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
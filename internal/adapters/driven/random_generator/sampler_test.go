@@ -0,0 +1,72 @@
+package randomgenerator_test
+
+import (
+	randomgenerator "louder/internal/adapters/driven/random_generator"
+	"slices"
+	"testing"
+)
+
+func TestSampleIndices(t *testing.T) {
+	tt := map[string]struct {
+		population int
+		k          int
+		weights    []float64
+		wantLen    int
+		neverPick  []int
+	}{
+		"uniform draw is capped by population": {
+			population: 5,
+			k:          10,
+			wantLen:    5,
+		},
+		"uniform draw over a large population": {
+			population: 1_000_000,
+			k:          50,
+			wantLen:    50,
+		},
+		"zero weights are never picked": {
+			population: 4,
+			k:          4,
+			weights:    []float64{0, 1, 0, 5},
+			wantLen:    2,
+			neverPick:  []int{0, 2},
+		},
+		"empty population": {
+			population: 0,
+			k:          3,
+			wantLen:    0,
+		},
+	}
+
+	gen := randomgenerator.NewStdLibGenerator()
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			got := gen.SampleIndices(tc.population, tc.k, tc.weights, 42)
+
+			if len(got) != tc.wantLen {
+				t.Fatalf("unexpected sample size: expected %d got %d", tc.wantLen, len(got))
+			}
+
+			seen := make(map[int]bool, len(got))
+			for _, i := range got {
+				if i < 0 || i >= tc.population {
+					t.Fatalf("index %d out of range [0, %d)", i, tc.population)
+				}
+				if seen[i] {
+					t.Fatalf("index %d picked twice", i)
+				}
+				if slices.Contains(tc.neverPick, i) {
+					t.Fatalf("index %d has weight 0 but was picked", i)
+				}
+				seen[i] = true
+			}
+
+			// same seed, same draw
+			again := gen.SampleIndices(tc.population, tc.k, tc.weights, 42)
+			if !slices.Equal(got, again) {
+				t.Fatalf("draw not reproducible: %v vs %v", got, again)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package randomgenerator
 
 import (
+	"errors"
 	"fmt"
 	"louder/internal/core/domain"
 	"louder/internal/core/service/randomnumberscore"
@@ -19,25 +20,19 @@ func (s *StdLibGenerator) GenerateRandomNumber() domain.RandomNumber {
 	return domain.RandomNumber(rand.Uint())
 }
 
-const (
-	maxDice  = 10
-	maxSides = 20
-)
+var ErrNoDiceToRoll = errors.New("error cannot roll zero dice or dice without sides")
 
+// GenerateDiceRoll rolls numDice dice of the given sides. Limits are a business rule and are enforced by the core, not here.
 func (s *StdLibGenerator) GenerateDiceRoll(numDice, sides uint) (*domain.RandomDice, error) {
-
-	newDiceRoll := domain.RandomDice{
-		MaxDice:  maxDice,
-		MaxSides: maxSides,
+	// rand.IntN panics on 0 so this guard stays even if the core already validated
+	if numDice == 0 || sides == 0 {
+		return nil, fmt.Errorf("error adapter failed: %w", ErrNoDiceToRoll)
 	}
 
-	if err := newDiceRoll.ValidateDiceParameters(numDice, sides); err != nil {
-		return nil, fmt.Errorf("error adapter failed: %w", err)
+	newDiceRoll := domain.RandomDice{
+		Roll: make([]uint, numDice),
 	}
 
-	newDiceRoll.Roll = make([]uint, numDice, numDice)
-	// var sum uint
-
 	for i := range numDice {
 		newDiceRoll.Roll[i] = uint(rand.IntN(int(sides)) + 1)
 		newDiceRoll.RollSum += newDiceRoll.Roll[i]
@@ -0,0 +1,71 @@
+package stdlibapiadapter
+
+// Operation describes one route for the OpenAPI document. Resources list theirs next to RegisterRoutes, see Documented.
+type Operation struct {
+	Pattern     string // the route pattern exactly as registered, e.g. "GET /person/{id}"
+	Summary     string
+	Description string
+	Tags        []string
+	PathParams  []Param // wildcards without an entry are documented as plain strings
+	QueryParams []Param
+	RequestBody any         // zero value of the JSON body DTO, nil when the route takes no body
+	Responses   map[int]any // status code to zero value of the JSON DTO, nil for an empty body. Errors are always documented as problem+json.
+	Deprecated  bool
+}
+
+// Param describes a path or query parameter
+type Param struct {
+	Name        string
+	Description string
+	Type        string // JSON schema type: "string" (default), "integer", "number" or "boolean"
+	Format      string // optional JSON schema format, e.g. "uuid"
+	Required    bool   // path parameters are always required
+	Repeated    bool   // the parameter may be given several times
+}
+
+// Documented is implemented by resources that describe their routes. Every route a resource registers needs a matching Operation or the OpenAPI document can't be built.
+type Documented interface {
+	Operations() []Operation
+}
+
+// sampleQueryParams documents the query parameters read by ParseSampleRequest
+var sampleQueryParams = []Param{
+	{Name: "count", Type: "integer", Description: "how many distinct items to draw, defaults to 1"},
+	{Name: "seed", Type: "integer", Description: "the same seed over the same data returns the same items"},
+	{Name: "weight", Repeated: true, Description: `"<id>:<weight>" gives an item a relative weight, defaults to 1 and 0 excludes it`},
+}
+
+// SampleQueryParams returns the query parameters shared by every random sampling endpoint followed by extra, the endpoint's own
+func SampleQueryParams(extra ...Param) []Param {
+	params := make([]Param, 0, len(sampleQueryParams)+len(extra))
+	params = append(params, sampleQueryParams...)
+	return append(params, extra...)
+}
+
+// operationsOf returns the operations of a resource, none if it isn't Documented
+func operationsOf(resource Resource) []Operation {
+	if documented, ok := resource.(Documented); ok {
+		return documented.Operations()
+	}
+	return nil
+}
+
+// Operations forwards to the wrapped resource, middleware doesn't change what a route does
+func (rm *resourceWithMiddleware) Operations() []Operation {
+	return operationsOf(rm.resource)
+}
+
+// Operations returns the operations of every resource in the group, with the group's prefix added to their patterns
+func (g *Group) Operations() []Operation {
+	prefix := g.routePrefix()
+
+	var ops []Operation
+	for _, resource := range g.resources {
+		for _, op := range operationsOf(resource) {
+			op.Pattern = prefixPattern(prefix, op.Pattern)
+			op.Deprecated = op.Deprecated || g.deprecated
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
@@ -0,0 +1,24 @@
+package auditadapter
+
+// FieldChangeResponse defines the JSON payload for one changed field, before is null on creation and after on deletion
+type FieldChangeResponse struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// AuditEntryResponse defines the JSON payload for an audit entry
+type AuditEntryResponse struct {
+	ID         string                         `json:"id"`
+	OccurredAt string                         `json:"occurred_at"`
+	Actor      string                         `json:"actor"`
+	Action     string                         `json:"action"`
+	EntityType string                         `json:"entity_type"`
+	EntityID   string                         `json:"entity_id"`
+	Changes    map[string]FieldChangeResponse `json:"changes"`
+	RequestID  string                         `json:"request_id,omitempty"`
+}
+
+// AuditEntriesResponse defines the JSON payload for a page of the audit trail
+type AuditEntriesResponse struct {
+	Entries []AuditEntryResponse `json:"entries"`
+}
@@ -0,0 +1,97 @@
+package auditadapter
+
+import (
+	"errors"
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
+	"louder/internal/core/domain"
+	"louder/internal/core/errkind"
+	"louder/internal/core/service/auditcore"
+	"louder/pkg/logging"
+	"louder/pkg/types"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AuditHandler handles HTTP requests for the audit trail
+type AuditHandler struct {
+	service auditcore.AuditService
+}
+
+// NewAuditHandler creates a new AuditHandler
+func NewAuditHandler(srv auditcore.AuditService) *AuditHandler {
+	return &AuditHandler{
+		service: srv,
+	}
+}
+
+// HandleListAuditEntries handles get requests to /admin/audit, filtering by entity, actor and time range
+func (h *AuditHandler) HandleListAuditEntries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter, validationErrors := parseAuditFilter(r.URL.Query())
+	if len(validationErrors) > 0 {
+		stdlibapiadapter.RespondWithError(w, r, errors.Join(validationErrors...))
+		return
+	}
+
+	entries, err := h.service.ListEntries(ctx, filter)
+	if err != nil {
+		logging.FromContext(ctx).Warn("HandleListAuditEntries: service.ListEntries failed", "err", err)
+		stdlibapiadapter.RespondWithError(w, r, err)
+		return
+	}
+
+	stdlibapiadapter.RespondWithJSON(w, http.StatusOK, toAuditEntriesResponse(entries))
+}
+
+// parseAuditFilter reads the query parameters documented in Operations, every invalid one is reported
+func parseAuditFilter(params url.Values) (domain.AuditFilter, []error) {
+	validationErrors := make([]error, 0)
+	filter := domain.AuditFilter{
+		EntityID: strings.TrimSpace(params.Get("entity_id")),
+		Actor:    strings.TrimSpace(params.Get("actor")),
+	}
+
+	if typeParam := strings.TrimSpace(params.Get("entity_type")); typeParam != "" {
+		entityType, err := domain.ParseAuditEntityType(typeParam)
+		if err != nil {
+			validationErrors = append(validationErrors, err)
+		}
+		filter.EntityType = entityType
+	}
+
+	var err error
+	if filter.From, err = parseTimeParam(params, "from"); err != nil {
+		validationErrors = append(validationErrors, err)
+	}
+	if filter.To, err = parseTimeParam(params, "to"); err != nil {
+		validationErrors = append(validationErrors, err)
+	}
+
+	if limitParam := strings.TrimSpace(params.Get("limit")); limitParam != "" {
+		limit, err := strconv.Atoi(limitParam)
+		if err != nil {
+			validationErrors = append(validationErrors, errkind.NewField("limit", "must be a valid integer"))
+		}
+		filter.Limit = limit
+	}
+
+	return filter, validationErrors
+}
+
+// parseTimeParam reads an optional time, the zero time when it's missing
+func parseTimeParam(params url.Values, name string) (time.Time, error) {
+	param := strings.TrimSpace(params.Get(name))
+	if param == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := types.ParseUTCTime(param)
+	if err != nil {
+		return time.Time{}, errkind.NewField(name, "must be RFC3339 or YYYY-MM-DD")
+	}
+	return t.Time, nil
+}
@@ -0,0 +1,32 @@
+package auditadapter
+
+import (
+	"louder/internal/core/domain"
+	"time"
+)
+
+func toAuditEntryResponse(e *domain.AuditEntry) AuditEntryResponse {
+	changes := make(map[string]FieldChangeResponse, len(e.Changes()))
+	for field, change := range e.Changes() {
+		changes[field] = FieldChangeResponse{Before: change.Before, After: change.After}
+	}
+
+	return AuditEntryResponse{
+		ID:         e.ID(),
+		OccurredAt: e.OccurredAt().Format(time.RFC3339),
+		Actor:      e.Actor(),
+		Action:     string(e.Action()),
+		EntityType: string(e.EntityType()),
+		EntityID:   e.EntityID(),
+		Changes:    changes,
+		RequestID:  e.RequestID(),
+	}
+}
+
+func toAuditEntriesResponse(entries []*domain.AuditEntry) *AuditEntriesResponse {
+	resp := &AuditEntriesResponse{Entries: make([]AuditEntryResponse, 0, len(entries))}
+	for _, e := range entries {
+		resp.Entries = append(resp.Entries, toAuditEntryResponse(e))
+	}
+	return resp
+}
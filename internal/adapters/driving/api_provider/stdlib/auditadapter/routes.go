@@ -0,0 +1,35 @@
+package auditadapter
+
+import (
+	"fmt"
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
+	"louder/internal/core/domain"
+	"louder/internal/core/service/auditcore"
+	"net/http"
+)
+
+const AuditRoute = "/admin/audit"
+
+func (h *AuditHandler) RegisterRoutes(mux stdlibapiadapter.Routes) {
+	mux.Handle(http.MethodGet+" "+AuditRoute, stdlibapiadapter.Authorize(domain.RoleAdmin, h.HandleListAuditEntries))
+}
+
+func (h *AuditHandler) Operations() []stdlibapiadapter.Operation {
+	return []stdlibapiadapter.Operation{
+		{
+			Pattern:     http.MethodGet + " " + AuditRoute,
+			Summary:     "Query the audit trail",
+			Description: "Every create, update and delete on people, countries and currencies, newest first. Each entry has the fields that changed with their values before and after.",
+			Tags:        []string{"audit"},
+			QueryParams: []stdlibapiadapter.Param{
+				{Name: "entity_type", Description: "person, country or currency"},
+				{Name: "entity_id", Description: "ID of the entity, a person's UUID or a country or currency code"},
+				{Name: "actor", Description: `who made the change, "<issuer>/<subject>" as in the logs or "system"`},
+				{Name: "from", Description: "only changes at or after this time, RFC3339 or YYYY-MM-DD"},
+				{Name: "to", Description: "only changes before this time, RFC3339 or YYYY-MM-DD"},
+				{Name: "limit", Type: "integer", Description: fmt.Sprintf("at most this many entries, defaults to %d and can't exceed %d", auditcore.DefaultLimit, auditcore.MaxLimit)},
+			},
+			Responses: map[int]any{http.StatusOK: AuditEntriesResponse{}},
+		},
+	}
+}
@@ -0,0 +1,114 @@
+package stdlibapiadapter
+
+import (
+	"context"
+	"louder/internal/core/domain"
+	"louder/internal/core/service/authcore"
+	"louder/pkg/logging"
+	"net/http"
+	"strings"
+)
+
+// APIKeyHeader is where clients that can't set Authorization put their API key
+const APIKeyHeader = "X-API-Key"
+
+// sent with every 401 as RFC 9110 asks, bearer tokens cover both API keys and JWTs
+const wwwAuthenticate = `Bearer realm="louder"`
+
+// Authenticator checks credentials, authcore.AuthService is the real one
+type Authenticator interface {
+	AuthenticateAPIKey(ctx context.Context, secret string) (domain.Principal, error)
+	AuthenticateToken(ctx context.Context, token string) (domain.Principal, error)
+}
+
+// Authenticate resolves the caller from X-API-Key or "Authorization: Bearer", where a bearer starting with the API key prefix is an API key and anything else a JWT. The principal goes into the context (see authcore.PrincipalFromContext) and tags the request logger. Bad credentials get a 401, requests without any go through anonymously and Authorize refuses them on the routes.
+func Authenticate(auth Authenticator) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			apiKey, token := credentials(r)
+			if apiKey == "" && token == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			var principal domain.Principal
+			var err error
+			if apiKey != "" {
+				principal, err = auth.AuthenticateAPIKey(r.Context(), apiKey)
+			} else {
+				principal, err = auth.AuthenticateToken(r.Context(), token)
+			}
+			if err != nil {
+				logging.Audit(r.Context()).Warn("authentication failed", "method", authMethod(apiKey), "err", err)
+				respondAuthError(w, r, err)
+				return
+			}
+
+			ctx := authcore.WithPrincipal(r.Context(), principal)
+			ctx = logging.WithLogger(ctx, logging.FromContext(ctx).With("principal", principal.String()))
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// Anonymous lets requests without credentials act as principal, for running with authentication switched off. It must come after Authenticate.
+func Anonymous(principal domain.Principal) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := authcore.PrincipalFromContext(r.Context()); !ok {
+				r = r.WithContext(authcore.WithPrincipal(r.Context(), principal))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Authorize only lets callers with at least role through to handler, the others get a 401 if they're anonymous and a 403 if they aren't. Resources wrap each route with it in RegisterRoutes so a route's permission sits next to its pattern.
+func Authorize(role domain.Role, handler http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		action := r.Pattern
+		if action == "" {
+			action = r.Method + " " + r.URL.Path
+		}
+
+		if _, err := authcore.Authorize(r.Context(), authcore.ContextCaller{}, action, authcore.HasRole(role)); err != nil {
+			respondAuthError(w, r, err)
+			return
+		}
+		handler(w, r)
+	})
+}
+
+// credentials returns the API key or the bearer token sent with the request, at most one of them is set
+func credentials(r *http.Request) (apiKey, token string) {
+	if key := strings.TrimSpace(r.Header.Get(APIKeyHeader)); key != "" {
+		return key, ""
+	}
+
+	scheme, value, found := strings.Cut(strings.TrimSpace(r.Header.Get("Authorization")), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", ""
+	}
+
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, domain.APIKeyPrefix) {
+		return value, ""
+	}
+	return "", value
+}
+
+func authMethod(apiKey string) domain.AuthMethod {
+	if apiKey != "" {
+		return domain.AuthMethodAPIKey
+	}
+	return domain.AuthMethodJWT
+}
+
+// respondAuthError responds with err, adding the challenge when it's a 401
+func respondAuthError(w http.ResponseWriter, r *http.Request, err error) {
+	if StatusForError(err) == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", wwwAuthenticate)
+	}
+	RespondWithError(w, r, err)
+}
@@ -0,0 +1,94 @@
+package stdlibapiadapter_test
+
+import (
+	"context"
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
+	"louder/internal/core/domain"
+	"louder/internal/core/errkind"
+	"louder/internal/core/service/authcore"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const (
+	goodKey   = domain.APIKeyPrefix + "good"
+	goodToken = "header.payload.signature"
+)
+
+// fakeAuthenticator accepts goodKey, a reader, and goodToken, an editor
+type fakeAuthenticator struct{}
+
+func (fakeAuthenticator) AuthenticateAPIKey(_ context.Context, secret string) (domain.Principal, error) {
+	if secret != goodKey {
+		return domain.Principal{}, errkind.New(errkind.Unauthenticated, "invalid API key")
+	}
+	return domain.NewPrincipal("key-1", domain.APIKeyIssuer, domain.AuthMethodAPIKey, domain.RoleReader)
+}
+
+func (fakeAuthenticator) AuthenticateToken(_ context.Context, token string) (domain.Principal, error) {
+	if token != goodToken {
+		return domain.Principal{}, errkind.New(errkind.Unauthenticated, "invalid bearer token")
+	}
+	return domain.NewPrincipal("alice", "https://idp.example.com", domain.AuthMethodJWT, domain.RoleEditor)
+}
+
+func TestAuthenticateAndAuthorize(t *testing.T) {
+	tests := map[string]struct {
+		headers     map[string]string
+		role        domain.Role // the route's required role, none to skip Authorize
+		anonymous   bool        // authentication switched off
+		wantStatus  int
+		wantSubject string // empty for an anonymous request reaching the handler
+	}{
+		"anonymous on an open handler":      {wantStatus: http.StatusOK},
+		"anonymous on a protected route":    {role: domain.RoleReader, wantStatus: http.StatusUnauthorized},
+		"API key header":                    {headers: map[string]string{stdlibapiadapter.APIKeyHeader: goodKey}, role: domain.RoleReader, wantStatus: http.StatusOK, wantSubject: "key-1"},
+		"API key as bearer":                 {headers: map[string]string{"Authorization": "Bearer " + goodKey}, role: domain.RoleReader, wantStatus: http.StatusOK, wantSubject: "key-1"},
+		"JWT, scheme in lower case":         {headers: map[string]string{"Authorization": "bearer " + goodToken}, role: domain.RoleEditor, wantStatus: http.StatusOK, wantSubject: "alice"},
+		"role too low":                      {headers: map[string]string{stdlibapiadapter.APIKeyHeader: goodKey}, role: domain.RoleEditor, wantStatus: http.StatusForbidden},
+		"bad API key on an open handler":    {headers: map[string]string{stdlibapiadapter.APIKeyHeader: domain.APIKeyPrefix + "bad"}, wantStatus: http.StatusUnauthorized},
+		"bad token":                         {headers: map[string]string{"Authorization": "Bearer nope"}, role: domain.RoleReader, wantStatus: http.StatusUnauthorized},
+		"other schemes count as anonymous":  {headers: map[string]string{"Authorization": "Basic dXNlcjpwYXNz"}, role: domain.RoleReader, wantStatus: http.StatusUnauthorized},
+		"auth off, anonymous can edit":      {anonymous: true, role: domain.RoleEditor, wantStatus: http.StatusOK, wantSubject: "anonymous"},
+		"auth off, admin still needs a key": {anonymous: true, role: domain.RoleAdmin, wantStatus: http.StatusForbidden},
+		"auth off, credentials still count": {headers: map[string]string{stdlibapiadapter.APIKeyHeader: goodKey}, anonymous: true, role: domain.RoleAdmin, wantStatus: http.StatusForbidden},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var gotSubject string
+			route := func(w http.ResponseWriter, r *http.Request) {
+				if p, ok := authcore.PrincipalFromContext(r.Context()); ok {
+					gotSubject = p.Subject()
+				}
+			}
+
+			handler := http.Handler(http.HandlerFunc(route))
+			if tc.role != domain.RoleNone {
+				handler = stdlibapiadapter.Authorize(tc.role, route)
+			}
+			if tc.anonymous {
+				handler = stdlibapiadapter.Anonymous(domain.AnonymousPrincipal())(handler)
+			}
+			handler = stdlibapiadapter.Authenticate(fakeAuthenticator{})(handler)
+
+			req := httptest.NewRequest(http.MethodGet, "/whatever", nil)
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", rec.Code, tc.wantStatus, rec.Body.String())
+			}
+			if gotSubject != tc.wantSubject {
+				t.Errorf("principal subject = %q, want %q", gotSubject, tc.wantSubject)
+			}
+			if challenged := rec.Header().Get("WWW-Authenticate") != ""; challenged != (rec.Code == http.StatusUnauthorized) {
+				t.Errorf("WWW-Authenticate sent: %v on a %d", challenged, rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package authadapter
+
+// CreateAPIKeyRequest defines the expected JSON payload for creating an API key
+type CreateAPIKeyRequest struct {
+	Name string `json:"name"`           // what the key is for, e.g. "billing service"
+	Role string `json:"role,omitempty"` // admin, editor or reader (the default)
+}
+
+// APIKeyResponse defines the JSON payload for an API key, the secret is never part of it
+type APIKeyResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Role      string `json:"role"`
+	Prefix    string `json:"prefix"`
+	CreatedAt string `json:"created_at"`
+	LastUsed  string `json:"last_used,omitempty"`
+	RevokedAt string `json:"revoked_at,omitempty"`
+	Active    bool   `json:"active"`
+}
+
+// CreatedAPIKeyResponse defines the JSON payload returned once, when a key is created. The secret can't be recovered afterwards.
+type CreatedAPIKeyResponse struct {
+	APIKeyResponse
+	Secret string `json:"secret"`
+}
+
+// APIKeysResponse defines the JSON payload for the list of API keys
+type APIKeysResponse struct {
+	APIKeys []APIKeyResponse `json:"api_keys"`
+}
+
+// WhoAmIResponse defines the JSON payload describing the caller
+type WhoAmIResponse struct {
+	Subject string `json:"subject"`
+	Issuer  string `json:"issuer"`
+	Method  string `json:"method"`
+	Role    string `json:"role"`
+	Person  string `json:"person_id,omitempty"` // set when the caller is a person, not a service
+}
@@ -0,0 +1,98 @@
+package authadapter
+
+import (
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
+	"louder/internal/core/domain"
+	"louder/internal/core/service/authcore"
+	"louder/pkg/logging"
+	"net/http"
+)
+
+// AuthHandler handles HTTP requests for API key management and for the caller's identity
+type AuthHandler struct {
+	service authcore.AuthService
+}
+
+// NewAuthHandler creates a new AuthHandler
+func NewAuthHandler(srv authcore.AuthService) *AuthHandler {
+	return &AuthHandler{
+		service: srv,
+	}
+}
+
+// HandleCreateAPIKey handles post requests to /admin/api-keys, the response is the only place the secret ever shows up
+func (h *AuthHandler) HandleCreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req CreateAPIKeyRequest
+	if err := stdlibapiadapter.DecodeJSONBody(r, &req); err != nil {
+		stdlibapiadapter.RespondWithError(w, r, err)
+		return
+	}
+
+	role := domain.RoleReader
+	if req.Role != "" {
+		var err error
+		if role, err = domain.ParseRole(req.Role); err != nil {
+			stdlibapiadapter.RespondWithError(w, r, err)
+			return
+		}
+	}
+
+	key, secret, err := h.service.CreateAPIKey(ctx, req.Name, role)
+	if err != nil {
+		logging.FromContext(ctx).Warn("HandleCreateAPIKey: service.CreateAPIKey failed", "err", err)
+		stdlibapiadapter.RespondWithError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	stdlibapiadapter.RespondWithJSON(w, http.StatusCreated, CreatedAPIKeyResponse{
+		APIKeyResponse: toAPIKeyResponse(key),
+		Secret:         secret,
+	})
+}
+
+// HandleListAPIKeys handles get requests to /admin/api-keys
+func (h *AuthHandler) HandleListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	keys, err := h.service.ListAPIKeys(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Error("HandleListAPIKeys: service.ListAPIKeys failed", "err", err)
+		stdlibapiadapter.RespondWithError(w, r, err)
+		return
+	}
+
+	stdlibapiadapter.RespondWithJSON(w, http.StatusOK, toAPIKeysResponse(keys))
+}
+
+// HandleRevokeAPIKey handles delete requests to /admin/api-keys/{id}
+func (h *AuthHandler) HandleRevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := stdlibapiadapter.PathUUIDv7(r, "id")
+	if err != nil {
+		stdlibapiadapter.RespondWithError(w, r, err)
+		return
+	}
+
+	if err := h.service.RevokeAPIKey(ctx, domain.APIKeyID(id.String())); err != nil {
+		logging.FromContext(ctx).Warn("HandleRevokeAPIKey: service.RevokeAPIKey failed", "id", id, "err", err)
+		stdlibapiadapter.RespondWithError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleWhoAmI handles get requests to /auth/whoami, telling the caller who the API thinks they are
+func (h *AuthHandler) HandleWhoAmI(w http.ResponseWriter, r *http.Request) {
+	principal, ok := authcore.PrincipalFromContext(r.Context())
+	if !ok {
+		stdlibapiadapter.RespondWithError(w, r, authcore.ErrUnauthenticated)
+		return
+	}
+
+	stdlibapiadapter.RespondWithJSON(w, http.StatusOK, toWhoAmIResponse(principal))
+}
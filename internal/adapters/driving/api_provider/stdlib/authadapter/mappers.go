@@ -0,0 +1,48 @@
+package authadapter
+
+import (
+	"louder/internal/core/domain"
+	"time"
+)
+
+func toAPIKeyResponse(k *domain.APIKey) APIKeyResponse {
+	return APIKeyResponse{
+		ID:        string(k.ID()),
+		Name:      k.Name(),
+		Role:      k.Role().String(),
+		Prefix:    k.Prefix(),
+		CreatedAt: k.CreatedAt().Format(time.RFC3339),
+		LastUsed:  formatOptionalTime(k.LastUsed()),
+		RevokedAt: formatOptionalTime(k.RevokedAt()),
+		Active:    k.Active(),
+	}
+}
+
+func toAPIKeysResponse(keys []*domain.APIKey) *APIKeysResponse {
+	resp := &APIKeysResponse{APIKeys: make([]APIKeyResponse, 0, len(keys))}
+	for _, k := range keys {
+		resp.APIKeys = append(resp.APIKeys, toAPIKeyResponse(k))
+	}
+	return resp
+}
+
+func toWhoAmIResponse(p domain.Principal) WhoAmIResponse {
+	resp := WhoAmIResponse{
+		Subject: p.Subject(),
+		Issuer:  p.Issuer(),
+		Method:  string(p.Method()),
+		Role:    p.Role().String(),
+	}
+	if person, ok := p.Person(); ok {
+		resp.Person = person.String()
+	}
+	return resp
+}
+
+// formatOptionalTime leaves zero times out of the JSON
+func formatOptionalTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
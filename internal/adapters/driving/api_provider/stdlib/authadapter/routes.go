@@ -0,0 +1,54 @@
+package authadapter
+
+import (
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
+	"louder/internal/core/domain"
+	"net/http"
+)
+
+const (
+	APIKeysRoute = "/admin/api-keys"
+	APIKeyRoute  = "/admin/api-keys/{id}"
+	WhoAmIRoute  = "/auth/whoami"
+)
+
+func (h *AuthHandler) RegisterRoutes(mux stdlibapiadapter.Routes) {
+	mux.Handle(http.MethodPost+" "+APIKeysRoute, stdlibapiadapter.Authorize(domain.RoleAdmin, h.HandleCreateAPIKey))
+	mux.Handle(http.MethodGet+" "+APIKeysRoute, stdlibapiadapter.Authorize(domain.RoleAdmin, h.HandleListAPIKeys))
+	mux.Handle(http.MethodDelete+" "+APIKeyRoute, stdlibapiadapter.Authorize(domain.RoleAdmin, h.HandleRevokeAPIKey))
+	mux.Handle(http.MethodGet+" "+WhoAmIRoute, stdlibapiadapter.Authorize(domain.RoleReader, h.HandleWhoAmI))
+}
+
+func (h *AuthHandler) Operations() []stdlibapiadapter.Operation {
+	tags := []string{"auth"}
+
+	return []stdlibapiadapter.Operation{
+		{
+			Pattern:     http.MethodPost + " " + APIKeysRoute,
+			Summary:     "Create an API key",
+			Description: "The secret is only returned here, store it straight away. Send it as X-API-Key or as a bearer token.",
+			Tags:        tags,
+			RequestBody: CreateAPIKeyRequest{},
+			Responses:   map[int]any{http.StatusCreated: CreatedAPIKeyResponse{}},
+		},
+		{
+			Pattern:   http.MethodGet + " " + APIKeysRoute,
+			Summary:   "List API keys, revoked ones included",
+			Tags:      tags,
+			Responses: map[int]any{http.StatusOK: APIKeysResponse{}},
+		},
+		{
+			Pattern:    http.MethodDelete + " " + APIKeyRoute,
+			Summary:    "Revoke an API key",
+			Tags:       tags,
+			PathParams: []stdlibapiadapter.Param{{Name: "id", Format: "uuid", Description: "version 7 UUID of the key"}},
+			Responses:  map[int]any{http.StatusNoContent: nil},
+		},
+		{
+			Pattern:   http.MethodGet + " " + WhoAmIRoute,
+			Summary:   "Describe the authenticated caller",
+			Tags:      tags,
+			Responses: map[int]any{http.StatusOK: WhoAmIResponse{}},
+		},
+	}
+}
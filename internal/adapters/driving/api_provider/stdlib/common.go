@@ -1,23 +1,119 @@
 package stdlibapiadapter
 
 import (
+	"context"
 	"encoding/json"
-	"log"
+	"errors"
+	"fmt"
+	"log/slog"
+	"louder/internal/core/errkind"
+	"louder/pkg/logging"
 	"net/http"
+	"strings"
 )
 
+const problemContentType = "application/problem+json"
+
 func RespondWithJSON(w http.ResponseWriter, code int, payload any) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(code)
 
 	if payload != nil {
 		if err := json.NewEncoder(w).Encode(payload); err != nil {
-			log.Printf("Failed to encode JSON response: %v", err)
+			slog.Error("failed to encode JSON response", "err", err)
 		}
 	}
 }
 
-func RespondWithError(w http.ResponseWriter, code int, message string) {
-	// We wrap the single message in our standard ErrorResponse struct
-	RespondWithJSON(w, code, ErrorResponse{ErrorMsgs: []string{message}})
+// RespondWithProblem writes p as application/problem+json, filling in the type and title if they were left empty
+func RespondWithProblem(w http.ResponseWriter, p Problem) {
+	if p.Type == "" {
+		p.Type = "about:blank" // the status code says it all
+	}
+	if p.Title == "" {
+		p.Title = http.StatusText(p.Status)
+	}
+
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(p.Status)
+
+	if err := json.NewEncoder(w).Encode(p); err != nil {
+		slog.Error("failed to encode problem response", "err", err)
+	}
+}
+
+// RespondWithError is the single place core errors are turned into HTTP responses. The status comes from the error kind, internal details are only logged.
+func RespondWithError(w http.ResponseWriter, r *http.Request, err error) {
+	status := StatusForError(err)
+	logger := logging.FromContext(r.Context())
+	problem := Problem{
+		Status:    status,
+		Instance:  r.URL.Path,
+		RequestID: logging.RequestID(r.Context()),
+	}
+
+	switch status {
+	case http.StatusInternalServerError:
+		logger.Error("request failed", "status", status, "err", err)
+		problem.Detail = "an unexpected error occurred"
+	case http.StatusServiceUnavailable:
+		logger.Warn("request failed, dependency unavailable", "status", status, "err", err)
+		problem.Detail = "a dependency is temporarily unavailable, try again later"
+	default:
+		// errors.Join separates with new lines, which read badly in a single JSON string
+		problem.Detail = strings.ReplaceAll(err.Error(), "\n", "; ")
+	}
+
+	for _, field := range errkind.Fields(err) {
+		problem.Errors = append(problem.Errors, FieldProblem{Field: field.Field, Message: field.Message})
+	}
+
+	RespondWithProblem(w, problem)
+}
+
+// StatusForError maps an error to its HTTP status through its kind
+func StatusForError(err error) int {
+	// the request ran out of time somewhere down the line, not the caller's fault
+	if errors.Is(err, context.DeadlineExceeded) {
+		return http.StatusServiceUnavailable
+	}
+
+	// set by MaxBodySize
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return http.StatusRequestEntityTooLarge
+	}
+
+	switch errkind.Of(err) {
+	case errkind.Invalid:
+		return http.StatusBadRequest
+	case errkind.NotFound:
+		return http.StatusNotFound
+	case errkind.Conflict:
+		return http.StatusConflict
+	case errkind.Unavailable:
+		return http.StatusServiceUnavailable
+	case errkind.Unauthenticated:
+		return http.StatusUnauthorized
+	case errkind.Forbidden:
+		return http.StatusForbidden
+	case errkind.RateLimited:
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// DecodeJSONBody decodes the request body into dst, a malformed body is reported as an Invalid error on the "body" field and one over the MaxBodySize limit as a 413
+func DecodeJSONBody(r *http.Request, dst any) error {
+	defer r.Body.Close()
+
+	if err := json.NewDecoder(r.Body).Decode(dst); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return fmt.Errorf("request body larger than %d bytes: %w", maxBytesErr.Limit, err)
+		}
+		return errkind.NewField("body", fmt.Sprintf("invalid JSON payload: %v", err))
+	}
+	return nil
 }
@@ -0,0 +1,107 @@
+package stdlibapiadapter_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
+	"louder/internal/core/errkind"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestRespondWithError(t *testing.T) {
+	errFirst := errkind.NewField("first_name", "cannot be empty")
+	errDOB := errkind.NewField("dob", "cannot be in the future")
+
+	tests := map[string]struct {
+		err          error
+		wantStatus   int
+		wantFields   []stdlibapiadapter.FieldProblem
+		wantNoDetail string // internal details must never leak into the response
+	}{
+		"unclassified error is a 500": {
+			err:          errors.New("sqlite: disk I/O error"),
+			wantStatus:   http.StatusInternalServerError,
+			wantNoDetail: "sqlite: disk I/O error",
+		},
+		"wrapped not found": {
+			err:        fmt.Errorf("failed to get person: %w", errkind.New(errkind.NotFound, "no such person")),
+			wantStatus: http.StatusNotFound,
+		},
+		"conflict": {
+			err:        errkind.New(errkind.Conflict, "email already in use"),
+			wantStatus: http.StatusConflict,
+		},
+		"unavailable": {
+			err:          errkind.Wrap(errkind.Unavailable, errors.New("database is locked")),
+			wantStatus:   http.StatusServiceUnavailable,
+			wantNoDetail: "database is locked",
+		},
+		"unauthenticated": {
+			err:        errkind.New(errkind.Unauthenticated, "invalid API key"),
+			wantStatus: http.StatusUnauthorized,
+		},
+		"forbidden": {
+			err:        errkind.New(errkind.Forbidden, "admin role required"),
+			wantStatus: http.StatusForbidden,
+		},
+		"rate limited": {
+			err:        errkind.New(errkind.RateLimited, "too many requests"),
+			wantStatus: http.StatusTooManyRequests,
+		},
+		"deadline exceeded": {
+			err:        fmt.Errorf("query: %w", context.DeadlineExceeded),
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		"joined field errors keep their order": {
+			err:        fmt.Errorf("invalid data: %w", errors.Join(errFirst, fmt.Errorf("%w: got 2999-01-01", errDOB))),
+			wantStatus: http.StatusBadRequest,
+			wantFields: []stdlibapiadapter.FieldProblem{
+				{Field: "first_name", Message: "cannot be empty"},
+				{Field: "dob", Message: "cannot be in the future"},
+			},
+		},
+		"outer kind wins over inner kind": {
+			err:        errkind.Wrap(errkind.Conflict, errkind.New(errkind.NotFound, "no countries")),
+			wantStatus: http.StatusConflict,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/person/random", nil)
+
+			stdlibapiadapter.RespondWithError(rec, req, tc.err)
+
+			if rec.Code != tc.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tc.wantStatus)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/problem+json" {
+				t.Errorf("Content-Type = %q, want application/problem+json", ct)
+			}
+
+			var problem stdlibapiadapter.Problem
+			if err := json.NewDecoder(rec.Body).Decode(&problem); err != nil {
+				t.Fatalf("decoding problem: %v", err)
+			}
+
+			if problem.Status != tc.wantStatus || problem.Type != "about:blank" || problem.Title != http.StatusText(tc.wantStatus) {
+				t.Errorf("problem = %+v, want status %d with default type and title", problem, tc.wantStatus)
+			}
+			if problem.Instance != "/person/random" {
+				t.Errorf("instance = %q, want /person/random", problem.Instance)
+			}
+			if tc.wantNoDetail != "" && problem.Detail == tc.wantNoDetail {
+				t.Errorf("detail leaks the internal error: %q", problem.Detail)
+			}
+			if !reflect.DeepEqual(problem.Errors, tc.wantFields) {
+				t.Errorf("field errors = %+v, want %+v", problem.Errors, tc.wantFields)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package stdlibapiadapter
+
+import (
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+)
+
+// encoder is a compressing writer that can be reused through a pool
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// supported encodings, earlier ones win when the client rates several the same
+var encodings = []string{"br", "gzip"}
+
+var encoderPools = map[string]*sync.Pool{
+	"br": {New: func() any { return brotli.NewWriterLevel(nil, brotli.DefaultCompression) }},
+	"gzip": {New: func() any {
+		gz, _ := gzip.NewWriterLevel(nil, gzip.DefaultCompression) // only errors on an invalid level
+		return gz
+	}},
+}
+
+// Compress encodes textual responses with brotli or gzip, whichever the client prefers through Accept-Encoding. Responses that are already encoded, empty or binary are sent as they are.
+func Compress() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+
+			encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+			if encoding == "" || r.Method == http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			cw := &compressWriter{ResponseWriter: w, encoding: encoding}
+			defer cw.close()
+
+			next.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// negotiateEncoding picks the supported encoding with the highest q value, empty if the client accepts none of them
+func negotiateEncoding(acceptEncoding string) string {
+	best, bestQ := "", 0.0
+
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		q := 1.0
+		if qParam, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(qParam, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+
+		for _, enc := range encodings {
+			if name != enc && name != "*" {
+				continue
+			}
+			// ties go to whichever comes first in encodings
+			if q > bestQ || (q == bestQ && preference(enc) < preference(best)) {
+				best, bestQ = enc, q
+			}
+			break
+		}
+	}
+
+	return best
+}
+
+func preference(encoding string) int {
+	for i, enc := range encodings {
+		if enc == encoding {
+			return i
+		}
+	}
+	return len(encodings)
+}
+
+// compressible is true for text like content where compression pays off
+func compressible(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	switch {
+	case strings.HasPrefix(mediaType, "text/"),
+		strings.HasSuffix(mediaType, "+json"),
+		strings.HasSuffix(mediaType, "+xml"):
+		return true
+	}
+
+	switch mediaType {
+	case "application/json", "application/javascript", "application/xml", "image/svg+xml":
+		return true
+	}
+	return false
+}
+
+// compressWriter only decides whether to compress once the status and headers are known, i.e. on the first WriteHeader or Write
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+	encoder  encoder
+	decided  bool
+}
+
+func (cw *compressWriter) WriteHeader(code int) {
+	if !cw.decided {
+		cw.decide(code, nil)
+	}
+	cw.ResponseWriter.WriteHeader(code)
+}
+
+func (cw *compressWriter) Write(b []byte) (int, error) {
+	if !cw.decided {
+		cw.decide(http.StatusOK, b)
+	}
+	if cw.encoder != nil {
+		return cw.encoder.Write(b)
+	}
+	return cw.ResponseWriter.Write(b)
+}
+
+func (cw *compressWriter) decide(status int, firstChunk []byte) {
+	cw.decided = true
+	header := cw.Header()
+
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified {
+		return
+	}
+	if header.Get("Content-Encoding") != "" {
+		return
+	}
+
+	// same sniffing net/http would do, it has to happen before the body is encoded
+	if header.Get("Content-Type") == "" && firstChunk != nil {
+		header.Set("Content-Type", http.DetectContentType(firstChunk))
+	}
+	if !compressible(header.Get("Content-Type")) {
+		return
+	}
+
+	header.Del("Content-Length") // no longer true once compressed
+	header.Set("Content-Encoding", cw.encoding)
+
+	enc := encoderPools[cw.encoding].Get().(encoder)
+	enc.Reset(cw.ResponseWriter)
+	cw.encoder = enc
+}
+
+// Flush pushes whatever is buffered in the encoder before flushing the connection
+func (cw *compressWriter) Flush() {
+	if cw.encoder != nil {
+		cw.encoder.Flush()
+	}
+	http.NewResponseController(cw.ResponseWriter).Flush()
+}
+
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+func (cw *compressWriter) close() {
+	if cw.encoder == nil {
+		return
+	}
+	cw.encoder.Close()
+	encoderPools[cw.encoding].Put(cw.encoder)
+	cw.encoder = nil
+}
@@ -0,0 +1,19 @@
+package countryadapter
+
+import "louder/internal/adapters/driving/api_provider/stdlib/currencyadapter"
+
+// CountryResponse defines the JSON payload for a single country
+type CountryResponse struct {
+	Code       string                             `json:"code"`
+	Name       string                             `json:"name"`
+	Region     string                             `json:"region,omitempty"`
+	WikiDataID string                             `json:"wikidataid,omitempty"`
+	Currencies []currencyadapter.CurrencyResponse `json:"currencies"`
+}
+
+// CountriesSampleResponse defines the JSON payload for a random sample of countries. The seed is a string so JS clients don't lose precision.
+type CountriesSampleResponse struct {
+	Seed       uint64            `json:"seed,string"`
+	Population int               `json:"population"`
+	Countries  []CountryResponse `json:"countries"`
+}
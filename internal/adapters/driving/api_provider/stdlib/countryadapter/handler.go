@@ -0,0 +1,77 @@
+package countryadapter
+
+import (
+	"errors"
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
+	"louder/internal/core/domain"
+	"louder/internal/core/errkind"
+	"louder/internal/core/service/countrycore"
+	"louder/pkg/logging"
+	"net/http"
+	"strings"
+)
+
+// CountryHandler handles HTTP requests related to countries
+type CountryHandler struct {
+	service countrycore.CountryService
+}
+
+// NewCountryHandler creates a new CountryHandler
+func NewCountryHandler(srv countrycore.CountryService) *CountryHandler {
+	return &CountryHandler{
+		service: srv,
+	}
+}
+
+// HandleGetCountry handles get requests to /country/{code}
+func (h *CountryHandler) HandleGetCountry(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	code, err := stdlibapiadapter.PathCountryCode(r, "code")
+	if err != nil {
+		stdlibapiadapter.RespondWithError(w, r, err)
+		return
+	}
+
+	country, err := h.service.GetCountry(ctx, code)
+	if err != nil {
+		logging.FromContext(ctx).Warn("HandleGetCountry: service.GetCountry failed", "code", code, "err", err)
+		stdlibapiadapter.RespondWithError(w, r, err)
+		return
+	}
+
+	stdlibapiadapter.RespondWithJSON(w, http.StatusOK, toCountryResponse(*country))
+}
+
+// HandleSampleCountries handles get requests to /country/random, drawing distinct random countries
+func (h *CountryHandler) HandleSampleCountries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	params := r.URL.Query()
+
+	sampleReq, validationErrors := stdlibapiadapter.ParseSampleRequest(params)
+
+	filter := domain.CountryFilter{
+		Region: strings.TrimSpace(params.Get("region")),
+	}
+	if currencyParam := strings.TrimSpace(params.Get("currency")); currencyParam != "" {
+		cc, err := domain.NewCurrencyCode(currencyParam)
+		if err != nil {
+			validationErrors = append(validationErrors, errkind.NewField("currency", "must be a 3 letter currency code"))
+		}
+		filter.CurrencyCode = cc
+	}
+
+	if len(validationErrors) > 0 {
+		stdlibapiadapter.RespondWithError(w, r, errors.Join(validationErrors...))
+		return
+	}
+
+	sample, err := h.service.SampleCountries(ctx, sampleReq, filter)
+	if err != nil {
+		logging.FromContext(ctx).Error("HandleSampleCountries: service.SampleCountries failed", "err", err)
+		stdlibapiadapter.RespondWithError(w, r, err)
+		return
+	}
+
+	stdlibapiadapter.RespondWithJSON(w, http.StatusOK, toCountriesSampleResponse(sample))
+}
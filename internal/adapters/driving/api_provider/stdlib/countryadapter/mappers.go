@@ -0,0 +1,34 @@
+package countryadapter
+
+import (
+	"louder/internal/adapters/driving/api_provider/stdlib/currencyadapter"
+	"louder/internal/core/domain"
+)
+
+func toCountryResponse(c domain.Country) CountryResponse {
+	currencies := make([]currencyadapter.CurrencyResponse, 0, len(c.Currencies()))
+	for _, cur := range c.Currencies() {
+		currencies = append(currencies, currencyadapter.ToCurrencyResponse(cur))
+	}
+
+	return CountryResponse{
+		Code:       c.Code().String(),
+		Name:       c.Name(),
+		Region:     c.Region(),
+		WikiDataID: string(c.WikiId()),
+		Currencies: currencies,
+	}
+}
+
+func toCountriesSampleResponse(s *domain.Sample[*domain.Country]) *CountriesSampleResponse {
+	countries := make([]CountryResponse, 0, len(s.Items()))
+	for _, c := range s.Items() {
+		countries = append(countries, toCountryResponse(*c))
+	}
+
+	return &CountriesSampleResponse{
+		Seed:       s.Seed(),
+		Population: s.Population(),
+		Countries:  countries,
+	}
+}
@@ -0,0 +1,41 @@
+package countryadapter
+
+import (
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
+	"louder/internal/core/domain"
+	"net/http"
+)
+
+const (
+	SampleCountryRoute = "/country/random"
+	GetCountryRoute    = "/country/{code}"
+)
+
+func (h *CountryHandler) RegisterRoutes(mux stdlibapiadapter.Routes) {
+	mux.Handle(http.MethodGet+" "+GetCountryRoute, stdlibapiadapter.Authorize(domain.RoleReader, h.HandleGetCountry))
+	mux.Handle(http.MethodGet+" "+SampleCountryRoute, stdlibapiadapter.Authorize(domain.RoleReader, h.HandleSampleCountries))
+}
+
+func (h *CountryHandler) Operations() []stdlibapiadapter.Operation {
+	tags := []string{"country"}
+
+	return []stdlibapiadapter.Operation{
+		{
+			Pattern:    http.MethodGet + " " + GetCountryRoute,
+			Summary:    "Get a country by its ISO code",
+			Tags:       tags,
+			PathParams: []stdlibapiadapter.Param{{Name: "code", Description: "2 letter ISO 3166-1 code, case insensitive"}},
+			Responses:  map[int]any{http.StatusOK: CountryResponse{}},
+		},
+		{
+			Pattern: http.MethodGet + " " + SampleCountryRoute,
+			Summary: "Draw random countries",
+			Tags:    tags,
+			QueryParams: stdlibapiadapter.SampleQueryParams(
+				stdlibapiadapter.Param{Name: "region", Description: "only countries in this region, e.g. Europe"},
+				stdlibapiadapter.Param{Name: "currency", Description: "only countries using this 3 letter currency"},
+			),
+			Responses: map[int]any{http.StatusOK: CountriesSampleResponse{}},
+		},
+	}
+}
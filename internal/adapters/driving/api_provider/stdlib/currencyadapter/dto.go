@@ -0,0 +1,14 @@
+package currencyadapter
+
+// CurrencyResponse defines the JSON payload for a single currency
+type CurrencyResponse struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// CurrenciesSampleResponse defines the JSON payload for a random sample of currencies. The seed is a string so JS clients don't lose precision.
+type CurrenciesSampleResponse struct {
+	Seed       uint64             `json:"seed,string"`
+	Population int                `json:"population"`
+	Currencies []CurrencyResponse `json:"currencies"`
+}
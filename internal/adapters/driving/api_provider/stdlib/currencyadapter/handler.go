@@ -0,0 +1,55 @@
+package currencyadapter
+
+import (
+	"errors"
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
+	"louder/internal/core/domain"
+	"louder/internal/core/errkind"
+	"louder/internal/core/service/currencycore"
+	"louder/pkg/logging"
+	"net/http"
+	"strings"
+)
+
+// CurrencyHandler handles HTTP requests related to currencies
+type CurrencyHandler struct {
+	service currencycore.CurrencyService
+}
+
+// NewCurrencyHandler creates a new CurrencyHandler
+func NewCurrencyHandler(srv currencycore.CurrencyService) *CurrencyHandler {
+	return &CurrencyHandler{
+		service: srv,
+	}
+}
+
+// HandleSampleCurrencies handles get requests to /currency/random, drawing distinct random currencies
+func (h *CurrencyHandler) HandleSampleCurrencies(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	params := r.URL.Query()
+
+	sampleReq, validationErrors := stdlibapiadapter.ParseSampleRequest(params)
+
+	var filter domain.CurrencyFilter
+	if countryParam := strings.TrimSpace(params.Get("country")); countryParam != "" {
+		cc, err := domain.NewCountryCode(countryParam)
+		if err != nil {
+			validationErrors = append(validationErrors, errkind.NewField("country", "must be a 2 letter country code"))
+		}
+		filter.CountryCode = cc
+	}
+
+	if len(validationErrors) > 0 {
+		stdlibapiadapter.RespondWithError(w, r, errors.Join(validationErrors...))
+		return
+	}
+
+	sample, err := h.service.SampleCurrencies(ctx, sampleReq, filter)
+	if err != nil {
+		logging.FromContext(ctx).Error("HandleSampleCurrencies: service.SampleCurrencies failed", "err", err)
+		stdlibapiadapter.RespondWithError(w, r, err)
+		return
+	}
+
+	stdlibapiadapter.RespondWithJSON(w, http.StatusOK, toCurrenciesSampleResponse(sample))
+}
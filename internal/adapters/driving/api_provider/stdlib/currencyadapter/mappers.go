@@ -0,0 +1,24 @@
+package currencyadapter
+
+import "louder/internal/core/domain"
+
+// ToCurrencyResponse converts a domain.Currency to its response DTO. Exported so other resources embedding currencies can reuse it.
+func ToCurrencyResponse(c domain.Currency) CurrencyResponse {
+	return CurrencyResponse{
+		Code: c.Code().String(),
+		Name: c.Name(),
+	}
+}
+
+func toCurrenciesSampleResponse(s *domain.Sample[*domain.Currency]) *CurrenciesSampleResponse {
+	currencies := make([]CurrencyResponse, 0, len(s.Items()))
+	for _, c := range s.Items() {
+		currencies = append(currencies, ToCurrencyResponse(*c))
+	}
+
+	return &CurrenciesSampleResponse{
+		Seed:       s.Seed(),
+		Population: s.Population(),
+		Currencies: currencies,
+	}
+}
@@ -0,0 +1,29 @@
+package currencyadapter
+
+import (
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
+	"louder/internal/core/domain"
+	"net/http"
+)
+
+const (
+	SampleCurrencyRoute = "/currency/random"
+)
+
+func (h *CurrencyHandler) RegisterRoutes(mux stdlibapiadapter.Routes) {
+	mux.Handle(http.MethodGet+" "+SampleCurrencyRoute, stdlibapiadapter.Authorize(domain.RoleReader, h.HandleSampleCurrencies))
+}
+
+func (h *CurrencyHandler) Operations() []stdlibapiadapter.Operation {
+	return []stdlibapiadapter.Operation{
+		{
+			Pattern: http.MethodGet + " " + SampleCurrencyRoute,
+			Summary: "Draw random currencies",
+			Tags:    []string{"currency"},
+			QueryParams: stdlibapiadapter.SampleQueryParams(
+				stdlibapiadapter.Param{Name: "country", Description: "only currencies used in this 2 letter country"},
+			),
+			Responses: map[int]any{http.StatusOK: CurrenciesSampleResponse{}},
+		},
+	}
+}
@@ -1,5 +1,18 @@
 package stdlibapiadapter
 
-type ErrorResponse struct {
-	ErrorMsgs []string `json:"errors"`
+// Problem is an RFC 7807 problem details body, every error response uses it
+type Problem struct {
+	Type      string         `json:"type"`
+	Title     string         `json:"title"`
+	Status    int            `json:"status"`
+	Detail    string         `json:"detail,omitempty"`
+	Instance  string         `json:"instance,omitempty"`
+	RequestID string         `json:"request_id,omitempty"` // extension member, matches the X-Request-ID header and the logs
+	Errors    []FieldProblem `json:"errors,omitempty"`     // extension member, one entry per invalid input field
+}
+
+// FieldProblem reports why a single input field (body field, query or path parameter) was rejected
+type FieldProblem struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
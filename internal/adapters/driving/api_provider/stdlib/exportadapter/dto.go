@@ -0,0 +1,8 @@
+package exportadapter
+
+// PersonExportResponse defines the JSON payload of a person's export, each table is a list of rows keyed by column
+type PersonExportResponse struct {
+	PersonID    string                      `json:"person_id"`
+	GeneratedAt string                      `json:"generated_at"`
+	Tables      map[string][]map[string]any `json:"tables"`
+}
@@ -0,0 +1,84 @@
+package exportadapter
+
+import (
+	"bytes"
+	"fmt"
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
+	"louder/internal/core/domain"
+	"louder/internal/core/errkind"
+	"louder/internal/core/service/exportcore"
+	"louder/pkg/logging"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	formatJSON = "json"
+	formatZIP  = "zip"
+)
+
+var errFormat = errkind.NewField("format", "must be json or zip")
+
+// ExportHandler handles HTTP requests for personal data exports
+type ExportHandler struct {
+	service exportcore.ExportService
+}
+
+// NewExportHandler creates a new ExportHandler
+func NewExportHandler(srv exportcore.ExportService) *ExportHandler {
+	return &ExportHandler{
+		service: srv,
+	}
+}
+
+// HandleExportPerson handles GET requests to /person/{id}/export, as JSON or as a ZIP of one CSV per table
+func (h *ExportHandler) HandleExportPerson(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.FromContext(ctx)
+
+	personUUID, err := stdlibapiadapter.PathUUIDv7(r, "id")
+	if err != nil {
+		logger.Warn("HandleExportPerson: invalid id", "id", r.PathValue("id"), "err", err)
+		stdlibapiadapter.RespondWithError(w, r, err)
+		return
+	}
+	personID := domain.PersonID(personUUID)
+
+	format := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("format")))
+	switch format {
+	case "":
+		format = formatJSON
+	case formatJSON, formatZIP:
+	default:
+		stdlibapiadapter.RespondWithError(w, r, errFormat)
+		return
+	}
+
+	export, err := h.service.ExportPerson(ctx, personID)
+	if err != nil {
+		logger.Warn("HandleExportPerson: service.ExportPerson failed", "person_id", personID, "err", err)
+		stdlibapiadapter.RespondWithError(w, r, err)
+		return
+	}
+
+	if format == formatJSON {
+		stdlibapiadapter.RespondWithJSON(w, http.StatusOK, toPersonExportResponse(export))
+		return
+	}
+
+	// built in memory first, a failure half way through can still be answered with a proper error
+	var buf bytes.Buffer
+	if err := writeExportZip(&buf, export); err != nil {
+		logger.Error("HandleExportPerson: writing ZIP", "person_id", personID, "err", err)
+		stdlibapiadapter.RespondWithError(w, r, fmt.Errorf("writing export: %w", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="person-%s.zip"`, personID.String()))
+	w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+	if _, err := buf.WriteTo(w); err != nil {
+		logger.Warn("HandleExportPerson: sending ZIP", "person_id", personID, "err", err)
+	}
+}
@@ -0,0 +1,175 @@
+package exportadapter_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"louder/internal/adapters/driving/api_provider/stdlib/exportadapter"
+	"louder/internal/core/domain"
+	"louder/internal/core/service/authcore"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+type fakeExportService struct{ export *domain.PersonExport }
+
+func (f fakeExportService) ExportPerson(_ context.Context, pid domain.PersonID) (*domain.PersonExport, error) {
+	export := *f.export
+	export.PersonID = pid
+	return &export, nil
+}
+
+func TestHandleExportPerson(t *testing.T) {
+	pid := domain.PersonID(uuid.Must(uuid.NewV7()))
+	service := fakeExportService{export: &domain.PersonExport{
+		GeneratedAt: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+		Tables: []domain.ExportTable{
+			{Name: "person", Columns: []string{"first_name", "deleted_at"}, Rows: [][]any{{"Jane, \"JD\"", nil}}},
+			{Name: "audit_log", Columns: []string{"action", "changes"}, Rows: [][]any{{"create", map[string]domain.FieldChange{"email": {After: "jane@example.com"}}}}},
+			{Name: "countries", Columns: []string{"relation", "code"}},
+		},
+	}}
+
+	mux := http.NewServeMux()
+	exportadapter.NewExportHandler(service).RegisterRoutes(mux)
+
+	get := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/person/"+pid.String()+"/export"+query, nil)
+		req = req.WithContext(authcore.WithPrincipal(req.Context(), domain.AnonymousPrincipal()))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("json", func(t *testing.T) {
+		rec := get("")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want 200 (body %s)", rec.Code, rec.Body.String())
+		}
+
+		var got exportadapter.PersonExportResponse
+		if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		if got.PersonID != pid.String() || got.GeneratedAt != "2026-10-19T12:00:00Z" {
+			t.Errorf("person %s generated at %s", got.PersonID, got.GeneratedAt)
+		}
+		if person := got.Tables["person"]; len(person) != 1 || person[0]["first_name"] != "Jane, \"JD\"" || person[0]["deleted_at"] != nil {
+			t.Errorf("person table = %v", person)
+		}
+		if countries, ok := got.Tables["countries"]; !ok || len(countries) != 0 {
+			t.Errorf("an empty table should be an empty list, got %v (present %v)", countries, ok)
+		}
+	})
+
+	t.Run("zip", func(t *testing.T) {
+		rec := get("?format=zip")
+		if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/zip" {
+			t.Fatalf("status = %d, content type %q", rec.Code, rec.Header().Get("Content-Type"))
+		}
+
+		zr, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		files := make(map[string][][]string)
+		for _, f := range zr.File {
+			rc, err := f.Open()
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, _ := io.ReadAll(rc)
+			rc.Close()
+			if files[f.Name], err = csv.NewReader(bytes.NewReader(b)).ReadAll(); err != nil {
+				t.Fatalf("%s is not valid CSV: %v", f.Name, err)
+			}
+		}
+
+		want := map[string][][]string{
+			"person.csv":    {{"first_name", "deleted_at"}, {"Jane, \"JD\"", ""}},
+			"audit_log.csv": {{"action", "changes"}, {"create", `{"email":{"before":null,"after":"jane@example.com"}}`}},
+			"countries.csv": {{"relation", "code"}},
+		}
+		if !reflect.DeepEqual(files, want) {
+			t.Errorf("ZIP holds %v, want %v", files, want)
+		}
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		if rec := get("?format=xml"); rec.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want 400", rec.Code)
+		}
+	})
+}
+
+func TestHandleExportPersonEscapesFormulas(t *testing.T) {
+	pid := domain.PersonID(uuid.Must(uuid.NewV7()))
+	names := []string{`=HYPERLINK("https://evil.example","x")`, "+1", "-2+3", "@SUM(A1)", "\tTab", "\rReturn", "Jane", "", "O'Neil = ok"}
+	rows := make([][]any, 0, len(names))
+	for _, name := range names {
+		rows = append(rows, []any{name, 42})
+	}
+	service := fakeExportService{export: &domain.PersonExport{
+		GeneratedAt: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+		Tables:      []domain.ExportTable{{Name: "person", Columns: []string{"first_name", "n"}, Rows: rows}},
+	}}
+
+	mux := http.NewServeMux()
+	exportadapter.NewExportHandler(service).RegisterRoutes(mux)
+
+	get := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/person/"+pid.String()+"/export"+query, nil)
+		req = req.WithContext(authcore.WithPrincipal(req.Context(), domain.AnonymousPrincipal()))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get("?format=zip")
+	zr, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rc, err := zr.Open("person.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(rc).ReadAll()
+	rc.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := [][]string{
+		{"first_name", "n"},
+		{`'=HYPERLINK("https://evil.example","x")`, "42"},
+		{"'+1", "42"},
+		{"'-2+3", "42"},
+		{"'@SUM(A1)", "42"},
+		{"'\tTab", "42"},
+		{"'\rReturn", "42"},
+		{"Jane", "42"},
+		{"", "42"},
+		{"O'Neil = ok", "42"},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("person.csv = %q, want %q", records, want)
+	}
+
+	// the JSON export is data, not a spreadsheet, it keeps the values as they are
+	var got exportadapter.PersonExportResponse
+	if err := json.NewDecoder(get("").Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if first := got.Tables["person"][0]["first_name"]; first != names[0] {
+		t.Errorf("JSON first_name = %q, want %q", first, names[0])
+	}
+}
@@ -0,0 +1,87 @@
+package exportadapter
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"louder/internal/core/domain"
+	"strings"
+	"time"
+)
+
+// toPersonExportResponse converts a domain.PersonExport to its JSON DTO
+func toPersonExportResponse(e *domain.PersonExport) *PersonExportResponse {
+	tables := make(map[string][]map[string]any, len(e.Tables))
+	for _, table := range e.Tables {
+		rows := make([]map[string]any, 0, len(table.Rows))
+		for _, row := range table.Rows {
+			record := make(map[string]any, len(table.Columns))
+			for i, column := range table.Columns {
+				record[column] = row[i]
+			}
+			rows = append(rows, record)
+		}
+		tables[table.Name] = rows
+	}
+
+	return &PersonExportResponse{
+		PersonID:    e.PersonID.String(),
+		GeneratedAt: e.GeneratedAt.UTC().Format(time.RFC3339),
+		Tables:      tables,
+	}
+}
+
+// writeExportZip writes one CSV per table to w, header row first. Empty tables still get their header so the bundle always has the same files.
+func writeExportZip(w io.Writer, e *domain.PersonExport) error {
+	zw := zip.NewWriter(w)
+
+	for _, table := range e.Tables {
+		f, err := zw.CreateHeader(&zip.FileHeader{Name: table.Name + ".csv", Method: zip.Deflate, Modified: e.GeneratedAt})
+		if err != nil {
+			return err
+		}
+
+		cw := csv.NewWriter(f)
+		if err := cw.Write(table.Columns); err != nil {
+			return err
+		}
+		for _, row := range table.Rows {
+			record := make([]string, len(row))
+			for i, value := range row {
+				if record[i], err = csvValue(value); err != nil {
+					return fmt.Errorf("table %s, column %s: %w", table.Name, table.Columns[i], err)
+				}
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// the first characters that make a spreadsheet read a cell as a formula
+const formulaPrefixes = "=+-@\t\r"
+
+// csvValue writes strings as they are, nil as an empty cell and anything else as JSON. Strings a spreadsheet would run as a formula get a leading quote (OWASP's CSV injection advice), the data subject opens these files.
+func csvValue(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		if v != "" && strings.ContainsRune(formulaPrefixes, rune(v[0])) {
+			return "'" + v, nil
+		}
+		return v, nil
+	}
+
+	b, err := json.Marshal(value)
+	return string(b), err
+}
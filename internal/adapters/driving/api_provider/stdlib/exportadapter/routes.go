@@ -0,0 +1,28 @@
+package exportadapter
+
+import (
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
+	"louder/internal/core/domain"
+	"net/http"
+)
+
+const ExportPersonRoute = "/person/{id}/export"
+
+func (h *ExportHandler) RegisterRoutes(mux stdlibapiadapter.Routes) {
+	// readers may only export themselves, the service checks ownership
+	mux.Handle(http.MethodGet+" "+ExportPersonRoute, stdlibapiadapter.Authorize(domain.RoleReader, h.HandleExportPerson))
+}
+
+func (h *ExportHandler) Operations() []stdlibapiadapter.Operation {
+	return []stdlibapiadapter.Operation{
+		{
+			Pattern:     http.MethodGet + " " + ExportPersonRoute,
+			Summary:     "Export everything stored about a person",
+			Description: "For GDPR subject access requests: the profile, countries and audit trail of the person, deleted or not. format=zip returns a ZIP with one CSV per table instead of JSON. Readers may only export themselves.",
+			Tags:        []string{"person"},
+			PathParams:  []stdlibapiadapter.Param{{Name: "id", Format: "uuid", Description: "version 7 UUID of the person"}},
+			QueryParams: []stdlibapiadapter.Param{{Name: "format", Description: "json (default) or zip"}},
+			Responses:   map[int]any{http.StatusOK: PersonExportResponse{}},
+		},
+	}
+}
@@ -0,0 +1,111 @@
+package stdlibapiadapter
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Group mounts resources under a common path prefix such as /api/v1. A Group is itself a Resource, so groups nest and several versions can be registered side by side on the same router.
+type Group struct {
+	prefix      string
+	resources   []Resource
+	middlewares []Middleware
+	deprecated  bool
+}
+
+// NewGroup returns a group serving resources under prefix, "" mounts them at the root
+func NewGroup(prefix string, resources ...Resource) *Group {
+	return &Group{
+		prefix:    "/" + strings.Trim(prefix, "/"),
+		resources: resources,
+	}
+}
+
+// Use adds middlewares that only apply to the group's routes, in Chain order
+func (g *Group) Use(middlewares ...Middleware) *Group {
+	g.middlewares = append(g.middlewares, middlewares...)
+	return g
+}
+
+// Deprecate marks every route of the group as deprecated, both in the responses' headers and in the OpenAPI document
+func (g *Group) Deprecate(d Deprecation) *Group {
+	g.deprecated = true
+	return g.Use(Deprecated(d))
+}
+
+func (g *Group) RegisterRoutes(mux Routes) {
+	var routes Routes = &prefixedRoutes{parent: mux, prefix: g.routePrefix()}
+	if len(g.middlewares) > 0 {
+		routes = &wrappedRoutes{parent: routes, middleware: Chain(g.middlewares...)}
+	}
+
+	for _, resource := range g.resources {
+		resource.RegisterRoutes(routes)
+	}
+}
+
+// routePrefix is the prefix without its trailing slash, so the root group adds nothing
+func (g *Group) routePrefix() string {
+	return strings.TrimSuffix(g.prefix, "/")
+}
+
+// prefixedRoutes inserts prefix in front of the path of every pattern, keeping the method if there is one
+type prefixedRoutes struct {
+	parent Routes
+	prefix string
+}
+
+func (pr *prefixedRoutes) Handle(pattern string, handler http.Handler) {
+	pr.parent.Handle(prefixPattern(pr.prefix, pattern), handler)
+}
+
+func (pr *prefixedRoutes) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	pr.Handle(pattern, http.HandlerFunc(handler))
+}
+
+// prefixPattern turns ("/api/v1", "GET /person/{id}") into "GET /api/v1/person/{id}"
+func prefixPattern(prefix, pattern string) string {
+	method, path, found := strings.Cut(pattern, " ")
+	if !found {
+		return prefix + pattern
+	}
+	return method + " " + prefix + strings.TrimLeft(path, " ")
+}
+
+// Deprecation describes when routes were deprecated, when they'll be removed and where clients should go instead
+type Deprecation struct {
+	Since     time.Time // when the routes were deprecated
+	Sunset    time.Time // when they stop working, zero if not decided yet
+	Successor string    // base path of the replacement, e.g. /api/v2
+}
+
+// Deprecated marks every response with the Deprecation (RFC 9745) and Sunset (RFC 8594) headers, plus a successor-version Link when there is one
+func Deprecated(d Deprecation) Middleware {
+	deprecation := "@" + strconv.FormatInt(d.Since.Unix(), 10)
+
+	var sunset string
+	if !d.Sunset.IsZero() {
+		sunset = d.Sunset.UTC().Format(http.TimeFormat)
+	}
+
+	var link string
+	if d.Successor != "" {
+		link = "<" + d.Successor + `>; rel="successor-version"`
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := w.Header()
+			header.Set("Deprecation", deprecation)
+			if sunset != "" {
+				header.Set("Sunset", sunset)
+			}
+			if link != "" {
+				header.Add("Link", link)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
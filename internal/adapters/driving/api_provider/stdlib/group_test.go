@@ -0,0 +1,62 @@
+package stdlibapiadapter_test
+
+import (
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGroup(t *testing.T) {
+	sunset := time.Date(2027, time.April, 30, 0, 0, 0, 0, time.UTC)
+
+	router := stdlibapiadapter.NewRouter(
+		stdlibapiadapter.NewGroup("/api/v1", testResource{pattern: "GET /person/{id}"}).Use(stdlibapiadapter.Deprecated(stdlibapiadapter.Deprecation{
+			Since:     time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC),
+			Sunset:    sunset,
+			Successor: "/api/v2",
+		})),
+		stdlibapiadapter.NewGroup("/api/v2", testResource{pattern: "GET /person/{id}"}),
+		stdlibapiadapter.NewGroup("/api", stdlibapiadapter.NewGroup("v3/", testResource{pattern: "/person"})),
+	)
+
+	tests := map[string]struct {
+		path           string
+		wantStatus     int
+		wantDeprecated bool
+	}{
+		"old version still served": {path: "/api/v1/person/42", wantStatus: http.StatusOK, wantDeprecated: true},
+		"new version alongside":    {path: "/api/v2/person/42", wantStatus: http.StatusOK},
+		"nested groups":            {path: "/api/v3/person", wantStatus: http.StatusOK},
+		"unprefixed route gone":    {path: "/person/42", wantStatus: http.StatusNotFound},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.path, nil))
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tc.wantStatus)
+			}
+
+			header := rec.Header()
+			if !tc.wantDeprecated {
+				if header.Get("Deprecation") != "" || header.Get("Sunset") != "" {
+					t.Errorf("unexpected deprecation headers: %v", header)
+				}
+				return
+			}
+			if got, want := header.Get("Deprecation"), "@1792281600"; got != want {
+				t.Errorf("Deprecation = %q, want %q", got, want)
+			}
+			if got, want := header.Get("Sunset"), sunset.Format(http.TimeFormat); got != want {
+				t.Errorf("Sunset = %q, want %q", got, want)
+			}
+			if got, want := header.Get("Link"), `</api/v2>; rel="successor-version"`; got != want {
+				t.Errorf("Link = %q, want %q", got, want)
+			}
+		})
+	}
+}
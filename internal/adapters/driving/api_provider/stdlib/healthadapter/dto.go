@@ -0,0 +1,32 @@
+package healthadapter
+
+// LivenessResponse defines the JSON payload of /healthz
+type LivenessResponse struct {
+	Status string `json:"status"`
+}
+
+// ReadinessResponse defines the JSON payload of /readyz
+type ReadinessResponse struct {
+	Status string          `json:"status"` // "ready", "not ready" or "draining"
+	Checks []CheckResponse `json:"checks,omitempty"`
+}
+
+// CheckResponse is the outcome of one readiness check
+type CheckResponse struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"` // "ok" or "failing"
+	Critical   bool   `json:"critical"`
+	Error      string `json:"error,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+	CheckedAt  string `json:"checked_at"`
+}
+
+// VersionResponse defines the JSON payload of /version
+type VersionResponse struct {
+	Module     string `json:"module,omitempty"`
+	Version    string `json:"version"`
+	GoVersion  string `json:"go_version,omitempty"`
+	Revision   string `json:"revision,omitempty"`
+	CommitTime string `json:"commit_time,omitempty"`
+	Modified   bool   `json:"modified"`
+}
@@ -0,0 +1,50 @@
+package healthadapter
+
+import (
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
+	"louder/internal/core/service/healthcore"
+	"louder/pkg/buildinfo"
+	"louder/pkg/logging"
+	"net/http"
+)
+
+// HealthHandler answers the orchestrator's probes
+type HealthHandler struct {
+	service healthcore.HealthService
+}
+
+// NewHealthHandler creates a new HealthHandler
+func NewHealthHandler(srv healthcore.HealthService) *HealthHandler {
+	return &HealthHandler{
+		service: srv,
+	}
+}
+
+// HandleLiveness handles get requests to /healthz, answering at all is the proof the process is alive
+func (h *HealthHandler) HandleLiveness(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+	stdlibapiadapter.RespondWithJSON(w, http.StatusOK, LivenessResponse{Status: "ok"})
+}
+
+// HandleReadiness handles get requests to /readyz, a 503 takes the instance out of the load balancer
+func (h *HealthHandler) HandleReadiness(w http.ResponseWriter, r *http.Request) {
+	report := h.service.Ready(r.Context())
+
+	status := http.StatusOK
+	if !report.Ready {
+		status = http.StatusServiceUnavailable
+		for _, c := range report.Checks {
+			if c.Err != nil {
+				logging.FromContext(r.Context()).Warn("HandleReadiness: check failing", "check", c.Name, "critical", c.Critical, "err", c.Err)
+			}
+		}
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	stdlibapiadapter.RespondWithJSON(w, status, toReadinessResponse(report))
+}
+
+// HandleVersion handles get requests to /version
+func (h *HealthHandler) HandleVersion(w http.ResponseWriter, r *http.Request) {
+	stdlibapiadapter.RespondWithJSON(w, http.StatusOK, toVersionResponse(buildinfo.Read()))
+}
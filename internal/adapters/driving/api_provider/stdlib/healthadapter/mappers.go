@@ -0,0 +1,49 @@
+package healthadapter
+
+import (
+	"louder/internal/core/service/healthcore"
+	"louder/pkg/buildinfo"
+	"time"
+)
+
+func toReadinessResponse(r healthcore.Report) *ReadinessResponse {
+	status := "ready"
+	switch {
+	case r.Draining:
+		status = "draining"
+	case !r.Ready:
+		status = "not ready"
+	}
+
+	checks := make([]CheckResponse, 0, len(r.Checks))
+	for _, c := range r.Checks {
+		check := CheckResponse{
+			Name:       c.Name,
+			Status:     "ok",
+			Critical:   c.Critical,
+			DurationMS: c.Duration.Milliseconds(),
+			CheckedAt:  c.CheckedAt.UTC().Format(time.RFC3339),
+		}
+		if c.Err != nil {
+			check.Status = "failing"
+			check.Error = c.Err.Error()
+		}
+		checks = append(checks, check)
+	}
+
+	return &ReadinessResponse{Status: status, Checks: checks}
+}
+
+func toVersionResponse(info buildinfo.Info) *VersionResponse {
+	resp := &VersionResponse{
+		Module:    info.Module,
+		Version:   info.Version,
+		GoVersion: info.GoVersion,
+		Revision:  info.Revision,
+		Modified:  info.Modified,
+	}
+	if !info.Time.IsZero() {
+		resp.CommitTime = info.Time.UTC().Format(time.RFC3339)
+	}
+	return resp
+}
@@ -0,0 +1,19 @@
+package healthadapter
+
+import (
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
+	"net/http"
+)
+
+const (
+	LivenessRoute  = "/healthz"
+	ReadinessRoute = "/readyz"
+	VersionRoute   = "/version"
+)
+
+// RegisterRoutes mounts the probes without any authorization, orchestrators call them anonymously
+func (h *HealthHandler) RegisterRoutes(mux stdlibapiadapter.Routes) {
+	mux.HandleFunc(http.MethodGet+" "+LivenessRoute, h.HandleLiveness)
+	mux.HandleFunc(http.MethodGet+" "+ReadinessRoute, h.HandleReadiness)
+	mux.HandleFunc(http.MethodGet+" "+VersionRoute, h.HandleVersion)
+}
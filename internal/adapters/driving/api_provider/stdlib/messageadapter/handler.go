@@ -2,9 +2,10 @@ package messageadapter
 
 import (
 	"encoding/json"
-	"log"
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
 	"louder/internal/core/domain"
 	"louder/internal/core/service/messagecore"
+	"louder/pkg/logging"
 
 	"net/http"
 )
@@ -19,11 +20,23 @@ type MessageHandler struct {
 	MessageService messagecore.MessageService // injected core service
 }
 
-func (h *MessageHandler) RegisterRoutes(mux *http.ServeMux) {
-	const (
-		NewMessageRoute = "/message"
-	)
-	mux.HandleFunc(http.MethodGet+" "+NewMessageRoute, h.HandleGetMessage)
+const (
+	NewMessageRoute = "/message"
+)
+
+func (h *MessageHandler) RegisterRoutes(mux stdlibapiadapter.Routes) {
+	mux.Handle(http.MethodGet+" "+NewMessageRoute, stdlibapiadapter.Authorize(domain.RoleReader, h.HandleGetMessage))
+}
+
+func (h *MessageHandler) Operations() []stdlibapiadapter.Operation {
+	return []stdlibapiadapter.Operation{
+		{
+			Pattern:   http.MethodGet + " " + NewMessageRoute,
+			Summary:   "Get a message",
+			Tags:      []string{"message"},
+			Responses: map[int]any{http.StatusOK: MessageResponse{}},
+		},
+	}
 }
 
 func NewMessageHandler(service messagecore.MessageService) *MessageHandler {
@@ -32,14 +45,14 @@ func NewMessageHandler(service messagecore.MessageService) *MessageHandler {
 
 // HandleGetMessage is an http.HandlerFunc for the /message route
 func (mh *MessageHandler) HandleGetMessage(w http.ResponseWriter, r *http.Request) {
-	log.Println("stdlib API adapter: Got GET request for /message")
+	logger := logging.FromContext(r.Context())
+	logger.Debug("stdlib API adapter: GET for /message")
 
 	msgData := mh.MessageService.GetMessage()
 	response := MessageResponse{Message: msgData}
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(response); err != nil {
-		log.Printf("Failed to encode message response %v", err)
-		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		logger.Error("failed to encode message response", "err", err)
 	}
 }
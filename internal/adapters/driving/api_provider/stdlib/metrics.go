@@ -0,0 +1,73 @@
+package stdlibapiadapter
+
+import (
+	"louder/pkg/metrics"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	httpRequests = metrics.Default.NewCounterVec("louder_http_requests_total",
+		"HTTP requests served, by method, route pattern and status code.", "method", "route", "status")
+	httpDuration = metrics.Default.NewHistogramVec("louder_http_request_duration_seconds",
+		"Time taken to serve HTTP requests, by method and route pattern.", metrics.DefBuckets, "method", "route")
+	httpInFlight = metrics.Default.NewGaugeVec("louder_http_requests_in_flight",
+		"HTTP requests being served right now.")
+)
+
+// unmatchedRoute labels requests matching no pattern, labelling them by path would let any client create series at will
+const unmatchedRoute = "unmatched"
+
+// PatternMatcher tells which route pattern a request matches, Router is one
+type PatternMatcher interface {
+	Pattern(r *http.Request) string
+}
+
+// Metrics counts requests and times them per route pattern, along with the number in flight. Put it outside Recover so panics count as the 500s they become.
+func Metrics(routes PatternMatcher) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			inFlight := httpInFlight.With()
+			inFlight.Inc()
+			defer inFlight.Dec()
+
+			// matched up front, the request may well be answered before reaching the router (rate limited, unauthenticated...)
+			method, route := metricsMethod(r.Method), metricsRoute(routes.Pattern(r))
+
+			start := time.Now()
+			sw := &statusWriter{ResponseWriter: w}
+
+			next.ServeHTTP(sw, r)
+
+			status := sw.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			httpRequests.With(method, route, strconv.Itoa(status)).Inc()
+			httpDuration.With(method, route).Observe(time.Since(start).Seconds())
+		})
+	}
+}
+
+// metricsRoute drops the method from pattern, it has a label (or attribute) of its own
+func metricsRoute(pattern string) string {
+	if pattern == "" {
+		return unmatchedRoute
+	}
+	if _, path, found := strings.Cut(pattern, " "); found {
+		return strings.TrimSpace(path)
+	}
+	return pattern
+}
+
+// metricsMethod folds non standard methods into one label value, for the same reason as unmatchedRoute
+func metricsMethod(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		return method
+	}
+	return "OTHER"
+}
@@ -0,0 +1,53 @@
+package stdlibapiadapter_test
+
+import (
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
+	"louder/pkg/metrics"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMetricsLabelsByRoutePattern(t *testing.T) {
+	router := stdlibapiadapter.NewRouter(testResource{pattern: "GET /metrics-test/{id}"})
+
+	// answers before the router does, the route must still be known
+	teapot := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Has("teapot") {
+				w.WriteHeader(http.StatusTeapot)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+	handler := stdlibapiadapter.Chain(stdlibapiadapter.Metrics(router), teapot)(router)
+
+	for _, req := range []struct{ method, path string }{
+		{http.MethodGet, "/metrics-test/1"},
+		{http.MethodGet, "/metrics-test/2"},
+		{http.MethodGet, "/metrics-test/3?teapot"},
+		{http.MethodGet, "/metrics-test-nope/1"},
+		{"BREW", "/metrics-test/1"},
+	} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(req.method, req.path, nil))
+	}
+
+	var sb strings.Builder
+	metrics.Default.WriteTo(&sb)
+	exposition := sb.String()
+
+	for _, want := range []string{
+		`louder_http_requests_total{method="GET",route="/metrics-test/{id}",status="200"} 2`,
+		`louder_http_requests_total{method="GET",route="/metrics-test/{id}",status="418"} 1`,
+		`louder_http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		`louder_http_requests_total{method="OTHER",route="unmatched",status="405"} 1`,
+		`louder_http_request_duration_seconds_count{method="GET",route="/metrics-test/{id}"} 3`,
+		`louder_http_requests_in_flight 0`,
+	} {
+		if !strings.Contains(exposition, want+"\n") {
+			t.Errorf("missing %s in:\n%s", want, exposition)
+		}
+	}
+}
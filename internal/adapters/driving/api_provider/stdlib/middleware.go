@@ -0,0 +1,263 @@
+package stdlibapiadapter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"louder/pkg/logging"
+	"net"
+	"net/http"
+	"net/netip"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// Middleware wraps a handler with behaviour shared by many routes
+type Middleware func(http.Handler) http.Handler
+
+// Chain composes middlewares into one. The first one listed is the outermost, so Chain(a, b)(h) is a(b(h)) and a request goes through a, then b, then h.
+func Chain(middlewares ...Middleware) Middleware {
+	return func(next http.Handler) http.Handler {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			next = middlewares[i](next)
+		}
+		return next
+	}
+}
+
+// statusWriter remembers the status and size of a response for the middlewares that need them
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (sw *statusWriter) WriteHeader(code int) {
+	if sw.status == 0 {
+		sw.status = code
+	}
+	sw.ResponseWriter.WriteHeader(code)
+}
+
+func (sw *statusWriter) Write(b []byte) (int, error) {
+	if sw.status == 0 {
+		sw.status = http.StatusOK
+	}
+	n, err := sw.ResponseWriter.Write(b)
+	sw.bytes += n
+	return n, err
+}
+
+// Unwrap lets http.ResponseController reach the real writer (Flush, deadlines...)
+func (sw *statusWriter) Unwrap() http.ResponseWriter {
+	return sw.ResponseWriter
+}
+
+// RequestIDHeader carries the correlation ID in both directions, clients and proxies may set it and every response echoes it
+const RequestIDHeader = "X-Request-ID"
+
+// anything longer is more likely abuse than a real correlation ID
+const maxRequestIDLength = 128
+
+// RequestID assigns every request an ID, reusing a well formed incoming X-Request-ID, echoes it in the response and stores it in the context together with a logger that tags every line with it
+func RequestID(logger *slog.Logger) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := r.Header.Get(RequestIDHeader)
+			if !validRequestID(requestID) {
+				requestID = newRequestID()
+			}
+			w.Header().Set(RequestIDHeader, requestID)
+
+			reqLogger := logger.With("request_id", requestID, "method", r.Method, "path", r.URL.Path)
+
+			ctx := logging.WithRequestID(r.Context(), requestID)
+			ctx = logging.WithLogger(ctx, reqLogger)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// validRequestID only accepts printable ASCII without spaces so an incoming ID can't mess with log lines
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID returns a time ordered UUID so IDs sort with the logs
+func newRequestID() string {
+	id, err := uuid.NewV7()
+	if err != nil {
+		// only fails if the system's random source does, a timestamp is still unique enough to correlate logs
+		return strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+	return id.String()
+}
+
+type clientIPKey struct{}
+
+// RealIP works out the client's address. X-Forwarded-For and X-Real-IP are only believed when the direct peer is one of the trusted proxies, otherwise anyone could pick their own IP. The result is available through ClientIP and tags the request logger.
+func RealIP(trustedProxies []netip.Prefix) Middleware {
+	trusted := func(addr netip.Addr) bool {
+		for _, prefix := range trustedProxies {
+			if prefix.Contains(addr) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			clientIP := remoteAddrIP(r.RemoteAddr)
+
+			if peer, err := netip.ParseAddr(clientIP); err == nil && trusted(peer) {
+				clientIP = forwardedIP(r.Header, trusted, clientIP)
+			}
+
+			ctx := context.WithValue(r.Context(), clientIPKey{}, clientIP)
+			ctx = logging.WithLogger(ctx, logging.FromContext(ctx).With("client_ip", clientIP))
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// ClientIP returns the address found by RealIP, or the direct peer's address when the middleware isn't in use
+func ClientIP(r *http.Request) string {
+	if ip, ok := r.Context().Value(clientIPKey{}).(string); ok {
+		return ip
+	}
+	return remoteAddrIP(r.RemoteAddr)
+}
+
+func remoteAddrIP(remoteAddr string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	return host
+}
+
+// forwardedIP walks X-Forwarded-For from the right (the entries our proxies added) and returns the first address not belonging to a trusted proxy, falling back to X-Real-IP then to the peer itself
+func forwardedIP(header http.Header, trusted func(netip.Addr) bool, peer string) string {
+	if xff := header.Values("X-Forwarded-For"); len(xff) > 0 {
+		hops := strings.Split(strings.Join(xff, ","), ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			addr, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+			if err != nil {
+				break // garbage from here on can't be trusted
+			}
+			if !trusted(addr) {
+				return addr.String()
+			}
+		}
+	}
+
+	if addr, err := netip.ParseAddr(strings.TrimSpace(header.Get("X-Real-IP"))); err == nil {
+		return addr.String()
+	}
+
+	return peer
+}
+
+// AccessLog writes one line per request once it's done, with the status, size and duration. 5xx are logged as errors and 4xx as warnings.
+func AccessLog() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			sw := &statusWriter{ResponseWriter: w}
+
+			next.ServeHTTP(sw, r)
+
+			status := sw.status
+			if status == 0 {
+				status = http.StatusOK // nothing written at all
+			}
+
+			level := slog.LevelInfo
+			switch {
+			case status >= 500:
+				level = slog.LevelError
+			case status >= 400:
+				level = slog.LevelWarn
+			}
+
+			logging.FromContext(r.Context()).Log(r.Context(), level, "request completed",
+				"status", status,
+				"bytes", sw.bytes,
+				"duration", time.Since(start),
+			)
+		})
+	}
+}
+
+// Recover turns a panic in a handler into a 500 problem response (if nothing was sent yet) and logs it with the stack trace. http.ErrAbortHandler is passed through as the server uses it to drop the connection.
+func Recover() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sw := &statusWriter{ResponseWriter: w}
+
+			defer func() {
+				p := recover()
+				if p == nil {
+					return
+				}
+				if err, ok := p.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+					panic(p)
+				}
+
+				logging.FromContext(r.Context()).Error("panic serving request", "panic", p, "stack", string(debug.Stack()))
+
+				if sw.status == 0 {
+					RespondWithError(sw, r, fmt.Errorf("panic: %v", p))
+				}
+			}()
+
+			next.ServeHTTP(sw, r)
+		})
+	}
+}
+
+// MaxBodySize caps request bodies at maxBytes. Oversized requests that announce their length are refused straight away, the others fail when the handler reads past the limit (see DecodeJSONBody).
+func MaxBodySize(maxBytes int64) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > maxBytes {
+				RespondWithError(w, r, &http.MaxBytesError{Limit: maxBytes})
+				return
+			}
+
+			r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Timeout gives every request a deadline. Handlers should stop when their context is done, if they don't the client still gets a 503 problem response on time.
+func Timeout(d time.Duration) Middleware {
+	body, _ := json.Marshal(Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(http.StatusServiceUnavailable),
+		Status: http.StatusServiceUnavailable,
+		Detail: "request timed out",
+	})
+
+	return func(next http.Handler) http.Handler {
+		return http.TimeoutHandler(next, d, string(body))
+	}
+}
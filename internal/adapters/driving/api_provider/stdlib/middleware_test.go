@@ -0,0 +1,353 @@
+package stdlibapiadapter_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"log/slog"
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
+	"louder/pkg/logging"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andybalholm/brotli"
+)
+
+func TestRequestID(t *testing.T) {
+	tests := map[string]struct {
+		incoming string
+		wantSame bool
+	}{
+		"no incoming ID gets a new one":  {incoming: "", wantSame: false},
+		"well formed ID is propagated":   {incoming: "abc-123.lb-7", wantSame: true},
+		"ID with spaces is replaced":     {incoming: "abc 123", wantSame: false},
+		"overly long ID is replaced":     {incoming: strings.Repeat("a", 200), wantSame: false},
+		"non ASCII ID is replaced":       {incoming: "ïd", wantSame: false},
+		"ID at the length limit is kept": {incoming: strings.Repeat("a", 128), wantSame: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var logs bytes.Buffer
+			logger := logging.New(&logs, logging.FormatJSON, slog.LevelInfo)
+
+			var ctxRequestID string
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ctxRequestID = logging.RequestID(r.Context())
+				logging.FromContext(r.Context()).Info("inside handler")
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/random", nil)
+			if tc.incoming != "" {
+				req.Header.Set(stdlibapiadapter.RequestIDHeader, tc.incoming)
+			}
+			rec := httptest.NewRecorder()
+
+			stdlibapiadapter.RequestID(logger)(next).ServeHTTP(rec, req)
+
+			got := rec.Header().Get(stdlibapiadapter.RequestIDHeader)
+			if got == "" {
+				t.Fatal("response has no request ID")
+			}
+			if (got == tc.incoming) != tc.wantSame {
+				t.Errorf("response request ID = %q, incoming %q, want same: %v", got, tc.incoming, tc.wantSame)
+			}
+			if ctxRequestID != got {
+				t.Errorf("context request ID = %q, want %q", ctxRequestID, got)
+			}
+			if !strings.Contains(logs.String(), `"request_id":"`+got+`"`) {
+				t.Errorf("handler log line is not tagged with the request ID: %s", logs.String())
+			}
+		})
+	}
+}
+
+func TestChainOrder(t *testing.T) {
+	var calls []string
+	tag := func(name string) stdlibapiadapter.Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, name+" in")
+				next.ServeHTTP(w, r)
+				calls = append(calls, name+" out")
+			})
+		}
+	}
+
+	handler := stdlibapiadapter.Chain(tag("a"), tag("b"), tag("c"))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "handler")
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	want := []string{"a in", "b in", "c in", "handler", "c out", "b out", "a out"}
+	if !slices.Equal(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+type testResource struct {
+	pattern string
+}
+
+func (tr testResource) RegisterRoutes(mux stdlibapiadapter.Routes) {
+	mux.HandleFunc(tr.pattern, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func TestWithMiddleware(t *testing.T) {
+	marker := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Marked", "yes")
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	router := stdlibapiadapter.NewRouter(
+		stdlibapiadapter.WithMiddleware(testResource{pattern: "GET /marked"}, marker),
+		testResource{pattern: "GET /plain"},
+	)
+
+	tests := map[string]struct {
+		path       string
+		wantMarked bool
+	}{
+		"wrapped resource gets the middleware": {path: "/marked", wantMarked: true},
+		"other resources are left alone":       {path: "/plain", wantMarked: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.path, nil))
+
+			if got := rec.Header().Get("X-Marked") == "yes"; got != tc.wantMarked {
+				t.Errorf("marked = %v, want %v", got, tc.wantMarked)
+			}
+		})
+	}
+}
+
+func TestRecover(t *testing.T) {
+	var logs bytes.Buffer
+	logger := logging.New(&logs, logging.FormatJSON, slog.LevelInfo)
+
+	handler := stdlibapiadapter.Chain(
+		stdlibapiadapter.RequestID(logger),
+		stdlibapiadapter.Recover(),
+	)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/random", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Errorf("content type = %q, want application/problem+json", ct)
+	}
+	if strings.Contains(rec.Body.String(), "boom") {
+		t.Errorf("panic value leaked to the client: %s", rec.Body.String())
+	}
+	if !strings.Contains(logs.String(), "boom") {
+		t.Errorf("panic was not logged: %s", logs.String())
+	}
+}
+
+func TestCORS(t *testing.T) {
+	cfg := stdlibapiadapter.CORSConfig{
+		AllowedOrigins: []string{"https://app.example.com"},
+		AllowedMethods: []string{http.MethodGet, http.MethodPost},
+		AllowedHeaders: []string{"Content-Type"},
+		MaxAge:         time.Minute,
+	}
+
+	tests := map[string]struct {
+		method        string
+		origin        string
+		preflight     bool
+		wantStatus    int
+		wantAllowed   bool
+		wantReachNext bool
+	}{
+		"preflight from allowed origin":   {method: http.MethodOptions, origin: "https://app.example.com", preflight: true, wantStatus: http.StatusNoContent, wantAllowed: true},
+		"preflight from unknown origin":   {method: http.MethodOptions, origin: "https://evil.example.com", preflight: true, wantStatus: http.StatusOK, wantReachNext: true},
+		"simple request from allowed one": {method: http.MethodGet, origin: "https://app.example.com", wantStatus: http.StatusOK, wantAllowed: true, wantReachNext: true},
+		"same origin request":             {method: http.MethodGet, wantStatus: http.StatusOK, wantReachNext: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			reached := false
+			handler := stdlibapiadapter.CORS(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				reached = true
+			}))
+
+			req := httptest.NewRequest(tc.method, "/person", nil)
+			if tc.origin != "" {
+				req.Header.Set("Origin", tc.origin)
+			}
+			if tc.preflight {
+				req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tc.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tc.wantStatus)
+			}
+			if reached != tc.wantReachNext {
+				t.Errorf("reached next handler = %v, want %v", reached, tc.wantReachNext)
+			}
+			if got := rec.Header().Get("Access-Control-Allow-Origin") == tc.origin && tc.origin != ""; got != tc.wantAllowed {
+				t.Errorf("origin allowed = %v, want %v", got, tc.wantAllowed)
+			}
+			if tc.preflight && tc.wantAllowed && rec.Header().Get("Access-Control-Max-Age") != "60" {
+				t.Errorf("max age = %q, want 60", rec.Header().Get("Access-Control-Max-Age"))
+			}
+		})
+	}
+}
+
+func TestCompress(t *testing.T) {
+	body := strings.Repeat(`{"first_name":"Ada","last_name":"Lovelace"}`, 50)
+
+	tests := map[string]struct {
+		acceptEncoding string
+		contentType    string
+		wantEncoding   string
+	}{
+		"gzip requested":                 {acceptEncoding: "gzip", contentType: "application/json", wantEncoding: "gzip"},
+		"brotli preferred on a tie":      {acceptEncoding: "gzip, br", contentType: "application/json", wantEncoding: "br"},
+		"higher q value wins":            {acceptEncoding: "br;q=0.5, gzip;q=0.9", contentType: "application/json", wantEncoding: "gzip"},
+		"q=0 refuses an encoding":        {acceptEncoding: "br;q=0, gzip", contentType: "application/json", wantEncoding: "gzip"},
+		"nothing supported":              {acceptEncoding: "deflate", contentType: "application/json", wantEncoding: ""},
+		"binary content left untouched":  {acceptEncoding: "gzip", contentType: "image/png", wantEncoding: ""},
+		"problem responses compress too": {acceptEncoding: "gzip", contentType: "application/problem+json", wantEncoding: "gzip"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			handler := stdlibapiadapter.Compress()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tc.contentType)
+				io.WriteString(w, body)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/person/random", nil)
+			req.Header.Set("Accept-Encoding", tc.acceptEncoding)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if got := rec.Header().Get("Content-Encoding"); got != tc.wantEncoding {
+				t.Fatalf("content encoding = %q, want %q", got, tc.wantEncoding)
+			}
+			if !slices.Contains(rec.Header().Values("Vary"), "Accept-Encoding") {
+				t.Errorf("Vary = %v, want Accept-Encoding in it", rec.Header().Values("Vary"))
+			}
+
+			var reader io.Reader = rec.Body
+			switch tc.wantEncoding {
+			case "gzip":
+				gz, err := gzip.NewReader(rec.Body)
+				if err != nil {
+					t.Fatalf("invalid gzip body: %v", err)
+				}
+				reader = gz
+			case "br":
+				reader = brotli.NewReader(rec.Body)
+			}
+
+			got, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("reading body: %v", err)
+			}
+			if string(got) != body {
+				t.Errorf("decoded body does not match what the handler wrote")
+			}
+		})
+	}
+}
+
+func TestMaxBodySize(t *testing.T) {
+	tests := map[string]struct {
+		body       string
+		hideLength bool
+		wantStatus int
+	}{
+		"body under the limit":            {body: `{"first_name":"Ada"}`, wantStatus: http.StatusOK},
+		"announced oversized body":        {body: `{"first_name":"` + strings.Repeat("a", 100) + `"}`, wantStatus: http.StatusRequestEntityTooLarge},
+		"oversized body without a length": {body: `{"first_name":"` + strings.Repeat("a", 100) + `"}`, hideLength: true, wantStatus: http.StatusRequestEntityTooLarge},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			handler := stdlibapiadapter.MaxBodySize(64)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var payload map[string]any
+				if err := stdlibapiadapter.DecodeJSONBody(r, &payload); err != nil {
+					stdlibapiadapter.RespondWithError(w, r, err)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/person", strings.NewReader(tc.body))
+			if tc.hideLength {
+				req.ContentLength = -1
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tc.wantStatus {
+				t.Errorf("status = %d, want %d (%s)", rec.Code, tc.wantStatus, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestRealIP(t *testing.T) {
+	trusted := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+
+	tests := map[string]struct {
+		remoteAddr   string
+		forwardedFor string
+		realIP       string
+		want         string
+	}{
+		"direct client":                        {remoteAddr: "203.0.113.7:5000", want: "203.0.113.7"},
+		"untrusted peer can't spoof":           {remoteAddr: "203.0.113.7:5000", forwardedFor: "1.2.3.4", want: "203.0.113.7"},
+		"trusted proxy forwards the client":    {remoteAddr: "10.0.0.2:5000", forwardedFor: "198.51.100.9", want: "198.51.100.9"},
+		"chain of trusted proxies is skipped":  {remoteAddr: "10.0.0.2:5000", forwardedFor: "1.2.3.4, 198.51.100.9, 10.0.0.5", want: "198.51.100.9"},
+		"X-Real-IP used without forwarded for": {remoteAddr: "10.0.0.2:5000", realIP: "198.51.100.9", want: "198.51.100.9"},
+		"trusted proxy without headers":        {remoteAddr: "10.0.0.2:5000", want: "10.0.0.2"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got string
+			handler := stdlibapiadapter.RealIP(trusted)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = stdlibapiadapter.ClientIP(r)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/random", nil)
+			req.RemoteAddr = tc.remoteAddr
+			if tc.forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", tc.forwardedFor)
+			}
+			if tc.realIP != "" {
+				req.Header.Set("X-Real-IP", tc.realIP)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != tc.want {
+				t.Errorf("client IP = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
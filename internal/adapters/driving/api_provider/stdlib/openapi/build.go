@@ -0,0 +1,190 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	jsonContentType    = "application/json"
+	problemContentType = "application/problem+json"
+	problemSchemaName  = "Problem"
+)
+
+// wildcardPattern matches the ServeMux wildcards: {name}, {name...} and {$}
+var wildcardPattern = regexp.MustCompile(`\{([^}.]*)(\.\.\.)?\}`)
+
+// Build registers the resources on a recorder to find every route they serve and documents each one with the Operation the resource declares for it. It fails if a route has no Operation or an Operation matches no route, so the document can't silently drift from the router.
+func Build(info Info, resources ...stdlibapiadapter.Resource) (*Document, error) {
+	recorder := &routeRecorder{}
+	var ops []stdlibapiadapter.Operation
+	for _, resource := range resources {
+		resource.RegisterRoutes(recorder)
+		if documented, ok := resource.(stdlibapiadapter.Documented); ok {
+			ops = append(ops, documented.Operations()...)
+		}
+	}
+
+	var errs []error
+
+	documented := make(map[string]stdlibapiadapter.Operation, len(ops))
+	for _, op := range ops {
+		documented[normalisePattern(op.Pattern)] = op
+	}
+	for _, pattern := range recorder.patterns {
+		if _, ok := documented[pattern]; !ok {
+			errs = append(errs, fmt.Errorf("route %q has no OpenAPI operation", pattern))
+		}
+	}
+	for pattern := range documented {
+		if !slices.Contains(recorder.patterns, pattern) {
+			errs = append(errs, fmt.Errorf("OpenAPI operation %q matches no route", pattern))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]PathItem),
+	}
+	schemas := newSchemas()
+	problemRef := schemas.of(stdlibapiadapter.Problem{})
+
+	for _, pattern := range recorder.patterns {
+		method, path, found := strings.Cut(pattern, " ")
+		if !found {
+			errs = append(errs, fmt.Errorf("route %q has no method, it can't be documented", pattern))
+			continue
+		}
+
+		openAPIPath := wildcardPattern.ReplaceAllStringFunc(path, func(wildcard string) string {
+			if wildcard == "{$}" {
+				return ""
+			}
+			return "{" + wildcardPattern.FindStringSubmatch(wildcard)[1] + "}"
+		})
+
+		if doc.Paths[openAPIPath] == nil {
+			doc.Paths[openAPIPath] = make(PathItem)
+		}
+		doc.Paths[openAPIPath][strings.ToLower(method)] = buildOperation(documented[pattern], method, path, openAPIPath, schemas, problemRef)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	doc.Components.Schemas = schemas.components
+	return doc, nil
+}
+
+func buildOperation(op stdlibapiadapter.Operation, method, path, openAPIPath string, schemas *schemas, problemRef *Schema) *OperationObject {
+	operation := &OperationObject{
+		OperationID: operationID(method, openAPIPath),
+		Summary:     op.Summary,
+		Description: op.Description,
+		Tags:        op.Tags,
+		Deprecated:  op.Deprecated,
+		Responses:   make(map[string]ResponseObject),
+	}
+
+	// every wildcard is a required path parameter, documented or not
+	for _, match := range wildcardPattern.FindAllStringSubmatch(path, -1) {
+		name := match[1]
+		if name == "$" {
+			continue
+		}
+
+		param := stdlibapiadapter.Param{Name: name}
+		if i := slices.IndexFunc(op.PathParams, func(p stdlibapiadapter.Param) bool { return p.Name == name }); i >= 0 {
+			param = op.PathParams[i]
+		}
+		param.Required = true
+		operation.Parameters = append(operation.Parameters, parameter(param, "path"))
+	}
+	for _, param := range op.QueryParams {
+		operation.Parameters = append(operation.Parameters, parameter(param, "query"))
+	}
+
+	if op.RequestBody != nil {
+		operation.RequestBody = &RequestBodyObject{
+			Required: true,
+			Content:  map[string]MediaType{jsonContentType: {Schema: schemas.of(op.RequestBody)}},
+		}
+	}
+
+	for status, body := range op.Responses {
+		response := ResponseObject{Description: http.StatusText(status)}
+		if body != nil {
+			response.Content = map[string]MediaType{jsonContentType: {Schema: schemas.of(body)}}
+		}
+		operation.Responses[strconv.Itoa(status)] = response
+	}
+
+	// every error goes through RespondWithError
+	operation.Responses["default"] = ResponseObject{
+		Description: "Error, described as an RFC 7807 problem",
+		Content:     map[string]MediaType{problemContentType: {Schema: problemRef}},
+	}
+
+	return operation
+}
+
+func parameter(param stdlibapiadapter.Param, in string) ParameterObject {
+	schema := &Schema{Type: param.Type, Format: param.Format}
+	if schema.Type == "" {
+		schema.Type = "string"
+	}
+	if param.Repeated {
+		schema = &Schema{Type: "array", Items: schema}
+	}
+
+	return ParameterObject{
+		Name:        param.Name,
+		In:          in,
+		Description: param.Description,
+		Required:    param.Required,
+		Schema:      schema,
+	}
+}
+
+// operationID derives a stable camel case ID from the method and path, e.g. getApiV1PersonId for GET /api/v1/person/{id}
+func operationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+
+	words := strings.FieldsFunc(path, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+// normalisePattern collapses the spaces between method and path so equivalent patterns compare equal
+func normalisePattern(pattern string) string {
+	return strings.Join(strings.Fields(pattern), " ")
+}
+
+// routeRecorder is a stdlibapiadapter.Routes that only remembers the patterns it's given
+type routeRecorder struct {
+	patterns []string
+}
+
+func (rr *routeRecorder) Handle(pattern string, _ http.Handler) {
+	rr.patterns = append(rr.patterns, normalisePattern(pattern))
+}
+
+func (rr *routeRecorder) HandleFunc(pattern string, _ func(http.ResponseWriter, *http.Request)) {
+	rr.patterns = append(rr.patterns, normalisePattern(pattern))
+}
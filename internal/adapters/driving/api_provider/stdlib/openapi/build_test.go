@@ -0,0 +1,121 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
+	"louder/internal/adapters/driving/api_provider/stdlib/openapi"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type widgetResponse struct {
+	ID     string            `json:"id"`
+	Seed   uint64            `json:"seed,string"`
+	Tags   []string          `json:"tags,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+type widgetResource struct {
+	routes []string
+	ops    []stdlibapiadapter.Operation
+}
+
+func (wr widgetResource) RegisterRoutes(mux stdlibapiadapter.Routes) {
+	for _, pattern := range wr.routes {
+		mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {})
+	}
+}
+
+func (wr widgetResource) Operations() []stdlibapiadapter.Operation {
+	return wr.ops
+}
+
+func TestBuild(t *testing.T) {
+	getWidget := stdlibapiadapter.Operation{
+		Pattern:   "GET /widget/{id}",
+		Summary:   "Get a widget",
+		Responses: map[int]any{http.StatusOK: widgetResponse{}},
+	}
+
+	tests := map[string]struct {
+		resource stdlibapiadapter.Resource
+		wantErr  string
+	}{
+		"documented route": {
+			resource: widgetResource{routes: []string{"GET /widget/{id}"}, ops: []stdlibapiadapter.Operation{getWidget}},
+		},
+		"route without an operation": {
+			resource: widgetResource{routes: []string{"GET /widget/{id}", "DELETE /widget/{id}"}, ops: []stdlibapiadapter.Operation{getWidget}},
+			wantErr:  `route "DELETE /widget/{id}" has no OpenAPI operation`,
+		},
+		"operation without a route": {
+			resource: widgetResource{ops: []stdlibapiadapter.Operation{getWidget}},
+			wantErr:  `OpenAPI operation "GET /widget/{id}" matches no route`,
+		},
+		"resource that documents nothing": {
+			resource: stdlibapiadapter.NewGroup("/api"),
+		},
+		"grouped and deprecated route keeps its operation": {
+			resource: stdlibapiadapter.NewGroup("/api/v1", widgetResource{routes: []string{"GET /widget/{id}"}, ops: []stdlibapiadapter.Operation{getWidget}}).
+				Deprecate(stdlibapiadapter.Deprecation{}),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := openapi.Build(openapi.Info{Title: "test", Version: "0"}, tc.resource)
+
+			if tc.wantErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)) {
+				t.Fatalf("error = %v, want it to contain %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildDocument(t *testing.T) {
+	resource := stdlibapiadapter.NewGroup("/api/v1", widgetResource{
+		routes: []string{"GET /widget/{id}"},
+		ops: []stdlibapiadapter.Operation{{
+			Pattern:     "GET /widget/{id}",
+			Summary:     "Get a widget",
+			PathParams:  []stdlibapiadapter.Param{{Name: "id", Format: "uuid"}},
+			QueryParams: []stdlibapiadapter.Param{{Name: "weight", Repeated: true}},
+			Responses:   map[int]any{http.StatusOK: widgetResponse{}},
+		}},
+	}).Deprecate(stdlibapiadapter.Deprecation{})
+
+	doc, err := openapi.Build(openapi.Info{Title: "test", Version: "0"}, resource)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	op := doc.Paths["/api/v1/widget/{id}"]["get"]
+	if op == nil {
+		t.Fatalf("no operation for GET /api/v1/widget/{id}, paths: %v", doc.Paths)
+	}
+	if op.OperationID != "getApiV1WidgetId" {
+		t.Errorf("operationId = %q, want getApiV1WidgetId", op.OperationID)
+	}
+	if !op.Deprecated {
+		t.Error("operation in a deprecated group is not marked deprecated")
+	}
+	if len(op.Parameters) != 2 || op.Parameters[0].In != "path" || !op.Parameters[0].Required || op.Parameters[1].Schema.Type != "array" {
+		t.Errorf("unexpected parameters: %+v", op.Parameters)
+	}
+	if _, ok := op.Responses["default"].Content["application/problem+json"]; !ok {
+		t.Error("errors are not documented as problem+json")
+	}
+
+	got, err := json.Marshal(doc.Components.Schemas["widgetResponse"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"type":"object","properties":{"id":{"type":"string"},"labels":{"type":"object","additionalProperties":{"type":"string"}},"seed":{"type":"string"},"tags":{"type":"array","items":{"type":"string"}}},"required":["id","seed"]}`
+	if string(got) != want {
+		t.Errorf("widgetResponse schema =\n%s\nwant\n%s", got, want)
+	}
+}
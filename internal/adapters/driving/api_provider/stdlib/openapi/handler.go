@@ -0,0 +1,67 @@
+package openapi
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
+	"louder/pkg/logging"
+	"net/http"
+)
+
+// docsPage renders openapi.json with Swagger UI. Everything it loads is relative to the page so they can be mounted under any prefix.
+//
+//go:embed docs.html
+var docsPage []byte
+
+// docsAssets is the vendored Swagger UI, no third party code is fetched at runtime
+//
+//go:embed swagger-ui/swagger-ui-bundle.js swagger-ui/swagger-ui.css swagger-ui/init.js
+var docsAssets embed.FS
+
+// Handler serves a built document at /openapi.json and a browsable version of it at /docs
+type Handler struct {
+	spec []byte
+}
+
+// NewHandler encodes the document once, it never changes while the server runs
+func NewHandler(doc *Document) (*Handler, error) {
+	spec, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("encoding OpenAPI document: %w", err)
+	}
+	return &Handler{spec: spec}, nil
+}
+
+func (h *Handler) RegisterRoutes(mux stdlibapiadapter.Routes) {
+	const (
+		SpecRoute      = "/openapi.json"
+		DocsRoute      = "/docs"
+		DocsAssetRoute = "/docs/{asset}"
+	)
+	mux.HandleFunc(http.MethodGet+" "+SpecRoute, h.HandleGetSpec)
+	mux.HandleFunc(http.MethodGet+" "+DocsRoute, h.HandleGetDocs)
+	mux.HandleFunc(http.MethodGet+" "+DocsAssetRoute, h.HandleGetDocsAsset)
+}
+
+// HandleGetSpec handles get requests to /openapi.json
+func (h *Handler) HandleGetSpec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(h.spec); err != nil {
+		logging.FromContext(r.Context()).Warn("HandleGetSpec: writing response", "err", err)
+	}
+}
+
+// HandleGetDocs handles get requests to /docs
+func (h *Handler) HandleGetDocs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := w.Write(docsPage); err != nil {
+		logging.FromContext(r.Context()).Warn("HandleGetDocs: writing response", "err", err)
+	}
+}
+
+// HandleGetDocsAsset handles get requests to /docs/{asset}, the scripts and styles of the docs page
+func (h *Handler) HandleGetDocsAsset(w http.ResponseWriter, r *http.Request) {
+	// the path value is a single segment, it can't climb out of the directory
+	http.ServeFileFS(w, r, docsAssets, "swagger-ui/"+r.PathValue("asset"))
+}
@@ -0,0 +1,67 @@
+package openapi_test
+
+import (
+	"louder/internal/adapters/driving/api_provider/stdlib/openapi"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandlerServesDocs(t *testing.T) {
+	doc, err := openapi.Build(openapi.Info{Title: "test", Version: "0"})
+	if err != nil {
+		t.Fatalf("building document: %v", err)
+	}
+	handler, err := openapi.NewHandler(doc)
+	if err != nil {
+		t.Fatalf("creating handler: %v", err)
+	}
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+
+	tests := map[string]struct {
+		target      string
+		wantStatus  int
+		contentType string
+	}{
+		"docs page":          {target: "/docs", wantStatus: http.StatusOK, contentType: "text/html"},
+		"viewer script":      {target: "/docs/swagger-ui-bundle.js", wantStatus: http.StatusOK, contentType: "javascript"},
+		"viewer styles":      {target: "/docs/swagger-ui.css", wantStatus: http.StatusOK, contentType: "text/css"},
+		"viewer setup":       {target: "/docs/init.js", wantStatus: http.StatusOK, contentType: "javascript"},
+		"unknown asset":      {target: "/docs/redoc.standalone.js", wantStatus: http.StatusNotFound},
+		"license not served": {target: "/docs/LICENSE", wantStatus: http.StatusNotFound},
+		"spec":               {target: "/openapi.json", wantStatus: http.StatusOK, contentType: "application/json"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.target, nil))
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tc.wantStatus)
+			}
+			if got := rec.Header().Get("Content-Type"); !strings.Contains(got, tc.contentType) {
+				t.Errorf("Content-Type = %q, want it to contain %q", got, tc.contentType)
+			}
+		})
+	}
+}
+
+func TestDocsPageLoadsNothingRemote(t *testing.T) {
+	doc, err := openapi.Build(openapi.Info{Title: "test", Version: "0"})
+	if err != nil {
+		t.Fatalf("building document: %v", err)
+	}
+	handler, err := openapi.NewHandler(doc)
+	if err != nil {
+		t.Fatalf("creating handler: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	handler.HandleGetDocs(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+
+	if body := rec.Body.String(); strings.Contains(body, "http://") || strings.Contains(body, "https://") {
+		t.Errorf("docs page references a remote URL:\n%s", body)
+	}
+}
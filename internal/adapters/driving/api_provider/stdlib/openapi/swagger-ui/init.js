@@ -0,0 +1,6 @@
+// openapi.json and this script sit next to the docs page, so the page works under any prefix
+window.ui = SwaggerUIBundle({
+  url: new URL("openapi.json", document.baseURI).href,
+  dom_id: "#swagger-ui",
+  deepLinking: true,
+});
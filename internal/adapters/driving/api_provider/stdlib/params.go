@@ -0,0 +1,72 @@
+package stdlibapiadapter
+
+import (
+	"louder/internal/core/domain"
+	"louder/internal/core/errkind"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// The Path* helpers read a {name} wildcard of the route pattern and parse it. Every failure is an Invalid field error named after the wildcard, so handlers can hand it straight to RespondWithError.
+
+// PathUUIDv7 parses a version 7 UUID, the format of every ID this API hands out
+func PathUUIDv7(r *http.Request, name string) (uuid.UUID, error) {
+	raw, err := pathValue(r, name)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	id, err := uuid.FromString(raw)
+	if err != nil {
+		return uuid.Nil, errkind.NewField(name, "invalid UUID format")
+	}
+	if id.Version() != uuid.V7 {
+		return uuid.Nil, errkind.NewField(name, "must be a version 7 UUID")
+	}
+	return id, nil
+}
+
+// PathCountryCode parses a 2 letter ISO 3166-1 country code, case insensitive
+func PathCountryCode(r *http.Request, name string) (domain.CountryCode, error) {
+	raw, err := pathValue(r, name)
+	if err != nil {
+		return "", err
+	}
+
+	for _, c := range raw {
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') {
+			return "", errkind.NewField(name, "must be a 2 letter country code")
+		}
+	}
+
+	code, err := domain.NewCountryCode(raw)
+	if err != nil {
+		return "", errkind.NewField(name, "must be a 2 letter country code")
+	}
+	return code, nil
+}
+
+// PathInt parses a base 10 integer
+func PathInt(r *http.Request, name string) (int, error) {
+	raw, err := pathValue(r, name)
+	if err != nil {
+		return 0, err
+	}
+
+	val, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, errkind.NewField(name, "must be a valid integer")
+	}
+	return val, nil
+}
+
+func pathValue(r *http.Request, name string) (string, error) {
+	raw := strings.TrimSpace(r.PathValue(name))
+	if raw == "" {
+		return "", errkind.NewField(name, "cannot be blank")
+	}
+	return raw, nil
+}
@@ -0,0 +1,71 @@
+package stdlibapiadapter_test
+
+import (
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
+	"louder/internal/core/errkind"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPathParams(t *testing.T) {
+	parsers := map[string]func(r *http.Request) (any, error){
+		"uuid": func(r *http.Request) (any, error) {
+			id, err := stdlibapiadapter.PathUUIDv7(r, "id")
+			return id.String(), err
+		},
+		"country": func(r *http.Request) (any, error) {
+			code, err := stdlibapiadapter.PathCountryCode(r, "id")
+			return code.String(), err
+		},
+		"int": func(r *http.Request) (any, error) {
+			return stdlibapiadapter.PathInt(r, "id")
+		},
+	}
+
+	tests := map[string]struct {
+		parser  string
+		value   string
+		want    any
+		wantErr string
+	}{
+		"UUIDv7":                {parser: "uuid", value: "01a15164-d6e7-780f-8f1d-afe98c777f45", want: "01a15164-d6e7-780f-8f1d-afe98c777f45"},
+		"UUIDv4 rejected":       {parser: "uuid", value: "9b2f7c1e-3d4a-4b6f-8e2d-1a2b3c4d5e6f", wantErr: "Value error for 'id': must be a version 7 UUID"},
+		"not a UUID":            {parser: "uuid", value: "42", wantErr: "Value error for 'id': invalid UUID format"},
+		"blank value":           {parser: "uuid", value: " ", wantErr: "Value error for 'id': cannot be blank"},
+		"country code":          {parser: "country", value: "pt", want: "PT"},
+		"country code too long": {parser: "country", value: "PRT", wantErr: "Value error for 'id': must be a 2 letter country code"},
+		"country code digits":   {parser: "country", value: "P1", wantErr: "Value error for 'id': must be a 2 letter country code"},
+		"integer":               {parser: "int", value: "-12", want: -12},
+		"not an integer":        {parser: "int", value: "12a", wantErr: "Value error for 'id': must be a valid integer"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.SetPathValue("id", tc.value)
+
+			got, err := parsers[tc.parser](req)
+
+			if tc.wantErr != "" {
+				if err == nil {
+					t.Fatalf("expected error %q, got value %v", tc.wantErr, got)
+				}
+				if err.Error() != tc.wantErr {
+					t.Errorf("error = %q, want %q", err.Error(), tc.wantErr)
+				}
+				if errkind.Of(err) != errkind.Invalid {
+					t.Errorf("error kind = %v, want Invalid", errkind.Of(err))
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
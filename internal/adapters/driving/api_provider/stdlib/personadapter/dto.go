@@ -6,6 +6,16 @@ type CreatePersonRequest struct {
 	FirstName string `json:"first_name"`
 	LastName  string `json:"last_name"`
 	Email     string `json:"email"`
+	DOB       string `json:"dob,omitempty"`        // YYYY-MM-DD or RFC3339, required unless RandomDOB is set
+	RandomDOB bool   `json:"random_dob,omitempty"` // explicit opt-in to a made up DOB
+}
+
+// UpdatePersonRequest defines the expected JSON payload for updating a person, missing fields are left unchanged
+type UpdatePersonRequest struct {
+	FirstName *string `json:"first_name,omitempty"`
+	LastName  *string `json:"last_name,omitempty"`
+	Email     *string `json:"email,omitempty"`
+	DOB       *string `json:"dob,omitempty"` // YYYY-MM-DD or RFC3339
 }
 
 // PersonResponse defines the JSON payload for returning a person just created (inc UUID).
@@ -16,10 +26,27 @@ type PersonResponse struct {
 	LastName  string `json:"last_name"`
 	Email     string `json:"email"`
 	DOB       string `json:"dob"`
+	Age       int    `json:"age"`
+
+	BirthCountry     string `json:"birth_country,omitempty"`
+	ResidenceCountry string `json:"residence_country,omitempty"`
+
+	DeletedAt string `json:"deleted_at,omitempty"` // only set for soft deleted people
 
 	// TODO - implement later
 	// Pets             []domain.Pet `json:"pets,omitempty"`
-	// BirthCountry     domain.Country `json:"birth_country,omitempty"`
-	// ResidentCountry  domain.Country `json:"residence_country,omitempty"`
 	// VisitedCountries []domain.Country `json:"visited_countries,omitempty"`
 }
+
+// PeopleSampleResponse defines the JSON payload for a random sample of people. The seed is a string so JS clients don't lose precision.
+type PeopleSampleResponse struct {
+	Seed       uint64           `json:"seed,string"`
+	Population int              `json:"population"`
+	People     []PersonResponse `json:"people"`
+}
+
+// GeneratePeopleResponse defines the JSON payload returned after generating fake people
+type GeneratePeopleResponse struct {
+	Requested int `json:"requested"`
+	Created   int `json:"created"`
+}
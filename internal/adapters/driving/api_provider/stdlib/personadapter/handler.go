@@ -3,85 +3,120 @@ package personadapter
 import (
 	"encoding/json"
 	"errors"
-	"log"
-	dbcommon "louder/internal/adapters/driven/db/dbcommon"
+	"fmt"
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
 	"louder/internal/core/domain"
+	"louder/internal/core/errkind"
+	"louder/pkg/logging"
 	"net/http"
+	"strconv"
 	"strings"
-
-	"github.com/gofrs/uuid/v5"
 )
 
-// HandleGetPersonByID handles get requests to /person
+// HandleGetPersonByID handles GET requests to /person/{id}
 func (h *PersonHandler) HandleGetPersonByID(w http.ResponseWriter, r *http.Request) {
 	// do not forget to pass the context!
 	ctx := r.Context()
+	logger := logging.FromContext(ctx)
 
-	// check the method
-	if r.Method != http.MethodGet {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	personUUID, err := stdlibapiadapter.PathUUIDv7(r, "id")
+	if err != nil {
+		logger.Warn("HandleGetPersonByID: invalid id", "id", r.PathValue("id"), "err", err)
+		stdlibapiadapter.RespondWithError(w, r, err)
 		return
 	}
+	personID := domain.PersonID(personUUID)
 
-	// Extract the id from the path in the request
-	path := strings.Trim(r.URL.Path, "/")
-	parts := strings.Split(path, "/")
-
-	if len(parts) < 2 || parts[0] != "person" {
-		log.Printf("warning HandleGetPersonByID - Invalid path format: %s", r.URL.Path)
-		http.Error(w, "Bad Request: Invalid URL path. Expected /person/{id}", http.StatusBadRequest)
+	includeDeleted, err := parseIncludeDeleted(r.URL.Query())
+	if err != nil {
+		stdlibapiadapter.RespondWithError(w, r, err)
 		return
 	}
 
-	idStr := parts[1]
+	// get this personID from service layer
+	retrievedPerson, err := h.service.GetPersonByID(ctx, personID, includeDeleted)
+	if err != nil {
+		logger.Warn("HandleGetPersonByID: service.GetPersonByID failed", "person_id", personID, "err", err)
+		stdlibapiadapter.RespondWithError(w, r, err)
+		return
+	}
 
-	// validate the extracted string
+	// convert the domain.Person returned by the DB to a PersonResponseDTO
+	responseDTO := toPersonResponse(*retrievedPerson)
 
-	// check for empty string
-	if idStr == "" {
-		http.Error(w, "id cannot be blank", http.StatusBadRequest)
-		return
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(responseDTO); err != nil {
+		logger.Error("HandleGetPersonByID: encoding response", "err", err)
 	}
 
-	// convert the string we extracted into a uuid (also check for a valid uuid)
-	personUUID, err := uuid.FromString(idStr)
+	logger.Info("HandleGetPersonByID: retrieved person", "person_id", retrievedPerson.ID().String())
+}
+
+// HandleSamplePeople handles get requests to /person/random, drawing distinct random people
+func (h *PersonHandler) HandleSamplePeople(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	params := r.URL.Query()
+
+	sampleReq, validationErrors := stdlibapiadapter.ParseSampleRequest(params)
+
+	var filter domain.PersonFilter
+	if countryParam := strings.TrimSpace(params.Get("country")); countryParam != "" {
+		cc, err := domain.NewCountryCode(countryParam)
+		if err != nil {
+			validationErrors = append(validationErrors, errkind.NewField("country", "must be a 2 letter country code"))
+		}
+		filter.ResidenceCountryCode = cc
+	}
+	if birthCountryParam := strings.TrimSpace(params.Get("birth_country")); birthCountryParam != "" {
+		cc, err := domain.NewCountryCode(birthCountryParam)
+		if err != nil {
+			validationErrors = append(validationErrors, errkind.NewField("birth_country", "must be a 2 letter country code"))
+		}
+		filter.BirthCountryCode = cc
+	}
+	includeDeleted, err := parseIncludeDeleted(params)
 	if err != nil {
-		http.Error(w, "Invalid id format", http.StatusBadRequest)
-		log.Printf("warning HandleGetPersonByID - Invalid UUID format '%s': %v", idStr, err)
-		return
+		validationErrors = append(validationErrors, err)
 	}
+	filter.IncludeDeleted = includeDeleted
 
-	// check if uuid is V7
-	if personUUID.Version() != 7 {
-		http.Error(w, "Invalid id version", http.StatusBadRequest)
+	if len(validationErrors) > 0 {
+		stdlibapiadapter.RespondWithError(w, r, errors.Join(validationErrors...))
 		return
 	}
-	// log.Println("\n----->", personUUID.Version())
 
-	// convert the uuid into a domain.PersonID
-	personID := domain.PersonID(personUUID)
-
-	// get this personID from service layer
-	retrievedPerson, err := h.service.GetPersonByID(ctx, personID)
+	sample, err := h.service.SamplePeople(ctx, sampleReq, filter)
 	if err != nil {
-		log.Printf("error HandleGetPersonByID - service.GetPersonByID for ID %s: %v", idStr, err)
+		logging.FromContext(ctx).Error("HandleSamplePeople: service.SamplePeople failed", "err", err)
+		stdlibapiadapter.RespondWithError(w, r, err)
+		return
+	}
 
-		if errors.Is(err, dbcommon.ErrNotFound) { // use error from core/driven
-			http.Error(w, "Not found: person with the specified ID does not exist", http.StatusNotFound)
+	stdlibapiadapter.RespondWithJSON(w, http.StatusOK, toPeopleSampleResponse(sample))
+}
 
-		} else { // this for all other possible error that are not NotFound
-			http.Error(w, "Internal Server Error: Failed to retrieve person.", http.StatusInternalServerError)
-		}
+// HandleGeneratePeople handles post requests to /person/generate?count=N, creating N fake people for load testing
+func (h *PersonHandler) HandleGeneratePeople(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	countParam := strings.TrimSpace(r.URL.Query().Get("count"))
+	if countParam == "" {
+		stdlibapiadapter.RespondWithError(w, r, errkind.NewField("count", "missing required parameter"))
 		return
 	}
 
-	// convert the domain.Person returned by the DB to a PersonResponseDTO
-	responseDTO := toPersonResponse(*retrievedPerson)
+	count, err := strconv.Atoi(countParam)
+	if err != nil {
+		stdlibapiadapter.RespondWithError(w, r, errkind.NewField("count", "must be a valid integer"))
+		return
+	}
 
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(responseDTO); err != nil {
-		log.Printf("error HandleGetPersonByID - encoding response: %v", err)
+	created, err := h.service.GeneratePeople(ctx, count)
+	if err != nil {
+		logging.FromContext(ctx).Error("HandleGeneratePeople: service.GeneratePeople failed", "created", created, "requested", count, "err", err)
+		stdlibapiadapter.RespondWithError(w, r, fmt.Errorf("%d of %d people created: %w", created, count, err))
+		return
 	}
 
-	log.Printf("Info HandleGetPersonByID - Successfully retrieved person ID: %s", retrievedPerson.ID().String())
+	stdlibapiadapter.RespondWithJSON(w, http.StatusCreated, GeneratePeopleResponse{Requested: count, Created: created})
 }
@@ -0,0 +1,229 @@
+package personadapter_test
+
+import (
+	"context"
+	"encoding/json"
+	dbcommon "louder/internal/adapters/driven/db/dbcommon"
+	"louder/internal/adapters/driving/api_provider/stdlib/personadapter"
+	"louder/internal/core/domain"
+	"louder/internal/core/service/authcore"
+	"louder/internal/core/service/personcore"
+	"louder/pkg/types"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// memoryPersonRepo keeps people in a map, only what create and update need is implemented
+type memoryPersonRepo struct {
+	personcore.PersonRepository
+	people map[domain.PersonID]*domain.Person
+}
+
+func (m *memoryPersonRepo) GetByID(_ context.Context, pid domain.PersonID) (*domain.Person, error) {
+	if p, ok := m.people[pid]; ok {
+		return p, nil
+	}
+	return nil, dbcommon.ErrNotFound
+}
+
+func (m *memoryPersonRepo) GetByEmail(_ context.Context, email string) (*domain.Person, error) {
+	for _, p := range m.people {
+		if p.Email() == email {
+			return p, nil
+		}
+	}
+	return nil, dbcommon.ErrNotFound
+}
+
+func (m *memoryPersonRepo) Save(_ context.Context, person *domain.Person) (*domain.Person, error) {
+	m.people[person.ID()] = person
+	return person, nil
+}
+
+func newTestMux(t *testing.T) (*http.ServeMux, *memoryPersonRepo, domain.PersonID) {
+	t.Helper()
+	pid := domain.PersonID(uuid.Must(uuid.NewV7()))
+	dob := types.NewUTCTime(time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC))
+	repo := &memoryPersonRepo{people: map[domain.PersonID]*domain.Person{
+		pid: domain.HydratePerson(pid, "Jane", "Doe", "jane@example.com", dob, "", "", time.Time{}),
+	}}
+
+	service := personcore.NewPersonService(repo, nil, nil, nil, authcore.ContextCaller{})
+	mux := http.NewServeMux()
+	personadapter.NewPersonHandler(service).RegisterRoutes(mux)
+	return mux, repo, pid
+}
+
+func serve(mux *http.ServeMux, method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(authcore.WithPrincipal(req.Context(), domain.AnonymousPrincipal()))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandleUpdatePersonDOB(t *testing.T) {
+	tests := map[string]struct {
+		body       string
+		wantStatus int
+		wantDOB    string
+	}{
+		"plain date":               {body: `{"dob":"1985-01-02"}`, wantStatus: http.StatusOK, wantDOB: "1985-01-02T00:00:00Z"},
+		"offset is made UTC":       {body: `{"dob":"1985-01-02T00:30:00+01:00"}`, wantStatus: http.StatusOK, wantDOB: "1985-01-01T23:30:00Z"},
+		"no dob keeps it":          {body: `{"first_name":"Janet"}`, wantStatus: http.StatusOK, wantDOB: "1990-05-17T00:00:00Z"},
+		"unparseable":              {body: `{"dob":"17/05/1990"}`, wantStatus: http.StatusBadRequest},
+		"empty":                    {body: `{"dob":""}`, wantStatus: http.StatusBadRequest},
+		"in the future":            {body: `{"dob":"2999-01-01"}`, wantStatus: http.StatusBadRequest},
+		"implausibly old":          {body: `{"dob":"1850-01-01"}`, wantStatus: http.StatusBadRequest},
+		"random dob never applies": {body: `{"random_dob":true}`, wantStatus: http.StatusOK, wantDOB: "1990-05-17T00:00:00Z"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			mux, repo, pid := newTestMux(t)
+
+			rec := serve(mux, http.MethodPatch, "/person/"+pid.String(), tc.body)
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", rec.Code, tc.wantStatus, rec.Body.String())
+			}
+			if tc.wantStatus != http.StatusOK {
+				if !repo.people[pid].DOB().Equal(time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)) {
+					t.Errorf("a rejected update changed the dob to %v", repo.people[pid].DOB().Time)
+				}
+				return
+			}
+
+			var got personadapter.PersonResponse
+			if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			if got.DOB != tc.wantDOB {
+				t.Errorf("dob = %q, want %q", got.DOB, tc.wantDOB)
+			}
+		})
+	}
+}
+
+func TestHandleCreatePersonRandomDOB(t *testing.T) {
+	tests := map[string]struct {
+		body       string
+		wantStatus int
+		wantDOB    string
+	}{
+		"given dob":           {body: `{"first_name":"Ann","last_name":"Lee","email":"ann@example.com","dob":"1985-01-02"}`, wantStatus: http.StatusCreated, wantDOB: "1985-01-02T00:00:00Z"},
+		"random on request":   {body: `{"first_name":"Ann","last_name":"Lee","email":"ann@example.com","random_dob":true}`, wantStatus: http.StatusCreated},
+		"missing dob":         {body: `{"first_name":"Ann","last_name":"Lee","email":"ann@example.com"}`, wantStatus: http.StatusBadRequest},
+		"random not asked":    {body: `{"first_name":"Ann","last_name":"Lee","email":"ann@example.com","random_dob":false}`, wantStatus: http.StatusBadRequest},
+		"both dob and random": {body: `{"first_name":"Ann","last_name":"Lee","email":"ann@example.com","dob":"1985-01-02","random_dob":true}`, wantStatus: http.StatusBadRequest},
+		"bad dob":             {body: `{"first_name":"Ann","last_name":"Lee","email":"ann@example.com","dob":"yesterday"}`, wantStatus: http.StatusBadRequest},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			mux, repo, _ := newTestMux(t)
+
+			rec := serve(mux, http.MethodPost, "/person", tc.body)
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", rec.Code, tc.wantStatus, rec.Body.String())
+			}
+			if tc.wantStatus != http.StatusCreated {
+				if len(repo.people) != 1 {
+					t.Errorf("a rejected create saved someone, %d people", len(repo.people))
+				}
+				return
+			}
+
+			var got personadapter.PersonResponse
+			if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			dob, err := time.Parse(time.RFC3339Nano, got.DOB)
+			if err != nil {
+				t.Fatalf("dob %q: %v", got.DOB, err)
+			}
+			if tc.wantDOB != "" && got.DOB != tc.wantDOB {
+				t.Errorf("dob = %q, want %q", got.DOB, tc.wantDOB)
+			}
+			if _, err := domain.NewDOB(dob, time.Now()); err != nil {
+				t.Errorf("created with an invalid dob %q: %v", got.DOB, err)
+			}
+		})
+	}
+}
+
+func TestHandleReservedErasedEmail(t *testing.T) {
+	tests := map[string]struct {
+		method string
+		target func(pid domain.PersonID) string
+		body   string
+	}{
+		"create": {
+			method: http.MethodPost,
+			target: func(domain.PersonID) string { return "/person" },
+			body:   `{"first_name":"Ann","last_name":"Lee","email":"ann@erased.invalid","dob":"1985-01-02"}`,
+		},
+		"update": {
+			method: http.MethodPatch,
+			target: func(pid domain.PersonID) string { return "/person/" + pid.String() },
+			body:   `{"email":"erased-x@Erased.Invalid"}`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			mux, repo, pid := newTestMux(t)
+
+			rec := serve(mux, tc.method, tc.target(pid), tc.body)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want %d (body %s)", rec.Code, http.StatusBadRequest, rec.Body.String())
+			}
+			if len(repo.people) != 1 || repo.people[pid].Email() != "jane@example.com" || repo.people[pid].Erased() {
+				t.Error("a reserved email was saved")
+			}
+		})
+	}
+}
+
+func TestHandlePersonNameLength(t *testing.T) {
+	long := strings.Repeat("a", domain.MaxNameLength+1)
+
+	tests := map[string]struct {
+		method     string
+		update     bool
+		body       string
+		wantStatus int
+	}{
+		"create with a long first name": {method: http.MethodPost, body: `{"first_name":"` + long + `","last_name":"Lee","email":"ann@example.com","dob":"1985-01-02"}`, wantStatus: http.StatusBadRequest},
+		"create with a blank last name": {method: http.MethodPost, body: `{"first_name":"Ann","last_name":"  ","email":"ann@example.com","dob":"1985-01-02"}`, wantStatus: http.StatusBadRequest},
+		"update with a long last name":  {method: http.MethodPatch, update: true, body: `{"last_name":"` + long + `"}`, wantStatus: http.StatusBadRequest},
+		"update with the longest name":  {method: http.MethodPatch, update: true, body: `{"last_name":"` + long[1:] + `"}`, wantStatus: http.StatusOK},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			mux, repo, pid := newTestMux(t)
+			target := "/person"
+			if tc.update {
+				target += "/" + pid.String()
+			}
+
+			rec := serve(mux, tc.method, target, tc.body)
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", rec.Code, tc.wantStatus, rec.Body.String())
+			}
+			if tc.wantStatus != http.StatusOK && (len(repo.people) != 1 || repo.people[pid].LastName() != "Doe") {
+				t.Error("a rejected name was saved")
+			}
+		})
+	}
+}
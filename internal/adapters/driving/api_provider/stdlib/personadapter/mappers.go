@@ -7,11 +7,33 @@ import (
 
 // toPersonResponse converts a domain.Person (from the service layer) to a PersonResponse DTO.
 func toPersonResponse(p domain.Person) *PersonResponse {
-	return &PersonResponse{
+	resp := &PersonResponse{
 		ID:        p.ID().String(),
 		FirstName: p.FirstName(),
 		LastName:  p.LastName(),
 		Email:     p.Email(),
 		DOB:       p.DOB().UTC().Format(time.RFC3339Nano),
+		Age:       p.Age(time.Now()),
+
+		BirthCountry:     p.BirthCountry().String(),
+		ResidenceCountry: p.ResidenceCountry().String(),
+	}
+	if p.Deleted() {
+		resp.DeletedAt = p.DeletedAt().UTC().Format(time.RFC3339)
+	}
+	return resp
+}
+
+// toPeopleSampleResponse converts a sample of people to its response DTO
+func toPeopleSampleResponse(s *domain.Sample[*domain.Person]) *PeopleSampleResponse {
+	people := make([]PersonResponse, 0, len(s.Items()))
+	for _, p := range s.Items() {
+		people = append(people, *toPersonResponse(*p))
+	}
+
+	return &PeopleSampleResponse{
+		Seed:       s.Seed(),
+		Population: s.Population(),
+		People:     people,
 	}
 }
@@ -0,0 +1,96 @@
+package personadapter
+
+import (
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
+	"louder/internal/core/domain"
+	"louder/internal/core/errkind"
+	"louder/pkg/logging"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+var errIncludeDeletedFormat = errkind.NewField("include_deleted", "must be true or false")
+
+// HandleDeletePerson handles DELETE requests to /person/{id}, soft deleting the person
+func (h *PersonHandler) HandleDeletePerson(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.FromContext(ctx)
+
+	personUUID, err := stdlibapiadapter.PathUUIDv7(r, "id")
+	if err != nil {
+		logger.Warn("HandleDeletePerson: invalid id", "id", r.PathValue("id"), "err", err)
+		stdlibapiadapter.RespondWithError(w, r, err)
+		return
+	}
+	personID := domain.PersonID(personUUID)
+
+	if err := h.service.DeletePerson(ctx, personID); err != nil {
+		logger.Warn("HandleDeletePerson: service.DeletePerson failed", "person_id", personID, "err", err)
+		stdlibapiadapter.RespondWithError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleRestorePerson handles POST requests to /person/{id}/restore, undoing a soft delete
+func (h *PersonHandler) HandleRestorePerson(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.FromContext(ctx)
+
+	personUUID, err := stdlibapiadapter.PathUUIDv7(r, "id")
+	if err != nil {
+		logger.Warn("HandleRestorePerson: invalid id", "id", r.PathValue("id"), "err", err)
+		stdlibapiadapter.RespondWithError(w, r, err)
+		return
+	}
+	personID := domain.PersonID(personUUID)
+
+	restoredPerson, err := h.service.RestorePerson(ctx, personID)
+	if err != nil {
+		logger.Warn("HandleRestorePerson: service.RestorePerson failed", "person_id", personID, "err", err)
+		stdlibapiadapter.RespondWithError(w, r, err)
+		return
+	}
+
+	stdlibapiadapter.RespondWithJSON(w, http.StatusOK, toPersonResponse(*restoredPerson))
+}
+
+// HandleErasePerson handles POST requests to /person/{id}/erase, anonymising the person for a GDPR erasure request
+func (h *PersonHandler) HandleErasePerson(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.FromContext(ctx)
+
+	personUUID, err := stdlibapiadapter.PathUUIDv7(r, "id")
+	if err != nil {
+		logger.Warn("HandleErasePerson: invalid id", "id", r.PathValue("id"), "err", err)
+		stdlibapiadapter.RespondWithError(w, r, err)
+		return
+	}
+	personID := domain.PersonID(personUUID)
+
+	erasedPerson, err := h.service.ErasePerson(ctx, personID)
+	if err != nil {
+		logger.Warn("HandleErasePerson: service.ErasePerson failed", "person_id", personID, "err", err)
+		stdlibapiadapter.RespondWithError(w, r, err)
+		return
+	}
+
+	stdlibapiadapter.RespondWithJSON(w, http.StatusOK, toPersonResponse(*erasedPerson))
+}
+
+// parseIncludeDeleted reads the include_deleted query parameter, false when missing
+func parseIncludeDeleted(params url.Values) (bool, error) {
+	param := strings.TrimSpace(params.Get("include_deleted"))
+	if param == "" {
+		return false, nil
+	}
+
+	includeDeleted, err := strconv.ParseBool(param)
+	if err != nil {
+		return false, errIncludeDeletedFormat
+	}
+	return includeDeleted, nil
+}
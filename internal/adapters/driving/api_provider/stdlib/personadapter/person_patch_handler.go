@@ -0,0 +1,63 @@
+package personadapter
+
+import (
+	"encoding/json"
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
+	"louder/internal/core/domain"
+	"louder/pkg/logging"
+	"louder/pkg/types"
+	"net/http"
+)
+
+// HandleUpdatePerson handles PATCH requests to /person/{id}, only the fields present in the payload are changed
+func (h *PersonHandler) HandleUpdatePerson(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.FromContext(ctx)
+
+	personUUID, err := stdlibapiadapter.PathUUIDv7(r, "id")
+	if err != nil {
+		logger.Warn("HandleUpdatePerson: invalid id", "id", r.PathValue("id"), "err", err)
+		stdlibapiadapter.RespondWithError(w, r, err)
+		return
+	}
+	personID := domain.PersonID(personUUID)
+
+	var req UpdatePersonRequest
+	if err := stdlibapiadapter.DecodeJSONBody(r, &req); err != nil {
+		logger.Warn("HandleUpdatePerson: decoding request", "err", err)
+		stdlibapiadapter.RespondWithError(w, r, err)
+		return
+	}
+
+	changes := domain.PersonChanges{
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		Email:     req.Email,
+	}
+
+	if req.DOB != nil {
+		dob, err := types.ParseUTCTime(*req.DOB)
+		if err != nil {
+			logger.Warn("HandleUpdatePerson: parsing DOB", "dob", *req.DOB, "err", err)
+			stdlibapiadapter.RespondWithError(w, r, errDOBFormat)
+			return
+		}
+		changes.DOB = &dob
+	}
+
+	updatedPerson, err := h.service.UpdatePerson(ctx, personID, changes)
+	if err != nil {
+		logger.Warn("HandleUpdatePerson: service.UpdatePerson failed", "person_id", personID, "err", err)
+		stdlibapiadapter.RespondWithError(w, r, err)
+		return
+	}
+
+	responseDTO := toPersonResponse(*updatedPerson)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(responseDTO); err != nil {
+		logger.Error("HandleUpdatePerson: encoding response", "err", err)
+	}
+
+	logger.Info("HandleUpdatePerson: person updated", "person_id", updatedPerson.ID().String())
+}
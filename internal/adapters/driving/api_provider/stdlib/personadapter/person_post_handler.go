@@ -3,11 +3,17 @@ package personadapter
 import (
 	"encoding/json"
 	"fmt"
-	"log"
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
+	"louder/internal/core/errkind"
 	"louder/internal/core/service/personcore"
+	"louder/pkg/logging"
+	"louder/pkg/types"
 	"net/http"
 )
 
+// errDOBFormat is reported when the dob in a payload can't be parsed at all, the date itself is validated in the domain
+var errDOBFormat = errkind.NewField("dob", "invalid format, expected YYYY-MM-DD or RFC3339")
+
 // PersonHandler handles HTTP requests related to person entities
 type PersonHandler struct {
 	service personcore.PersonService // dependency on the Person Service Interface
@@ -24,38 +30,32 @@ func NewPersonHandler(srv personcore.PersonService) *PersonHandler {
 func (h *PersonHandler) HandleCreatePerson(w http.ResponseWriter, r *http.Request) {
 	// do not forget to pass the context!
 	ctx := r.Context()
-
-	if r.Method != http.MethodPost {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
+	logger := logging.FromContext(ctx)
 
 	var req CreatePersonRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		log.Printf("error HandleCreatePerson - decoding request: %v", err)
-		http.Error(w, fmt.Sprintf("invalid payload: %v", err), http.StatusBadRequest)
+	if err := stdlibapiadapter.DecodeJSONBody(r, &req); err != nil {
+		logger.Warn("HandleCreatePerson: decoding request", "err", err)
+		stdlibapiadapter.RespondWithError(w, r, err)
 		return
 	}
-	defer r.Body.Close()
 
-	// should user be able to submit person DOB:
-	// Validate and parse DOB from request DTO
-	// dob, err := time.Parse("2006-01-02", req.DOB)
-	// if err != nil {
-	// 	log.Printf("ERROR HandleCreatePerson - parsing DOB '%s': %v", req.DOB, err)
-	// 	http.Error(w, fmt.Sprintf("Bad Request: Invalid DOB format (expected YYYY-MM-DD): %v", err), http.StatusBadRequest)
-	// 	return
-	// }
+	// the DOB is optional in the payload only if a random one is explicitly requested, the rules themselves live in the domain
+	var dob types.UTCTime
+	if req.DOB != "" {
+		parsedDOB, err := types.ParseUTCTime(req.DOB)
+		if err != nil {
+			logger.Warn("HandleCreatePerson: parsing DOB", "dob", req.DOB, "err", err)
+			stdlibapiadapter.RespondWithError(w, r, errDOBFormat)
+			return
+		}
+		dob = parsedDOB
+	}
 
 	// Now we call the service layer with the context and (validated) data
-	createdPerson, err := h.service.CreatePerson(ctx, req.FirstName, req.LastName, req.Email)
+	createdPerson, err := h.service.CreatePerson(ctx, req.FirstName, req.LastName, req.Email, dob, req.RandomDOB)
 	if err != nil {
-		log.Printf("ERROR HandleCreatePerson - service.CreatePerson: %v", err)
-		// IMPORTANT
-		// check here for specific error types from the service layer
-		// to return more granular HTTP status codes (e.g., 409 Conflict for duplicate email).
-		// For now, a generic 500.
-		http.Error(w, "failed to create person.", http.StatusInternalServerError)
+		logger.Warn("HandleCreatePerson: service.CreatePerson failed", "err", err)
+		stdlibapiadapter.RespondWithError(w, r, err)
 		return
 	}
 
@@ -69,9 +69,8 @@ func (h *PersonHandler) HandleCreatePerson(w http.ResponseWriter, r *http.Reques
 
 	// encode the responseDTO into JSON
 	if err := json.NewEncoder(w).Encode(responseDTO); err != nil {
-		log.Printf("error HandleCreatePerson - encoding response: %v", err)
-		http.Error(w, "could not encode JSON", http.StatusInternalServerError)
+		logger.Error("HandleCreatePerson: encoding response", "err", err)
 	}
 
-	log.Printf("info: new person created succesfully: %s", createdPerson.ID().String())
+	logger.Info("HandleCreatePerson: person created", "person_id", createdPerson.ID().String())
 }
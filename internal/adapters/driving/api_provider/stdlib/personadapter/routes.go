@@ -1,12 +1,109 @@
 package personadapter
 
-import "net/http"
-
-func (h *PersonHandler) RegisterRoutes(mux *http.ServeMux) {
-	const (
-		GetPersonRoute = "/person/"
-		NewPersonRoute = "/person"
-	)
-	mux.HandleFunc(http.MethodGet+" "+GetPersonRoute, h.HandleGetPersonByID)
-	mux.HandleFunc(http.MethodPost+" "+NewPersonRoute, h.HandleCreatePerson)
+import (
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
+	"louder/internal/core/domain"
+	"net/http"
+)
+
+const (
+	GetPersonRoute      = "/person/{id}"
+	NewPersonRoute      = "/person"
+	SamplePersonRoute   = "/person/random"
+	GeneratePeopleRoute = "/person/generate"
+	UpdatePersonRoute   = "/person/{id}"
+	DeletePersonRoute   = "/person/{id}"
+	RestorePersonRoute  = "/person/{id}/restore"
+	ErasePersonRoute    = "/person/{id}/erase"
+)
+
+func (h *PersonHandler) RegisterRoutes(mux stdlibapiadapter.Routes) {
+	mux.Handle(http.MethodGet+" "+GetPersonRoute, stdlibapiadapter.Authorize(domain.RoleReader, h.HandleGetPersonByID))
+	mux.Handle(http.MethodPost+" "+NewPersonRoute, stdlibapiadapter.Authorize(domain.RoleEditor, h.HandleCreatePerson))
+	mux.Handle(http.MethodGet+" "+SamplePersonRoute, stdlibapiadapter.Authorize(domain.RoleReader, h.HandleSamplePeople))
+	mux.Handle(http.MethodPost+" "+GeneratePeopleRoute, stdlibapiadapter.Authorize(domain.RoleEditor, h.HandleGeneratePeople))
+	// readers may only update themselves, the service checks ownership
+	mux.Handle(http.MethodPatch+" "+UpdatePersonRoute, stdlibapiadapter.Authorize(domain.RoleReader, h.HandleUpdatePerson))
+	mux.Handle(http.MethodDelete+" "+DeletePersonRoute, stdlibapiadapter.Authorize(domain.RoleEditor, h.HandleDeletePerson))
+	mux.Handle(http.MethodPost+" "+RestorePersonRoute, stdlibapiadapter.Authorize(domain.RoleAdmin, h.HandleRestorePerson))
+	// readers may only erase themselves, the service checks ownership
+	mux.Handle(http.MethodPost+" "+ErasePersonRoute, stdlibapiadapter.Authorize(domain.RoleReader, h.HandleErasePerson))
+}
+
+func (h *PersonHandler) Operations() []stdlibapiadapter.Operation {
+	tags := []string{"person"}
+	idParam := []stdlibapiadapter.Param{{Name: "id", Format: "uuid", Description: "version 7 UUID of the person"}}
+	includeDeletedParam := stdlibapiadapter.Param{Name: "include_deleted", Type: "boolean", Description: "also find soft deleted people, admins only"}
+
+	return []stdlibapiadapter.Operation{
+		{
+			Pattern:     http.MethodGet + " " + GetPersonRoute,
+			Summary:     "Get a person by ID",
+			Description: "Soft deleted people are not found unless include_deleted is set.",
+			Tags:        tags,
+			PathParams:  idParam,
+			QueryParams: []stdlibapiadapter.Param{includeDeletedParam},
+			Responses:   map[int]any{http.StatusOK: PersonResponse{}},
+		},
+		{
+			Pattern:     http.MethodPost + " " + NewPersonRoute,
+			Summary:     "Create a person",
+			Description: "The email must not be in use already. The date of birth is required unless random_dob asks for a made up one.",
+			Tags:        tags,
+			RequestBody: CreatePersonRequest{},
+			Responses:   map[int]any{http.StatusCreated: PersonResponse{}},
+		},
+		{
+			Pattern: http.MethodGet + " " + SamplePersonRoute,
+			Summary: "Draw random people",
+			Tags:    tags,
+			QueryParams: stdlibapiadapter.SampleQueryParams(
+				stdlibapiadapter.Param{Name: "country", Description: "only people living in this 2 letter country"},
+				stdlibapiadapter.Param{Name: "birth_country", Description: "only people born in this 2 letter country"},
+				includeDeletedParam,
+			),
+			Responses: map[int]any{http.StatusOK: PeopleSampleResponse{}},
+		},
+		{
+			Pattern:     http.MethodPost + " " + GeneratePeopleRoute,
+			Summary:     "Generate fake people",
+			Description: "Creates count people with made up data, meant for load testing.",
+			Tags:        tags,
+			QueryParams: []stdlibapiadapter.Param{{Name: "count", Type: "integer", Required: true, Description: "how many people to create"}},
+			Responses:   map[int]any{http.StatusCreated: GeneratePeopleResponse{}},
+		},
+		{
+			Pattern:     http.MethodPatch + " " + UpdatePersonRoute,
+			Summary:     "Update a person",
+			Description: "Only the fields present in the payload are changed.",
+			Tags:        tags,
+			PathParams:  idParam,
+			RequestBody: UpdatePersonRequest{},
+			Responses:   map[int]any{http.StatusOK: PersonResponse{}},
+		},
+		{
+			Pattern:     http.MethodDelete + " " + DeletePersonRoute,
+			Summary:     "Delete a person",
+			Description: "Soft deletes the person, they can be restored until the retention period is over and they're purged. Their email stays taken until then.",
+			Tags:        tags,
+			PathParams:  idParam,
+			Responses:   map[int]any{http.StatusNoContent: nil},
+		},
+		{
+			Pattern:     http.MethodPost + " " + RestorePersonRoute,
+			Summary:     "Restore a deleted person",
+			Description: "Restoring a person who isn't deleted changes nothing.",
+			Tags:        tags,
+			PathParams:  idParam,
+			Responses:   map[int]any{http.StatusOK: PersonResponse{}},
+		},
+		{
+			Pattern:     http.MethodPost + " " + ErasePersonRoute,
+			Summary:     "Erase a person's personal data",
+			Description: "For GDPR erasure requests: the name and email are anonymised and the person is soft deleted. Readers may only erase themselves.",
+			Tags:        tags,
+			PathParams:  idParam,
+			Responses:   map[int]any{http.StatusOK: PersonResponse{}},
+		},
+	}
 }
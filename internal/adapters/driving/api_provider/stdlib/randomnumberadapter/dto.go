@@ -14,3 +14,15 @@ type RandomNumberResponse struct {
 type DiceRollResponse struct {
 	DiceRoll DiceRollDTO `json:"diceroll"`
 }
+
+type DiceLimitsDTO struct {
+	MaxDice  uint `json:"max_dice"`
+	MaxSides uint `json:"max_sides"`
+}
+
+// DiceLimitsResponse reports the limits of the caller's rolls and where they come from. Overrides for a client are set on the server and only show up in active.
+type DiceLimitsResponse struct {
+	Active    DiceLimitsDTO            `json:"active"`
+	Default   DiceLimitsDTO            `json:"default"`
+	Endpoints map[string]DiceLimitsDTO `json:"endpoints"` // by route pattern, e.g. "POST /diceroll"
+}
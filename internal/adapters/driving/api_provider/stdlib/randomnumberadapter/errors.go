@@ -1,13 +1,13 @@
 package randomnumberadapter
 
-import "errors"
+import "louder/internal/core/errkind"
 
 // Sentinel errors for randomnumbers
 var (
-	ErrMissingNumDice  = errors.New("Missing required parameter: 'numdice'")
-	ErrMissingNumSides = errors.New("Missing required parameter: 'numsides'")
-	ErrFormatNumDice   = errors.New("Invalid format for 'numdice': must be a valid integer.")
-	ErrFormatNumSides  = errors.New("Invalid format for 'numsides': must be a valid integer.")
-	ErrValueNumDice    = errors.New("Value error for 'numdice': must be a positive number")
-	ErrValueNumSides   = errors.New("Value error for 'numsides': must be a positive number")
+	ErrMissingNumDice  = errkind.NewField("numdice", "missing required parameter")
+	ErrMissingNumSides = errkind.NewField("numsides", "missing required parameter")
+	ErrFormatNumDice   = errkind.NewField("numdice", "must be a valid integer")
+	ErrFormatNumSides  = errkind.NewField("numsides", "must be a valid integer")
+	ErrValueNumDice    = errkind.NewField("numdice", "must be a positive number")
+	ErrValueNumSides   = errkind.NewField("numsides", "must be a positive number")
 )
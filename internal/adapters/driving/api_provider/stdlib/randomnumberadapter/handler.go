@@ -3,10 +3,9 @@ package randomnumberadapter
 import (
 	"encoding/json"
 	"errors"
-	"log"
 	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
-	"louder/internal/core/domain"
 	"louder/internal/core/service/randomnumberscore"
+	"louder/pkg/logging"
 	"strconv"
 	"strings"
 
@@ -33,24 +32,25 @@ func NewRandomDiceHandler(service randomnumberscore.Port) *DiceRollHandler {
 
 // HandleGetRandomNumber is an http.HandlerFunc for the /random route
 func (h *RandomNumberHandler) HandleGetRandomNumber(w http.ResponseWriter, r *http.Request) {
-	log.Println("stdlib API adapter: GET for /random")
+	logger := logging.FromContext(r.Context())
+	logger.Debug("stdlib API adapter: GET for /random")
 
 	randomNumber := h.RandomNumberService.GetRandomNumber()
 	response := RandomNumberResponse{RandomNumber: randomNumber}
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(response); err != nil {
-		log.Printf("Failed to encode random number response %v", err)
-		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		logger.Error("failed to encode random number response", "err", err)
 	}
 }
 
 // HandleGetDiceRoll is an http.HandlerFunc for the /diceroll route
 func (h *DiceRollHandler) HandleGetDiceRoll(w http.ResponseWriter, r *http.Request) {
-	log.Println("stdlib API adapter: GET for /diceroll")
+	logger := logging.FromContext(r.Context())
+	logger.Debug("stdlib API adapter: POST for /diceroll")
 
 	params := r.URL.Query()
-	validationErrors := make([]string, 0)
+	validationErrors := make([]error, 0)
 
 	// convert the params to string first to remove \" if the user has provided a string as opposed to a number
 	numDiceParam := strings.Trim(params.Get("numdice"), "\"` ")
@@ -59,59 +59,46 @@ func (h *DiceRollHandler) HandleGetDiceRoll(w http.ResponseWriter, r *http.Reque
 
 	// check if required params exist
 	if numDiceParam == "" {
-		validationErrors = append(validationErrors, ErrMissingNumDice.Error())
+		validationErrors = append(validationErrors, ErrMissingNumDice)
 	} else {
 		val, err := strconv.Atoi(numDiceParam)
 		switch {
 		case err != nil:
-			validationErrors = append(validationErrors, ErrFormatNumDice.Error())
+			validationErrors = append(validationErrors, ErrFormatNumDice)
 		case val <= 0:
 			// this test is needed in case of a negative int being later converted to uint
-			validationErrors = append(validationErrors, ErrValueNumDice.Error())
+			validationErrors = append(validationErrors, ErrValueNumDice)
 		default:
 			numDice = uint(val)
 		}
 	}
 
 	if numSidesParam == "" {
-		validationErrors = append(validationErrors, ErrMissingNumSides.Error())
+		validationErrors = append(validationErrors, ErrMissingNumSides)
 	} else {
 		val, err := strconv.Atoi(numSidesParam)
 		switch {
 		case err != nil:
-			validationErrors = append(validationErrors, ErrFormatNumSides.Error())
+			validationErrors = append(validationErrors, ErrFormatNumSides)
 		case val <= 0:
-			validationErrors = append(validationErrors, ErrValueNumSides.Error())
+			validationErrors = append(validationErrors, ErrValueNumSides)
 		default:
 			numSides = uint(val)
 		}
 	}
 
 	if len(validationErrors) > 0 {
-		stdlibapiadapter.RespondWithJSON(w, http.StatusBadRequest, stdlibapiadapter.ErrorResponse{ErrorMsgs: validationErrors})
+		stdlibapiadapter.RespondWithError(w, r, errors.Join(validationErrors...))
 		return
 	}
 
 	// Initially I was validating everything before giving any feedback to the user but AI suggested separating Transport and Domain validation separate so lets go with that
 
-	diceRoll, err := h.RandomDiceService.RollDice(uint(numDice), uint(numSides))
+	diceRoll, err := h.RandomDiceService.RollDice(r.Context(), diceRollPattern, numDice, numSides)
 	if err != nil {
-		log.Printf("Service error during RollDice: %v", err)
-
-		if errors.Is(err, domain.ErrInvalidNumDice) {
-			validationErrors = append(validationErrors, domain.ErrInvalidNumDice.Error())
-		}
-		if errors.Is(err, domain.ErrInvalidNumSides) {
-			validationErrors = append(validationErrors, domain.ErrInvalidNumSides.Error())
-		}
-
-		switch {
-		case len(validationErrors) > 0:
-			stdlibapiadapter.RespondWithJSON(w, http.StatusBadRequest, stdlibapiadapter.ErrorResponse{ErrorMsgs: validationErrors})
-		default:
-			stdlibapiadapter.RespondWithError(w, http.StatusInternalServerError, err.Error())
-		}
-
+		logger.Warn("service error during RollDice", "err", err)
+		// the domain reports every out of range value at once, they all come back as field errors
+		stdlibapiadapter.RespondWithError(w, r, err)
 		return
 	}
 
@@ -119,3 +106,12 @@ func (h *DiceRollHandler) HandleGetDiceRoll(w http.ResponseWriter, r *http.Reque
 	response := DiceRollResponse{DiceRoll: *toRandomNumberDTO(diceRoll)}
 	stdlibapiadapter.RespondWithJSON(w, http.StatusOK, response)
 }
+
+// HandleGetDiceLimits is an http.HandlerFunc for the /diceroll/limits route. It reports the limits of the caller's rolls, the default ones and every endpoint override
+func (h *DiceRollHandler) HandleGetDiceLimits(w http.ResponseWriter, r *http.Request) {
+	logging.FromContext(r.Context()).Debug("stdlib API adapter: GET for /diceroll/limits")
+
+	active := h.RandomDiceService.DiceLimits(r.Context(), diceRollPattern)
+	response := toDiceLimitsResponse(active, h.RandomDiceService.DefaultDiceLimits(), h.RandomDiceService.EndpointDiceLimits())
+	stdlibapiadapter.RespondWithJSON(w, http.StatusOK, response)
+}
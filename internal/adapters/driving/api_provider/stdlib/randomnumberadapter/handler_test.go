@@ -0,0 +1,121 @@
+package randomnumberadapter_test
+
+import (
+	"encoding/json"
+	"louder/internal/adapters/driving/api_provider/stdlib/randomnumberadapter"
+	"louder/internal/core/domain"
+	"louder/internal/core/service/authcore"
+	"louder/internal/core/service/randomnumberscore"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// onesRoller rolls a 1 on every die
+type onesRoller struct{}
+
+func (onesRoller) GenerateRandomNumber() domain.RandomNumber { return 1 }
+
+func (onesRoller) GenerateDiceRoll(numDice, _ uint) (*domain.RandomDice, error) {
+	roll := make([]uint, numDice)
+	for i := range roll {
+		roll[i] = 1
+	}
+	return &domain.RandomDice{Roll: roll, RollSum: uint(numDice)}, nil
+}
+
+func limits(t *testing.T, maxDice, maxSides uint) domain.DiceLimits {
+	t.Helper()
+	l, err := domain.NewDiceLimits(maxDice, maxSides)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+func newDiceMux(t *testing.T) *http.ServeMux {
+	service := randomnumberscore.NewDiceRollService(onesRoller{}, authcore.ContextCaller{}, randomnumberscore.DiceLimitRules{
+		Default:   limits(t, 10, 20),
+		Clients:   map[string]domain.DiceLimits{"louder/big-game": limits(t, 50, 100)},
+		Endpoints: map[string]domain.DiceLimits{"POST /diceroll": limits(t, 5, 12)},
+	})
+	mux := http.NewServeMux()
+	randomnumberadapter.NewRandomDiceHandler(service).RegisterRoutes(mux)
+	return mux
+}
+
+// send makes the request as the API key with the given ID
+func send(t *testing.T, mux *http.ServeMux, method, target, keyID string) *httptest.ResponseRecorder {
+	t.Helper()
+	caller, err := domain.NewPrincipal(keyID, domain.APIKeyIssuer, domain.AuthMethodAPIKey, domain.RoleReader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(method, target, nil)
+	req = req.WithContext(authcore.WithPrincipal(req.Context(), caller))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandleGetDiceRoll(t *testing.T) {
+	mux := newDiceMux(t)
+
+	tests := map[string]struct {
+		query      string
+		keyID      string
+		wantStatus int
+	}{
+		"within the endpoint's limits":     {query: "numdice=5&numsides=12", keyID: "small-game", wantStatus: http.StatusOK},
+		"over the endpoint's dice":         {query: "numdice=6&numsides=12", keyID: "small-game", wantStatus: http.StatusBadRequest},
+		"over the endpoint's sides":        {query: "numdice=2&numsides=20", keyID: "small-game", wantStatus: http.StatusBadRequest},
+		"the client's override wins":       {query: "numdice=50&numsides=100", keyID: "big-game", wantStatus: http.StatusOK},
+		"over the client's override":       {query: "numdice=51&numsides=100", keyID: "big-game", wantStatus: http.StatusBadRequest},
+		"a profile can't be picked":        {query: "numdice=50&numsides=100&profile=big-game", keyID: "small-game", wantStatus: http.StatusBadRequest},
+		"missing parameters still checked": {query: "numdice=2", keyID: "small-game", wantStatus: http.StatusBadRequest},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := send(t, mux, http.MethodPost, "/diceroll?"+tc.query, tc.keyID)
+			if rec.Code != tc.wantStatus {
+				t.Errorf("status = %d, want %d (body %s)", rec.Code, tc.wantStatus, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandleGetDiceLimits(t *testing.T) {
+	mux := newDiceMux(t)
+
+	tests := map[string]struct {
+		keyID      string
+		wantActive randomnumberadapter.DiceLimitsDTO
+	}{
+		"endpoint override": {keyID: "small-game", wantActive: randomnumberadapter.DiceLimitsDTO{MaxDice: 5, MaxSides: 12}},
+		"client override":   {keyID: "big-game", wantActive: randomnumberadapter.DiceLimitsDTO{MaxDice: 50, MaxSides: 100}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := send(t, mux, http.MethodGet, "/diceroll/limits", tc.keyID)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want 200 (body %s)", rec.Code, rec.Body.String())
+			}
+
+			var got randomnumberadapter.DiceLimitsResponse
+			if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			if got.Active != tc.wantActive {
+				t.Errorf("active = %+v, want %+v", got.Active, tc.wantActive)
+			}
+			if got.Default != (randomnumberadapter.DiceLimitsDTO{MaxDice: 10, MaxSides: 20}) {
+				t.Errorf("default = %+v, want 10 dice of 20 sides", got.Default)
+			}
+			if len(got.Endpoints) != 1 || got.Endpoints["POST /diceroll"].MaxDice != 5 {
+				t.Errorf("endpoints = %+v, want the POST /diceroll override", got.Endpoints)
+			}
+		})
+	}
+}
@@ -13,3 +13,23 @@ func toRandomNumberDTO(p *domain.RandomDice) *DiceRollDTO {
 		Sum:  p.RollSum,
 	}
 }
+
+func toDiceLimitsDTO(l domain.DiceLimits) DiceLimitsDTO {
+	return DiceLimitsDTO{
+		MaxDice:  l.MaxDice(),
+		MaxSides: l.MaxSides(),
+	}
+}
+
+func toDiceLimitsResponse(active, defaultLimits domain.DiceLimits, endpoints map[string]domain.DiceLimits) DiceLimitsResponse {
+	endpointDTOs := make(map[string]DiceLimitsDTO, len(endpoints))
+	for pattern, limits := range endpoints {
+		endpointDTOs[pattern] = toDiceLimitsDTO(limits)
+	}
+
+	return DiceLimitsResponse{
+		Active:    toDiceLimitsDTO(active),
+		Default:   toDiceLimitsDTO(defaultLimits),
+		Endpoints: endpointDTOs,
+	}
+}
@@ -1,18 +1,59 @@
 package randomnumberadapter
 
-import "net/http"
+import (
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
+	"louder/internal/core/domain"
+	"net/http"
+)
 
-func (h *RandomNumberHandler) RegisterRoutes(mux *http.ServeMux) {
-	const (
-		NewRandomNumberRoute = "/random"
-	)
+const (
+	NewRandomNumberRoute = "/random"
+	NewDiceRollRoute     = "/diceroll"
+	DiceRollLimitRoute   = "/diceroll/limits"
+)
 
-	mux.HandleFunc(http.MethodGet+" "+NewRandomNumberRoute, h.HandleGetRandomNumber)
+// diceRollPattern is how the roll route is known to the dice limits and the rate limiter
+const diceRollPattern = http.MethodPost + " " + NewDiceRollRoute
+
+func (h *RandomNumberHandler) RegisterRoutes(mux stdlibapiadapter.Routes) {
+	mux.Handle(http.MethodGet+" "+NewRandomNumberRoute, stdlibapiadapter.Authorize(domain.RoleReader, h.HandleGetRandomNumber))
+}
+
+func (h *RandomNumberHandler) Operations() []stdlibapiadapter.Operation {
+	return []stdlibapiadapter.Operation{
+		{
+			Pattern:   http.MethodGet + " " + NewRandomNumberRoute,
+			Summary:   "Get a random number",
+			Tags:      []string{"random"},
+			Responses: map[int]any{http.StatusOK: RandomNumberResponse{}},
+		},
+	}
 }
 
-func (h *DiceRollHandler) RegisterRoutes(mux *http.ServeMux) {
-	const (
-		NewDiceRollRoute = "/diceroll"
-	)
-	mux.HandleFunc(http.MethodPost+" "+NewDiceRollRoute, h.HandleGetDiceRoll)
+func (h *DiceRollHandler) RegisterRoutes(mux stdlibapiadapter.Routes) {
+	mux.Handle(diceRollPattern, stdlibapiadapter.Authorize(domain.RoleReader, h.HandleGetDiceRoll))
+	mux.Handle(http.MethodGet+" "+DiceRollLimitRoute, stdlibapiadapter.Authorize(domain.RoleReader, h.HandleGetDiceLimits))
+}
+
+func (h *DiceRollHandler) Operations() []stdlibapiadapter.Operation {
+	tags := []string{"dice"}
+
+	return []stdlibapiadapter.Operation{
+		{
+			Pattern: diceRollPattern,
+			Summary: "Roll dice",
+			Tags:    tags,
+			QueryParams: []stdlibapiadapter.Param{
+				{Name: "numdice", Type: "integer", Required: true, Description: "how many dice to roll"},
+				{Name: "numsides", Type: "integer", Required: true, Description: "how many sides each die has"},
+			},
+			Responses: map[int]any{http.StatusOK: DiceRollResponse{}},
+		},
+		{
+			Pattern:   http.MethodGet + " " + DiceRollLimitRoute,
+			Summary:   "Get the dice roll limits",
+			Tags:      tags,
+			Responses: map[int]any{http.StatusOK: DiceLimitsResponse{}},
+		},
+	}
 }
@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// how often the memory store drops the buckets nobody has used for a while
+const sweepInterval = time.Minute
+
+// MemoryStore keeps the buckets in this process, fine for a single instance. Idle buckets are dropped as they'd be full anyway.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*memoryBucket
+	lastSweep time.Time
+}
+
+type memoryBucket struct {
+	bucket
+	idleAfter time.Duration // once unused for this long the bucket is full again and can go
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*memoryBucket)}
+}
+
+func (ms *MemoryStore) Take(_ context.Context, key string, limit Limit, now time.Time) (Result, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if now.Sub(ms.lastSweep) >= sweepInterval {
+		ms.sweep(now)
+	}
+
+	b, ok := ms.buckets[key]
+	if !ok {
+		b = &memoryBucket{}
+		ms.buckets[key] = b
+	}
+	b.idleAfter = limit.interval() * time.Duration(limit.Capacity())
+
+	return b.take(limit, now), nil
+}
+
+func (ms *MemoryStore) Peek(_ context.Context, key string, limit Limit, now time.Time) (Result, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	var b bucket
+	if stored, ok := ms.buckets[key]; ok {
+		b = stored.bucket
+	}
+	return b.take(limit, now), nil
+}
+
+// Len is the number of buckets held
+func (ms *MemoryStore) Len() int {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	return len(ms.buckets)
+}
+
+func (ms *MemoryStore) sweep(now time.Time) {
+	for key, b := range ms.buckets {
+		if now.Sub(b.last) >= b.idleAfter {
+			delete(ms.buckets, key)
+		}
+	}
+	ms.lastSweep = now
+}
@@ -0,0 +1,251 @@
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
+	"louder/internal/core/domain"
+	"louder/internal/core/errkind"
+	"louder/internal/core/service/authcore"
+	"louder/pkg/logging"
+	"math"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IPv6 clients usually get a whole /64, limiting single addresses would be pointless
+const ipv6ClientBits = 64
+
+// the name of the budget every route without one of its own shares
+const defaultBudget = "default"
+
+// the name of the per IP budget of failed authentications
+const authFailuresBudget = "auth-failures"
+
+// Budget gives the routes matching Pattern a limit of their own. Pattern is a ServeMux pattern as the resource registers it, without the group prefix, e.g. "POST /diceroll".
+type Budget struct {
+	Pattern string
+	Limit   Limit
+}
+
+// Config sets the limits. Routes without a budget share the default one, no limit at all if Default is the zero Limit.
+type Config struct {
+	Default      Limit
+	Budgets      []Budget
+	Prefixes     []string // group prefixes stripped from the path before the budgets are matched, e.g. "/api/v1"
+	AuthFailures Limit    // failed authentications allowed per client IP, no limit if zero
+}
+
+// Limiter picks the budget of each request and spends a token of the caller's bucket for it
+type Limiter struct {
+	store    Store
+	config   Config
+	limits   map[string]Limit // by budget pattern
+	matcher  *http.ServeMux   // only used to match the budget patterns, its handlers never run
+	prefixes []string         // longest first
+	now      func() time.Time
+}
+
+// NewLimiter checks the budgets' patterns and limits up front
+func NewLimiter(store Store, config Config) (*Limiter, error) {
+	l := &Limiter{
+		store:    store,
+		config:   config,
+		limits:   make(map[string]Limit, len(config.Budgets)),
+		matcher:  http.NewServeMux(),
+		prefixes: slices.Clone(config.Prefixes),
+		now:      time.Now,
+	}
+	slices.SortFunc(l.prefixes, func(a, b string) int { return len(b) - len(a) })
+
+	var errs []error
+	if l := config.AuthFailures; l != (Limit{}) && (l.Requests <= 0 || l.Per <= 0) {
+		errs = append(errs, fmt.Errorf("auth failures: invalid limit %s", l))
+	}
+	for _, budget := range config.Budgets {
+		if budget.Limit.Requests <= 0 || budget.Limit.Per <= 0 {
+			errs = append(errs, fmt.Errorf("budget %q: invalid limit %s", budget.Pattern, budget.Limit))
+			continue
+		}
+		if err := l.register(budget.Pattern); err != nil {
+			errs = append(errs, fmt.Errorf("budget %q: %w", budget.Pattern, err))
+			continue
+		}
+		l.limits[budget.Pattern] = budget.Limit
+	}
+
+	return l, errors.Join(errs...)
+}
+
+// register adds pattern to the matcher, which panics on malformed or duplicate patterns
+func (l *Limiter) register(pattern string) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("%v", rec)
+		}
+	}()
+	l.matcher.Handle(pattern, http.NotFoundHandler())
+	return nil
+}
+
+// Middleware answers 429 once the caller's bucket for the route is empty and tells every response how much is left through the RateLimit-* headers. It must run after Authenticate so API keys and tokens get a bucket of their own, anonymous callers are told apart by IP. A failing store lets requests through.
+func (l *Limiter) Middleware() stdlibapiadapter.Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			budget, limit := l.budget(r)
+			if limit.Requests == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key := budget + "|" + clientKey(r)
+			res, err := l.store.Take(r.Context(), key, limit, l.now())
+			if err != nil {
+				logging.FromContext(r.Context()).Error("rate limit store failed, letting the request through", "budget", budget, "err", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h := w.Header()
+			h.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d;burst=%d", limit.Requests, seconds(limit.Per), limit.Capacity()))
+			h.Set("RateLimit-Limit", strconv.Itoa(limit.Capacity()))
+			h.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			h.Set("RateLimit-Reset", strconv.Itoa(seconds(res.ResetAfter)))
+
+			if !res.Allowed {
+				retryAfter := max(seconds(res.RetryAfter), 1)
+				h.Set("Retry-After", strconv.Itoa(retryAfter))
+				logging.FromContext(r.Context()).Warn("rate limited", "budget", budget, "limit", limit.String())
+				stdlibapiadapter.RespondWithError(w, r, errkind.New(errkind.RateLimited, fmt.Sprintf("rate limit exceeded, retry in %d seconds", retryAfter)))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// AuthFailures throttles credential guessing: each request with credentials that ends in a 401 spends a token of the client IP's failed authentication bucket, and once it's empty the IP's requests with credentials get a 429 before they're checked. It must run before Authenticate, which answers bad credentials before the route limits are reached. A failing store lets requests through.
+func (l *Limiter) AuthFailures() stdlibapiadapter.Middleware {
+	return func(next http.Handler) http.Handler {
+		limit := l.config.AuthFailures
+		if limit.Requests == 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// requests without credentials can't be guessing any
+			if r.Header.Get("Authorization") == "" && r.Header.Get(stdlibapiadapter.APIKeyHeader) == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			logger := logging.FromContext(r.Context())
+			key := authFailuresBudget + "|" + ipKey(r)
+			res, err := l.store.Peek(r.Context(), key, limit, l.now())
+			if err != nil {
+				logger.Error("rate limit store failed, letting the request through", "budget", authFailuresBudget, "err", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !res.Allowed {
+				retryAfter := max(seconds(res.RetryAfter), 1)
+				w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+				logging.Audit(r.Context()).Warn("too many failed authentications", "budget", authFailuresBudget, "limit", limit.String())
+				stdlibapiadapter.RespondWithError(w, r, errkind.New(errkind.RateLimited, fmt.Sprintf("too many failed authentications, retry in %d seconds", retryAfter)))
+				return
+			}
+
+			sw := &statusWriter{ResponseWriter: w}
+			next.ServeHTTP(sw, r)
+
+			if sw.status == http.StatusUnauthorized {
+				if _, err := l.store.Take(r.Context(), key, limit, l.now()); err != nil {
+					logger.Error("rate limit store failed to charge a failed authentication", "err", err)
+				}
+			}
+		})
+	}
+}
+
+// statusWriter remembers the status of the response
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (sw *statusWriter) WriteHeader(status int) {
+	if sw.status == 0 {
+		sw.status = status
+	}
+	sw.ResponseWriter.WriteHeader(status)
+}
+
+func (sw *statusWriter) Write(b []byte) (int, error) {
+	if sw.status == 0 {
+		sw.status = http.StatusOK
+	}
+	return sw.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (sw *statusWriter) Unwrap() http.ResponseWriter {
+	return sw.ResponseWriter
+}
+
+// budget returns the name and limit of the budget the request falls under
+func (l *Limiter) budget(r *http.Request) (string, Limit) {
+	if len(l.limits) > 0 {
+		probe := *r
+		u := *r.URL
+		u.Path = l.stripPrefix(u.Path)
+		probe.URL = &u
+
+		if _, pattern := l.matcher.Handler(&probe); pattern != "" {
+			if limit, ok := l.limits[pattern]; ok {
+				return pattern, limit
+			}
+		}
+	}
+	return defaultBudget, l.config.Default
+}
+
+func (l *Limiter) stripPrefix(path string) string {
+	for _, prefix := range l.prefixes {
+		if rest, ok := strings.CutPrefix(path, prefix); ok && (rest == "" || rest[0] == '/') {
+			if rest == "" {
+				return "/"
+			}
+			return rest
+		}
+	}
+	return path
+}
+
+// clientKey identifies who is spending the tokens, the principal if there's one and the client's network otherwise
+func clientKey(r *http.Request) string {
+	if p, ok := authcore.PrincipalFromContext(r.Context()); ok && p.Method() != domain.AuthMethodNone {
+		return "principal:" + p.String()
+	}
+	return ipKey(r)
+}
+
+// ipKey identifies the client's network
+func ipKey(r *http.Request) string {
+	ip := stdlibapiadapter.ClientIP(r)
+	if addr, err := netip.ParseAddr(ip); err == nil && addr.Is6() && !addr.Is4In6() {
+		if prefix, err := addr.Prefix(ipv6ClientBits); err == nil {
+			return "ip:" + prefix.String()
+		}
+	}
+	return "ip:" + ip
+}
+
+// seconds rounds up, so clients never come back too early
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
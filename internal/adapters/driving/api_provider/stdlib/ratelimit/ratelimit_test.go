@@ -0,0 +1,193 @@
+package ratelimit_test
+
+import (
+	"context"
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
+	"louder/internal/adapters/driving/api_provider/stdlib/ratelimit"
+	"louder/internal/core/domain"
+	"louder/internal/core/errkind"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestMemoryStoreTake(t *testing.T) {
+	limit := ratelimit.Limit{Requests: 6, Per: time.Minute, Burst: 3} // a token every 10s, 3 at most
+	start := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+
+	steps := []struct {
+		at            time.Duration
+		wantAllowed   bool
+		wantRemaining int
+		wantRetry     time.Duration
+	}{
+		{at: 0, wantAllowed: true, wantRemaining: 2},
+		{at: 0, wantAllowed: true, wantRemaining: 1},
+		{at: 0, wantAllowed: true, wantRemaining: 0},
+		{at: 0, wantAllowed: false, wantRemaining: 0, wantRetry: 10 * time.Second},
+		{at: 4 * time.Second, wantAllowed: false, wantRemaining: 0, wantRetry: 6 * time.Second},
+		{at: 10 * time.Second, wantAllowed: true, wantRemaining: 0},
+		{at: 5 * time.Minute, wantAllowed: true, wantRemaining: 2}, // refilled to the burst, not beyond
+	}
+
+	store := ratelimit.NewMemoryStore()
+	for i, step := range steps {
+		res, err := store.Take(context.Background(), "client", limit, start.Add(step.at))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Allowed != step.wantAllowed || res.Remaining != step.wantRemaining || res.RetryAfter.Round(time.Millisecond) != step.wantRetry {
+			t.Errorf("step %d: got allowed %v remaining %d retry %s, want %v %d %s", i, res.Allowed, res.Remaining, res.RetryAfter, step.wantAllowed, step.wantRemaining, step.wantRetry)
+		}
+	}
+
+	// idle buckets are swept once they'd be full again
+	if _, err := store.Take(context.Background(), "other", limit, start.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if n := store.Len(); n != 1 {
+		t.Errorf("store holds %d buckets after the sweep, want 1", n)
+	}
+}
+
+func TestLimiterMiddleware(t *testing.T) {
+	limiter, err := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), ratelimit.Config{
+		Default:  ratelimit.Limit{Requests: 100, Per: time.Minute},
+		Budgets:  []ratelimit.Budget{{Pattern: "POST /diceroll", Limit: ratelimit.Limit{Requests: 2, Per: time.Minute}}},
+		Prefixes: []string{"/api/v1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := limiter.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	send := func(method, path, ip string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.RemoteAddr = ip + ":1234"
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// the versioned and the legacy route share the budget
+	for _, path := range []string{"/api/v1/diceroll", "/diceroll"} {
+		if rec := send(http.MethodPost, path, "192.0.2.1"); rec.Code != http.StatusOK {
+			t.Fatalf("POST %s: status %d, want 200", path, rec.Code)
+		}
+	}
+
+	rec := send(http.MethodPost, "/api/v1/diceroll", "192.0.2.1")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("third roll: status %d, want 429", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "30" {
+		t.Errorf("Retry-After = %q, want 30", got)
+	}
+	if got := rec.Header().Get("RateLimit-Remaining"); got != "0" {
+		t.Errorf("RateLimit-Remaining = %q, want 0", got)
+	}
+	if got := rec.Header().Get("RateLimit-Policy"); got != "2;w=60;burst=2" {
+		t.Errorf("RateLimit-Policy = %q", got)
+	}
+
+	// other routes and other clients aren't affected
+	if rec := send(http.MethodGet, "/api/v1/diceroll/limits", "192.0.2.1"); rec.Code != http.StatusOK || rec.Header().Get("RateLimit-Limit") != "100" {
+		t.Errorf("default budget: status %d, limit %q", rec.Code, rec.Header().Get("RateLimit-Limit"))
+	}
+	if rec := send(http.MethodPost, "/diceroll", "192.0.2.2"); rec.Code != http.StatusOK {
+		t.Errorf("another client: status %d, want 200", rec.Code)
+	}
+	// but the same IPv6 /64 is the same client
+	send(http.MethodPost, "/diceroll", "2001:db8::1")
+	send(http.MethodPost, "/diceroll", "2001:db8::2")
+	if rec := send(http.MethodPost, "/diceroll", "2001:db8::3"); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("same /64: status %d, want 429", rec.Code)
+	}
+}
+
+func TestNewLimiterRejectsBadBudgets(t *testing.T) {
+	_, err := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), ratelimit.Config{
+		Budgets: []ratelimit.Budget{
+			{Pattern: "POST /diceroll", Limit: ratelimit.Limit{}},
+			{Pattern: "BAD PATTERN HERE /x", Limit: ratelimit.Limit{Requests: 1, Per: time.Second}},
+		},
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+// keyAuthenticator only accepts goodKey
+type keyAuthenticator struct{}
+
+const goodKey = domain.APIKeyPrefix + "good"
+
+func (keyAuthenticator) AuthenticateAPIKey(_ context.Context, secret string) (domain.Principal, error) {
+	if secret != goodKey {
+		return domain.Principal{}, errkind.New(errkind.Unauthenticated, "invalid API key")
+	}
+	return domain.NewPrincipal("key-1", domain.APIKeyIssuer, domain.AuthMethodAPIKey, domain.RoleReader)
+}
+
+func (keyAuthenticator) AuthenticateToken(context.Context, string) (domain.Principal, error) {
+	return domain.Principal{}, errkind.New(errkind.Unauthenticated, "invalid bearer token")
+}
+
+func TestLimiterAuthFailures(t *testing.T) {
+	limiter, err := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), ratelimit.Config{
+		Default:      ratelimit.Limit{Requests: 100, Per: time.Minute},
+		AuthFailures: ratelimit.Limit{Requests: 3, Per: time.Minute},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := stdlibapiadapter.Chain(
+		limiter.AuthFailures(),
+		stdlibapiadapter.Authenticate(keyAuthenticator{}),
+		limiter.Middleware(),
+	)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	send := func(ip, key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/diceroll/limits", nil)
+		req.RemoteAddr = ip + ":1234"
+		if key != "" {
+			req.Header.Set(stdlibapiadapter.APIKeyHeader, key)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// good keys cost nothing
+	for range 5 {
+		if rec := send("192.0.2.1", goodKey); rec.Code != http.StatusOK {
+			t.Fatalf("good key: status %d, want 200", rec.Code)
+		}
+	}
+
+	for i := range 3 {
+		if rec := send("192.0.2.1", domain.APIKeyPrefix+"guess"+strconv.Itoa(i)); rec.Code != http.StatusUnauthorized {
+			t.Fatalf("bad key %d: status %d, want 401", i, rec.Code)
+		}
+	}
+	rec := send("192.0.2.1", domain.APIKeyPrefix+"guess3")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("bad key once the budget is spent: status %d, want 429", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "20" {
+		t.Errorf("Retry-After = %q, want 20", got)
+	}
+
+	// the IP can't check any credentials until it refills, but can still call anonymously, and other IPs aren't affected
+	if rec := send("192.0.2.1", goodKey); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("good key from the blocked IP: status %d, want 429", rec.Code)
+	}
+	if rec := send("192.0.2.1", ""); rec.Code != http.StatusOK {
+		t.Errorf("no credentials from the blocked IP: status %d, want 200", rec.Code)
+	}
+	if rec := send("192.0.2.2", domain.APIKeyPrefix+"guess"); rec.Code != http.StatusUnauthorized {
+		t.Errorf("bad key from another IP: status %d, want 401", rec.Code)
+	}
+}
@@ -0,0 +1,79 @@
+// Package ratelimit throttles inbound requests with a token bucket per client and route budget.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Limit is a token bucket: Burst tokens at most, refilled at Requests per Per. A request takes one token.
+type Limit struct {
+	Requests int
+	Per      time.Duration
+	Burst    int // the bucket's size, Requests when zero
+}
+
+// Capacity is the size of the bucket
+func (l Limit) Capacity() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return l.Requests
+}
+
+// interval is how long one token takes to come back
+func (l Limit) interval() time.Duration {
+	return l.Per / time.Duration(l.Requests)
+}
+
+func (l Limit) String() string {
+	return fmt.Sprintf("%d/%s burst %d", l.Requests, l.Per, l.Capacity())
+}
+
+// Result is the state of a bucket after a Take
+type Result struct {
+	Allowed    bool
+	Remaining  int           // whole tokens left
+	RetryAfter time.Duration // until the next token, zero when Allowed
+	ResetAfter time.Duration // until the bucket is full again
+}
+
+// Store keeps the buckets. Take must refill and take atomically so a store shared by several instances (Redis, a DB...) can't let two requests spend the same token.
+type Store interface {
+	// Take refills key's bucket for the time elapsed since it was last used and takes a token from it if there is one
+	Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error)
+	// Peek returns what Take would without taking the token or changing the bucket
+	Peek(ctx context.Context, key string, limit Limit, now time.Time) (Result, error)
+}
+
+// bucket is the token bucket state, tokens are fractional so slow refills aren't lost to rounding
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// take is the token bucket algorithm, shared by the stores that hold their buckets in memory
+func (b *bucket) take(limit Limit, now time.Time) Result {
+	capacity := float64(limit.Capacity())
+	perToken := limit.interval()
+
+	if b.last.IsZero() {
+		b.tokens = capacity
+	} else if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(capacity, b.tokens+float64(elapsed)/float64(perToken))
+	}
+	b.last = now
+
+	res := Result{Allowed: b.tokens >= 1}
+	if res.Allowed {
+		b.tokens--
+	} else {
+		res.RetryAfter = time.Duration((1 - b.tokens) * float64(perToken))
+	}
+	res.Remaining = int(b.tokens)
+	res.ResetAfter = time.Duration((capacity - b.tokens) * float64(perToken))
+
+	return res
+}
@@ -1,16 +1,81 @@
 package stdlibapiadapter
 
 import (
+	"louder/pkg/logging"
 	"net/http"
 )
 
 // NewRouter now takes a slice of Resource
-func NewRouter(resources ...Resource) *http.ServeMux {
+func NewRouter(resources ...Resource) *Router {
 	mux := http.NewServeMux()
 
 	for _, r := range resources {
 		r.RegisterRoutes(mux)
 	}
 
-	return mux
+	return &Router{mux: mux}
+}
+
+// Router answers requests that match no pattern with a problem response instead of the mux's plain text 404 / 405
+type Router struct {
+	mux *http.ServeMux
+}
+
+// Pattern returns the pattern r matches, "" when it matches none. Middlewares run before the mux sets r.Pattern, this lets them know the route anyway.
+func (rt *Router) Pattern(r *http.Request) string {
+	_, pattern := rt.mux.Handler(r)
+	return pattern
+}
+
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	handler, pattern := rt.mux.Handler(r)
+	if pattern != "" {
+		rt.mux.ServeHTTP(w, r)
+		return
+	}
+
+	// no route matched, let the mux's own handler work out the status (and Allow header for a 405) without sending its body
+	uw := &unmatchedWriter{header: make(http.Header)}
+	handler.ServeHTTP(uw, r)
+
+	if allow := uw.header.Get("Allow"); allow != "" {
+		w.Header().Set("Allow", allow)
+	}
+	if uw.status >= 300 && uw.status < 400 {
+		// redirects like /path/ -> /path are fine as they are
+		for key, values := range uw.header {
+			w.Header()[key] = values
+		}
+		w.WriteHeader(uw.status)
+		return
+	}
+
+	status := uw.status
+	if status == 0 {
+		status = http.StatusNotFound
+	}
+	RespondWithProblem(w, Problem{Status: status, Instance: r.URL.Path, RequestID: logging.RequestID(r.Context())})
+}
+
+// unmatchedWriter keeps the headers and status the mux's fallback handler writes and drops its body
+type unmatchedWriter struct {
+	header http.Header
+	status int
+}
+
+func (uw *unmatchedWriter) Header() http.Header {
+	return uw.header
+}
+
+func (uw *unmatchedWriter) WriteHeader(code int) {
+	if uw.status == 0 {
+		uw.status = code
+	}
+}
+
+func (uw *unmatchedWriter) Write(b []byte) (int, error) {
+	if uw.status == 0 {
+		uw.status = http.StatusOK
+	}
+	return len(b), nil
 }
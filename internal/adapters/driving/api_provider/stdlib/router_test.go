@@ -0,0 +1,42 @@
+package stdlibapiadapter_test
+
+import (
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRouterUnmatched(t *testing.T) {
+	router := stdlibapiadapter.NewRouter(testResource{pattern: "GET /person/{id}"})
+
+	tests := map[string]struct {
+		method      string
+		path        string
+		wantStatus  int
+		wantAllow   string
+		wantProblem bool
+	}{
+		"matched route":         {method: http.MethodGet, path: "/person/42", wantStatus: http.StatusOK},
+		"unknown path":          {method: http.MethodGet, path: "/nope", wantStatus: http.StatusNotFound, wantProblem: true},
+		"wrong method":          {method: http.MethodDelete, path: "/person/42", wantStatus: http.StatusMethodNotAllowed, wantAllow: "GET, HEAD", wantProblem: true},
+		"unclean path redirect": {method: http.MethodGet, path: "/person/../person/42", wantStatus: http.StatusTemporaryRedirect},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.path, nil))
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tc.wantStatus)
+			}
+			if got := rec.Header().Get("Allow"); got != tc.wantAllow {
+				t.Errorf("Allow = %q, want %q", got, tc.wantAllow)
+			}
+			if got := rec.Header().Get("Content-Type") == "application/problem+json"; got != tc.wantProblem {
+				t.Errorf("problem response = %v, want %v", got, tc.wantProblem)
+			}
+		})
+	}
+}
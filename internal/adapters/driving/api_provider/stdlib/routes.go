@@ -4,5 +4,42 @@ import "net/http"
 
 // Resource is an interface that a feature handler (like personadapter) must implement so the main router can register its routes.
 type Resource interface {
-	RegisterRoutes(mux *http.ServeMux)
+	RegisterRoutes(mux Routes)
+}
+
+// Routes is what a Resource registers its handlers on. *http.ServeMux satisfies it, so does the wrapper WithMiddleware hands out.
+type Routes interface {
+	Handle(pattern string, handler http.Handler)
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+}
+
+// WithMiddleware returns a Resource whose handlers are all wrapped in the given middlewares, in Chain order. Global middlewares still run first as they wrap the whole router.
+func WithMiddleware(resource Resource, middlewares ...Middleware) Resource {
+	return &resourceWithMiddleware{
+		resource:   resource,
+		middleware: Chain(middlewares...),
+	}
+}
+
+type resourceWithMiddleware struct {
+	resource   Resource
+	middleware Middleware
+}
+
+func (rm *resourceWithMiddleware) RegisterRoutes(mux Routes) {
+	rm.resource.RegisterRoutes(&wrappedRoutes{parent: mux, middleware: rm.middleware})
+}
+
+// wrappedRoutes wraps every handler in middleware before passing it on to the parent
+type wrappedRoutes struct {
+	parent     Routes
+	middleware Middleware
+}
+
+func (wr *wrappedRoutes) Handle(pattern string, handler http.Handler) {
+	wr.parent.Handle(pattern, wr.middleware(handler))
+}
+
+func (wr *wrappedRoutes) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	wr.Handle(pattern, http.HandlerFunc(handler))
 }
@@ -0,0 +1,74 @@
+package stdlibapiadapter
+
+import (
+	"fmt"
+	"louder/internal/core/domain"
+	"louder/internal/core/errkind"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ParseSampleRequest reads the query parameters shared by every random sampling endpoint:
+//
+//	count  - how many distinct items to draw (default 1)
+//	seed   - optional, the same seed over the same data returns the same items
+//	weight - optional and repeatable, "<id>:<weight>" gives an item a relative weight (default 1, 0 excludes it)
+//
+// Validation errors are returned so they can be reported alongside any endpoint specific ones.
+func ParseSampleRequest(params url.Values) (domain.SampleRequest, []error) {
+	validationErrors := make([]error, 0)
+
+	count := 1
+	if countParam := strings.TrimSpace(params.Get("count")); countParam != "" {
+		val, err := strconv.Atoi(countParam)
+		if err != nil {
+			validationErrors = append(validationErrors, errkind.NewField("count", "must be a valid integer"))
+		} else {
+			count = val
+		}
+	}
+
+	var seed uint64
+	seedParam := strings.TrimSpace(params.Get("seed"))
+	if seedParam != "" {
+		val, err := strconv.ParseUint(seedParam, 10, 64)
+		if err != nil {
+			validationErrors = append(validationErrors, errkind.NewField("seed", "must be a non-negative integer"))
+		} else {
+			seed = val
+		}
+	}
+
+	weights := make(map[string]float64)
+	for _, weightParam := range params["weight"] {
+		// split on the last colon so keys are free to contain one
+		idx := strings.LastIndex(weightParam, ":")
+		if idx <= 0 {
+			validationErrors = append(validationErrors, errkind.NewField("weight", fmt.Sprintf("expected <id>:<weight>, got '%s'", weightParam)))
+			continue
+		}
+
+		w, err := strconv.ParseFloat(weightParam[idx+1:], 64)
+		if err != nil {
+			validationErrors = append(validationErrors, errkind.NewField("weight", fmt.Sprintf("'%s' is not a number", weightParam[idx+1:])))
+			continue
+		}
+		weights[strings.TrimSpace(weightParam[:idx])] = w
+	}
+
+	if len(validationErrors) > 0 {
+		return domain.SampleRequest{}, validationErrors
+	}
+
+	req, err := domain.NewSampleRequest(count, weights)
+	if err != nil {
+		return domain.SampleRequest{}, []error{err}
+	}
+
+	if seedParam != "" {
+		req = req.WithSeed(seed)
+	}
+
+	return req, nil
+}
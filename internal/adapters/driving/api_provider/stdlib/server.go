@@ -2,7 +2,7 @@ package stdlibapiadapter
 
 import (
 	"context"
-	"log"
+	"log/slog"
 	"net/http"
 	"time"
 )
@@ -28,7 +28,7 @@ func NewStdAPIServer(addr string, router http.Handler) *StdAPIServer {
 
 // ListenAndServe starts the HTTP server. It's a blocking call.
 func (s *StdAPIServer) ListenAndServe() error {
-	log.Printf("Starting net/http server on %s", s.httpServer.Addr)
+	slog.Info("starting net/http server", "addr", s.httpServer.Addr)
 	if err := s.httpServer.ListenAndServe(); err != nil {
 		return err // return any error that is not graceful shutdown http.ErrServerClosed
 	}
@@ -38,6 +38,6 @@ func (s *StdAPIServer) ListenAndServe() error {
 
 // Shutdown gracefully shuts down the HTTP server
 func (s *StdAPIServer) Shutdown(ctx context.Context) error {
-	log.Printf("Shutting down net/http gracefully...")
+	slog.Info("shutting down net/http gracefully")
 	return s.httpServer.Shutdown(ctx)
 }
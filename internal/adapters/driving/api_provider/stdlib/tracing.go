@@ -0,0 +1,49 @@
+package stdlibapiadapter
+
+import (
+	"louder/pkg/logging"
+	"louder/pkg/tracing"
+	"net/http"
+)
+
+// Trace starts a server span for every request, continuing the caller's trace when it sent a traceparent. The span is named after the route pattern so requests to the same route group together, and the trace ID tags the request logger to find a slow request's logs.
+func Trace(routes PatternMatcher) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// unmatched requests are only named after their method, like metrics their paths would make names without end
+			name, route := r.Method, metricsRoute(routes.Pattern(r))
+			if route != unmatchedRoute {
+				name += " " + route
+			}
+
+			ctx := tracing.Extract(r.Context(), r.Header)
+			ctx, span := tracing.StartWithKind(ctx, tracing.KindServer, name,
+				tracing.String("http.request.method", r.Method),
+				tracing.String("url.path", r.URL.Path),
+				tracing.String("client.address", ClientIP(r)),
+				tracing.String("request.id", logging.RequestID(r.Context())),
+			)
+			defer span.End()
+			if route != unmatchedRoute {
+				span.SetAttributes(tracing.String("http.route", route))
+			}
+
+			if sc := span.SpanContext(); sc.IsValid() {
+				ctx = logging.WithLogger(ctx, logging.FromContext(ctx).With("trace_id", sc.TraceID.String()))
+			}
+
+			sw := &statusWriter{ResponseWriter: w}
+			next.ServeHTTP(sw, r.WithContext(ctx))
+
+			status := sw.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			span.SetAttributes(tracing.Int("http.response.status_code", status))
+			// client errors are the client's problem, only server errors fail the span
+			if status >= 500 {
+				span.SetStatus(tracing.StatusError, http.StatusText(status))
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package stdlibapiadapter_test
+
+import (
+	"context"
+	stdlibapiadapter "louder/internal/adapters/driving/api_provider/stdlib"
+	"louder/pkg/tracing"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// spanRecorder keeps exported spans for the tests to look at
+type spanRecorder struct {
+	spans []tracing.SpanData
+}
+
+func (sr *spanRecorder) Export(_ context.Context, batch tracing.Batch) error {
+	sr.spans = append(sr.spans, batch.Spans...)
+	return nil
+}
+
+func (sr *spanRecorder) Shutdown(context.Context) error { return nil }
+
+func TestTraceServerSpans(t *testing.T) {
+	const parent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	tests := map[string]struct {
+		path        string
+		traceparent string
+		status      int
+		wantName    string
+		wantStatus  tracing.StatusCode
+	}{
+		"continues the caller's trace": {path: "/person/42", traceparent: parent, status: http.StatusOK, wantName: "GET /person/{id}"},
+		"client errors don't fail":     {path: "/person/42", status: http.StatusNotFound, wantName: "GET /person/{id}"},
+		"server errors fail":           {path: "/person/42", status: http.StatusBadGateway, wantName: "GET /person/{id}", wantStatus: tracing.StatusError},
+		"unmatched named by method":    {path: "/nope", status: http.StatusNotFound, wantName: "GET"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			recorder := &spanRecorder{}
+			provider := tracing.NewProvider(recorder, tracing.Options{SampleRatio: 1})
+			tracing.SetProvider(provider)
+			defer tracing.SetProvider(nil)
+
+			router := stdlibapiadapter.NewRouter(testResource{pattern: "GET /person/{id}"})
+			var inner tracing.SpanContext
+			handler := stdlibapiadapter.Trace(router)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				inner = tracing.SpanContextFromContext(r.Context())
+				w.WriteHeader(tc.status)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			if tc.traceparent != "" {
+				req.Header.Set(tracing.TraceparentHeader, tc.traceparent)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+			provider.Shutdown(context.Background())
+
+			if len(recorder.spans) != 1 {
+				t.Fatalf("exported %d spans, want 1", len(recorder.spans))
+			}
+			span := recorder.spans[0]
+			if span.Name != tc.wantName || span.Kind != tracing.KindServer || span.Status != tc.wantStatus {
+				t.Errorf("span %q kind %v status %v, want %q server %v", span.Name, span.Kind, span.Status, tc.wantName, tc.wantStatus)
+			}
+			if span.SpanContext != inner {
+				t.Errorf("the handler should run within the server span")
+			}
+			if tc.traceparent != "" && (span.SpanContext.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || span.Parent.String() != "00f067aa0ba902b7") {
+				t.Errorf("span should be a child of the caller's, got trace %s parent %s", span.SpanContext.TraceID, span.Parent)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package domain
+
+import (
+	"louder/internal/core/errkind"
+	"reflect"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// AuditAction is the kind of change an audit entry records
+type AuditAction string
+
+const (
+	AuditCreate AuditAction = "create"
+	AuditUpdate AuditAction = "update"
+	AuditDelete AuditAction = "delete"
+)
+
+// AuditEntityType names what was changed
+type AuditEntityType string
+
+// messages are read only for now, so there is nothing of theirs to audit
+const (
+	AuditPerson   AuditEntityType = "person"
+	AuditCountry  AuditEntityType = "country"
+	AuditCurrency AuditEntityType = "currency"
+)
+
+// SystemActor is the actor of changes made without a caller, by the CLI tools or background jobs
+const SystemActor = "system"
+
+// AuditDeletedAtField is the snapshot field of soft deletable entities. Setting it is audited as a delete and clearing it, a restore, as an update.
+const AuditDeletedAtField = "deleted_at"
+
+// AuditRedacted replaces values the audit trail must no longer hold, such as the personal data of an erased person
+const AuditRedacted = "[erased]"
+
+// AuditSnapshot is the state of an entity as the audit trail sees it, field name to value. Values must be comparable with reflect.DeepEqual and encode to JSON.
+type AuditSnapshot map[string]any
+
+// FieldChange is a field's value before and after a change, nil when it didn't exist
+type FieldChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// AuditEntry records who changed an entity, how and when
+type AuditEntry struct {
+	id         string // version 7 UUID
+	occurredAt time.Time
+	actor      string // Principal.String() or SystemActor
+	action     AuditAction
+	entityType AuditEntityType
+	entityID   string
+	changes    map[string]FieldChange
+	requestID  string // empty outside of a request
+}
+
+// AuditFilter narrows down queries over the audit trail, empty fields are ignored. From is inclusive and To exclusive.
+type AuditFilter struct {
+	EntityType AuditEntityType
+	EntityID   string
+	Actor      string
+	From       time.Time
+	To         time.Time
+	Limit      int
+}
+
+var ErrAuditEntityMissing = errkind.New(errkind.Invalid, "an audit entry needs an entity type and ID")
+
+// DiffSnapshots returns the fields that differ between before and after, a missing field counts as nil. A nil before is a creation and a nil after a deletion, every field that is set shows up then.
+func DiffSnapshots(before, after AuditSnapshot) map[string]FieldChange {
+	changes := make(map[string]FieldChange)
+	for field, was := range before {
+		if !reflect.DeepEqual(was, after[field]) {
+			changes[field] = FieldChange{Before: was, After: after[field]}
+		}
+	}
+	for field, is := range after {
+		if _, ok := before[field]; !ok && is != nil {
+			changes[field] = FieldChange{After: is}
+		}
+	}
+	return changes
+}
+
+// NewAuditEntry records the change from before to after, the action follows from which of them is nil or, for a soft delete, from AuditDeletedAtField being set. It returns nil when nothing changed.
+func NewAuditEntry(entityType AuditEntityType, entityID, actor, requestID string, before, after AuditSnapshot, now time.Time) (*AuditEntry, error) {
+	if entityType == "" || entityID == "" {
+		return nil, ErrAuditEntityMissing
+	}
+
+	action := AuditUpdate
+	switch {
+	case before == nil && after == nil:
+		return nil, nil
+	case before == nil:
+		action = AuditCreate
+	case after == nil:
+		action = AuditDelete
+	case before[AuditDeletedAtField] == nil && after[AuditDeletedAtField] != nil:
+		action = AuditDelete
+	}
+
+	changes := DiffSnapshots(before, after)
+	if action == AuditUpdate && len(changes) == 0 {
+		return nil, nil
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+
+	if actor == "" {
+		actor = SystemActor
+	}
+
+	return &AuditEntry{
+		id:         id.String(),
+		occurredAt: now.UTC(),
+		actor:      actor,
+		action:     action,
+		entityType: entityType,
+		entityID:   entityID,
+		changes:    changes,
+		requestID:  requestID,
+	}, nil
+}
+
+// HydrateAuditEntry rebuilds an AuditEntry from stored data, no validation is done
+func HydrateAuditEntry(id string, occurredAt time.Time, actor string, action AuditAction, entityType AuditEntityType, entityID string, changes map[string]FieldChange, requestID string) *AuditEntry {
+	return &AuditEntry{
+		id:         id,
+		occurredAt: occurredAt,
+		actor:      actor,
+		action:     action,
+		entityType: entityType,
+		entityID:   entityID,
+		changes:    changes,
+		requestID:  requestID,
+	}
+}
+
+func (e *AuditEntry) ID() string {
+	return e.id
+}
+
+func (e *AuditEntry) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+func (e *AuditEntry) Actor() string {
+	return e.actor
+}
+
+func (e *AuditEntry) Action() AuditAction {
+	return e.action
+}
+
+func (e *AuditEntry) EntityType() AuditEntityType {
+	return e.entityType
+}
+
+func (e *AuditEntry) EntityID() string {
+	return e.entityID
+}
+
+// Changes maps each changed field to its values before and after
+func (e *AuditEntry) Changes() map[string]FieldChange {
+	return e.changes
+}
+
+func (e *AuditEntry) RequestID() string {
+	return e.requestID
+}
+
+// ParseAuditEntityType accepts the entity types that are audited
+func ParseAuditEntityType(s string) (AuditEntityType, error) {
+	switch t := AuditEntityType(s); t {
+	case AuditPerson, AuditCountry, AuditCurrency:
+		return t, nil
+	}
+	return "", errkind.NewField("entity_type", "must be one of person, country or currency")
+}
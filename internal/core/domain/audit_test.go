@@ -0,0 +1,62 @@
+package domain_test
+
+import (
+	"louder/internal/core/domain"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestNewAuditEntry(t *testing.T) {
+	eur := domain.AuditSnapshot{"name": "Euro", "currencies": []string{"EUR"}, "region": nil}
+	renamed := domain.AuditSnapshot{"name": "Euros", "currencies": []string{"EUR"}}
+	jane := domain.AuditSnapshot{"name": "Jane", domain.AuditDeletedAtField: nil}
+	janeDeleted := domain.AuditSnapshot{"name": "Jane", domain.AuditDeletedAtField: "2026-10-19T12:00:00Z"}
+	janeDeletedLater := domain.AuditSnapshot{"name": "Jane", domain.AuditDeletedAtField: "2026-10-20T12:00:00Z"}
+	janeErased := domain.AuditSnapshot{"name": domain.AuditRedacted, domain.AuditDeletedAtField: "2026-10-19T12:00:00Z"}
+
+	tests := map[string]struct {
+		before, after domain.AuditSnapshot
+		wantAction    domain.AuditAction // empty when no entry is expected
+		wantFields    []string
+	}{
+		"creation records every field": {after: eur, wantAction: domain.AuditCreate, wantFields: []string{"currencies", "name"}},
+		"update records what changed":  {before: eur, after: renamed, wantAction: domain.AuditUpdate, wantFields: []string{"name"}},
+		"deletion records every field": {before: eur, wantAction: domain.AuditDelete, wantFields: []string{"currencies", "name"}},
+		"no change, no entry":          {before: eur, after: domain.AuditSnapshot{"name": "Euro", "currencies": []string{"EUR"}}},
+		"soft delete is a deletion":    {before: jane, after: janeDeleted, wantAction: domain.AuditDelete, wantFields: []string{"deleted_at"}},
+		"restore is an update":         {before: janeDeleted, after: jane, wantAction: domain.AuditUpdate, wantFields: []string{"deleted_at"}},
+		"moving the deletion time":     {before: janeDeleted, after: janeDeletedLater, wantAction: domain.AuditUpdate, wantFields: []string{"deleted_at"}},
+		"erasing someone active":       {before: jane, after: janeErased, wantAction: domain.AuditDelete, wantFields: []string{"deleted_at", "name"}},
+		"erasing someone deleted":      {before: janeDeleted, after: janeErased, wantAction: domain.AuditUpdate, wantFields: []string{"name"}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			entry, err := domain.NewAuditEntry(domain.AuditCurrency, "EUR", "", "req-1", tc.before, tc.after, time.Now())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.wantAction == "" {
+				if entry != nil {
+					t.Fatalf("expected no entry, got %s of %v", entry.Action(), entry.Changes())
+				}
+				return
+			}
+			if entry == nil {
+				t.Fatal("expected an entry")
+			}
+
+			if entry.Action() != tc.wantAction {
+				t.Errorf("action = %s, want %s", entry.Action(), tc.wantAction)
+			}
+			if got := slices.Sorted(maps.Keys(entry.Changes())); !slices.Equal(got, tc.wantFields) {
+				t.Errorf("changed fields = %v, want %v", got, tc.wantFields)
+			}
+			if entry.Actor() != domain.SystemActor {
+				t.Errorf("actor = %q, want %q when nobody is calling", entry.Actor(), domain.SystemActor)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"louder/internal/core/errkind"
+	"strings"
+)
+
+// CityID is GeoDB's numeric ID of a city, it's kept so a sync updates the same row
+type CityID int64
+
+var (
+	ErrCityIDMissing      = errkind.New(errkind.Invalid, "a city needs a positive ID")
+	ErrCityNameMissing    = errkind.New(errkind.Invalid, "a city needs a name")
+	ErrCityCountryMissing = errkind.New(errkind.Invalid, "a city needs a country")
+	ErrCityCoordinates    = errkind.New(errkind.Invalid, "city coordinates out of range")
+	ErrRegionCodeMissing  = errkind.New(errkind.Invalid, "a region needs a code")
+	ErrRegionNameMissing  = errkind.New(errkind.Invalid, "a region needs a name")
+)
+
+// City is where a person can live, as GeoDB lists it
+type City struct {
+	id          CityID
+	name        string
+	countryCode CountryCode
+	regionCode  string // optional, code of the Region within the country
+	latitude    float64
+	longitude   float64
+	population  int
+	wikidataid  WikiCode
+}
+
+// NewCity validates a City, the region and wikidataid are optional
+func NewCity(id CityID, name string, countryCode CountryCode, regionCode string, latitude, longitude float64, population int, wikidataid WikiCode) (*City, error) {
+	if id <= 0 {
+		return nil, ErrCityIDMissing
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrCityNameMissing
+	}
+	if countryCode == "" {
+		return nil, ErrCityCountryMissing
+	}
+	if latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+		return nil, ErrCityCoordinates
+	}
+
+	return HydrateCity(id, name, countryCode, strings.ToUpper(regionCode), latitude, longitude, max(population, 0), wikidataid), nil
+}
+
+// HydrateCity rebuilds a City from stored data, no validation is done
+func HydrateCity(id CityID, name string, countryCode CountryCode, regionCode string, latitude, longitude float64, population int, wikidataid WikiCode) *City {
+	return &City{
+		id:          id,
+		name:        name,
+		countryCode: countryCode,
+		regionCode:  regionCode,
+		latitude:    latitude,
+		longitude:   longitude,
+		population:  population,
+		wikidataid:  wikidataid,
+	}
+}
+
+func (c *City) ID() CityID {
+	return c.id
+}
+
+func (c *City) Name() string {
+	return c.name
+}
+
+func (c *City) CountryCode() CountryCode {
+	return c.countryCode
+}
+
+// RegionCode is the code of the Region the city is in, empty if unknown
+func (c *City) RegionCode() string {
+	return c.regionCode
+}
+
+func (c *City) Latitude() float64 {
+	return c.latitude
+}
+
+func (c *City) Longitude() float64 {
+	return c.longitude
+}
+
+// Population is 0 when GeoDB doesn't know it
+func (c *City) Population() int {
+	return c.population
+}
+
+func (c *City) WikiId() WikiCode {
+	return c.wikidataid
+}
+
+// Region is a country's first level subdivision, a state or a province
+type Region struct {
+	countryCode CountryCode
+	code        string // unique within the country, ISO 3166-2 without the country part when there is one
+	name        string
+	wikidataid  WikiCode
+}
+
+// NewRegion validates a Region, wikidataid is optional
+func NewRegion(countryCode CountryCode, code, name string, wikidataid WikiCode) (*Region, error) {
+	if countryCode == "" {
+		return nil, ErrCityCountryMissing
+	}
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return nil, ErrRegionCodeMissing
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrRegionNameMissing
+	}
+
+	return &Region{
+		countryCode: countryCode,
+		code:        code,
+		name:        name,
+		wikidataid:  wikidataid,
+	}, nil
+}
+
+func (r *Region) CountryCode() CountryCode {
+	return r.countryCode
+}
+
+func (r *Region) Code() string {
+	return r.code
+}
+
+func (r *Region) Name() string {
+	return r.name
+}
+
+func (r *Region) WikiId() WikiCode {
+	return r.wikidataid
+}
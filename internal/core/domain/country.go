@@ -1,8 +1,9 @@
 package domain
 
 import (
-	"errors"
 	"fmt"
+	"louder/internal/core/errkind"
+	"slices"
 	"strings"
 )
 
@@ -13,12 +14,19 @@ type WikiCode string
 type Country struct {
 	code       CountryCode
 	name       string
+	region     string // optional, e.g. "Europe"
 	currencies []Currency
 	wikidataid WikiCode
 }
 
+// CountryFilter narrows down queries over countries, empty fields are ignored
+type CountryFilter struct {
+	Region       string
+	CurrencyCode CurrencyCode
+}
+
 // NewCountry creates a Country object
-func NewCountry(code CountryCode, name string, currs []Currency, wikidataid WikiCode) (*Country, error) {
+func NewCountry(code CountryCode, name, region string, currs []Currency, wikidataid WikiCode) (*Country, error) {
 	if code.String() == "" {
 		return nil, fmt.Errorf("error country code cannot be empty")
 	}
@@ -29,7 +37,7 @@ func NewCountry(code CountryCode, name string, currs []Currency, wikidataid Wiki
 
 	var currenciesCopy []Currency
 	if currs != nil {
-		currenciesCopy = make([]Currency, 0, len(currs))
+		currenciesCopy = make([]Currency, len(currs))
 		copy(currenciesCopy, currs)
 	} else {
 		currenciesCopy = []Currency{}
@@ -38,6 +46,7 @@ func NewCountry(code CountryCode, name string, currs []Currency, wikidataid Wiki
 	return &Country{
 		code:       code,
 		name:       name,
+		region:     region,
 		currencies: currenciesCopy,
 		wikidataid: wikidataid,
 	}, nil
@@ -53,6 +62,11 @@ func (c Country) Name() string {
 	return strings.ToTitle(c.name)
 }
 
+// Region returns the region the Country belongs to, empty if unknown
+func (c Country) Region() string {
+	return c.region
+}
+
 // Code returns the 2 digit Country code as a string
 func (c Country) Code() CountryCode {
 	return c.code
@@ -69,15 +83,31 @@ func (cc CountryCode) String() string {
 }
 
 func NewCountryCode(cc string) (CountryCode, error) {
-	if cc == "" || len(cc) != 3 {
-		return "", errors.New("error creating country code: must be a 3 letter")
+	if cc == "" || len(cc) != 2 {
+		return "", errkind.New(errkind.Invalid, "error creating country code: must be a 2 letter")
 	}
 	return CountryCode(strings.ToUpper(cc)), nil
 }
 
 func NewWikiCode(wc string) (WikiCode, error) {
 	if wc == "" {
-		return "", errors.New("error createing wikiid: must not be empty string")
+		return "", errkind.New(errkind.Invalid, "error createing wikiid: must not be empty string")
 	}
 	return WikiCode(strings.ToUpper(wc)), nil
 }
+
+// AuditSnapshot is the Country as the audit trail records it, its currencies by code
+func (c Country) AuditSnapshot() AuditSnapshot {
+	currencies := make([]string, 0, len(c.currencies))
+	for _, cur := range c.currencies {
+		currencies = append(currencies, cur.code.String())
+	}
+	slices.Sort(currencies)
+
+	return AuditSnapshot{
+		"name":        c.name,
+		"region":      c.region,
+		"wikidata_id": string(c.wikidataid),
+		"currencies":  currencies,
+	}
+}
@@ -1,7 +1,7 @@
 package domain
 
 import (
-	"errors"
+	"louder/internal/core/errkind"
 	"strings"
 )
 
@@ -12,6 +12,11 @@ type Currency struct {
 	name string
 }
 
+// CurrencyFilter narrows down queries over currencies, empty fields are ignored
+type CurrencyFilter struct {
+	CountryCode CountryCode // only currencies used by this country
+}
+
 func NewCurrency(code CurrencyCode, name string) (*Currency, error) {
 
 	return &Currency{
@@ -34,7 +39,12 @@ func (cc CurrencyCode) String() string {
 
 func NewCurrencyCode(cc string) (CurrencyCode, error) {
 	if cc == "" || len(cc) != 3 {
-		return "", errors.New("error creating currency code: must be 3 characters long")
+		return "", errkind.New(errkind.Invalid, "error creating currency code: must be 3 characters long")
 	}
 	return CurrencyCode(strings.ToUpper(cc)), nil
 }
+
+// AuditSnapshot is the Currency as the audit trail records it
+func (c *Currency) AuditSnapshot() AuditSnapshot {
+	return AuditSnapshot{"name": c.name}
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"fmt"
+	"louder/internal/core/errkind"
+	"louder/pkg/types"
+	"time"
+)
+
+// MaxPlausibleAge is the oldest a person can be, anything older is almost certainly a typo
+const MaxPlausibleAge = 130
+
+var (
+	ErrDOBMissing     = errkind.NewField("dob", "a date of birth is required")
+	ErrDOBInFuture    = errkind.NewField("dob", "cannot be in the future")
+	ErrDOBImplausible = errkind.NewField("dob", "out of plausible age range")
+)
+
+// NewDOB validates a date of birth against now and returns it normalised to UTC
+func NewDOB(t time.Time, now time.Time) (types.UTCTime, error) {
+	if t.IsZero() {
+		return types.UTCTime{}, ErrDOBMissing
+	}
+
+	dob := types.NewUTCTime(t)
+	if dob.After(now) {
+		return types.UTCTime{}, fmt.Errorf("%w: got %s", ErrDOBInFuture, dob.Format(time.RFC3339))
+	}
+
+	if dob.Before(now.AddDate(-MaxPlausibleAge, 0, 0)) {
+		return types.UTCTime{}, fmt.Errorf("%w: must be at most %d years ago, got %s", ErrDOBImplausible, MaxPlausibleAge, dob.Format(time.RFC3339))
+	}
+
+	return dob, nil
+}
+
+// AgeAt returns the number of full years between dob and now, both compared in UTC
+func AgeAt(dob types.UTCTime, now time.Time) int {
+	if dob.IsZero() {
+		return 0
+	}
+
+	born := dob.UTC()
+	now = now.UTC()
+
+	age := now.Year() - born.Year()
+	// birthday not reached yet this year
+	if now.Month() < born.Month() || (now.Month() == born.Month() && now.Day() < born.Day()) {
+		age--
+	}
+
+	return max(age, 0)
+}
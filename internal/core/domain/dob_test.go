@@ -0,0 +1,83 @@
+package domain_test
+
+import (
+	"errors"
+	"louder/internal/core/domain"
+	"louder/pkg/types"
+	"testing"
+	"time"
+)
+
+func TestNewDOB(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	lisbonSummer := time.FixedZone("WEST", 3600)
+
+	tests := map[string]struct {
+		dob     time.Time
+		want    time.Time
+		wantErr error
+	}{
+		"zero":                {dob: time.Time{}, wantErr: domain.ErrDOBMissing},
+		"future":              {dob: now.Add(time.Second), wantErr: domain.ErrDOBInFuture},
+		"exactly now":         {dob: now, want: now},
+		"oldest plausible":    {dob: now.AddDate(-domain.MaxPlausibleAge, 0, 0), want: now.AddDate(-domain.MaxPlausibleAge, 0, 0)},
+		"older than the max":  {dob: now.AddDate(-domain.MaxPlausibleAge, 0, 0).Add(-time.Second), wantErr: domain.ErrDOBImplausible},
+		"non UTC":             {dob: time.Date(1990, 5, 17, 0, 30, 0, 0, lisbonSummer), want: time.Date(1990, 5, 16, 23, 30, 0, 0, time.UTC)},
+		"future only in UTC":  {dob: time.Date(2026, 10, 19, 12, 30, 0, 0, lisbonSummer), want: time.Date(2026, 10, 19, 11, 30, 0, 0, time.UTC)},
+		"past only in offset": {dob: time.Date(2026, 10, 19, 13, 30, 0, 0, lisbonSummer), wantErr: domain.ErrDOBInFuture},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := domain.NewDOB(tc.dob, now)
+
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tc.wantErr)
+				}
+				if !got.IsZero() {
+					t.Errorf("got %v with an error, want the zero value", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !got.Equal(tc.want) || got.Location() != time.UTC {
+				t.Errorf("got %v, want %v in UTC", got.Time, tc.want)
+			}
+		})
+	}
+}
+
+func TestAgeAt(t *testing.T) {
+	dob := types.NewUTCTime(time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC))
+	leapling := types.NewUTCTime(time.Date(2000, 2, 29, 0, 0, 0, 0, time.UTC))
+
+	tests := map[string]struct {
+		dob  types.UTCTime
+		now  time.Time
+		want int
+	}{
+		"day before the birthday":    {dob: dob, now: time.Date(2026, 5, 16, 23, 59, 59, 0, time.UTC), want: 35},
+		"on the birthday":            {dob: dob, now: time.Date(2026, 5, 17, 0, 0, 0, 0, time.UTC), want: 36},
+		"day after the birthday":     {dob: dob, now: time.Date(2026, 5, 18, 0, 0, 0, 0, time.UTC), want: 36},
+		"earlier month":              {dob: dob, now: time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC), want: 35},
+		"birthday reached elsewhere": {dob: dob, now: time.Date(2026, 5, 17, 1, 0, 0, 0, time.FixedZone("AEST", 10*3600)), want: 35},
+		"born today":                 {dob: dob, now: dob.Time, want: 0},
+		"zero dob":                   {dob: types.UTCTime{}, now: time.Date(2026, 5, 17, 0, 0, 0, 0, time.UTC), want: 0},
+		"feb 29, feb 28 of a common": {dob: leapling, now: time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC), want: 24},
+		"feb 29, mar 1 of a common":  {dob: leapling, now: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), want: 25},
+		"feb 29, feb 28 of a leap":   {dob: leapling, now: time.Date(2028, 2, 28, 0, 0, 0, 0, time.UTC), want: 27},
+		"feb 29, feb 29 of a leap":   {dob: leapling, now: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC), want: 28},
+		"dob after now is never < 0": {dob: dob, now: time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC), want: 0},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := domain.AgeAt(tc.dob, tc.now); got != tc.want {
+				t.Errorf("AgeAt(%v, %v) = %d, want %d", tc.dob.Time, tc.now, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"fmt"
+	"louder/internal/core/errkind"
+	"net/mail"
+	"strings"
+)
+
+// length limits from RFC 5321, the whole address must also fit the person.email column
+const (
+	maxEmailLength      = 254
+	maxEmailLocalLength = 64
+)
+
+var (
+	ErrEmailMissing = errkind.NewField("email", "an email address is required")
+	ErrEmailInvalid = errkind.NewField("email", "not a valid email address")
+)
+
+// NormaliseEmail validates the syntax of a bare email address (no display name, no angle brackets) and returns it trimmed and lowercased, which is the form stored and compared for uniqueness
+func NormaliseEmail(email string) (string, error) {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return "", ErrEmailMissing
+	}
+
+	if len(email) > maxEmailLength {
+		return "", fmt.Errorf("%w: must be at most %d characters, got %d", ErrEmailInvalid, maxEmailLength, len(email))
+	}
+
+	// ParseAddress also accepts "Name <a@b.c>", only the bare address is allowed
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Name != "" || addr.Address != email {
+		return "", fmt.Errorf("%w: %q", ErrEmailInvalid, email)
+	}
+
+	local, host, _ := strings.Cut(email, "@")
+	if len(local) > maxEmailLocalLength {
+		return "", fmt.Errorf("%w: local part must be at most %d characters", ErrEmailInvalid, maxEmailLocalLength)
+	}
+
+	// a dotless host such as "localhost" is valid syntax but never a real mailbox
+	if !strings.Contains(host, ".") || strings.HasPrefix(host, ".") || strings.HasSuffix(host, ".") {
+		return "", fmt.Errorf("%w: %q has no valid domain", ErrEmailInvalid, email)
+	}
+
+	// only Erase may hand out addresses on the erased domain, they're what marks a person as erased
+	if strings.EqualFold(host, erasedEmailDomain) {
+		return "", fmt.Errorf("%w: the %s domain is reserved", ErrEmailInvalid, erasedEmailDomain)
+	}
+
+	return strings.ToLower(email), nil
+}
@@ -0,0 +1,54 @@
+package domain_test
+
+import (
+	"errors"
+	"louder/internal/core/domain"
+	"testing"
+)
+
+func TestNormaliseEmail(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    string
+		wantErr error
+	}{
+		"lowercased and trimmed": {input: "  Jane.Doe@Example.COM ", want: "jane.doe@example.com"},
+		"plus tag kept":          {input: "jane+news@example.com", want: "jane+news@example.com"},
+		"subdomain":              {input: "a@mail.example.co.uk", want: "a@mail.example.co.uk"},
+		"empty":                  {input: "   ", wantErr: domain.ErrEmailMissing},
+		"no at sign":             {input: "jane.example.com", wantErr: domain.ErrEmailInvalid},
+		"display name":           {input: "Jane <jane@example.com>", wantErr: domain.ErrEmailInvalid},
+		"dotless domain":         {input: "jane@localhost", wantErr: domain.ErrEmailInvalid},
+		"trailing dot domain":    {input: "jane@example.", wantErr: domain.ErrEmailInvalid},
+		"two at signs":           {input: "jane@doe@example.com", wantErr: domain.ErrEmailInvalid},
+		"space in local part":    {input: "jane doe@example.com", wantErr: domain.ErrEmailInvalid},
+		"reserved erased domain": {input: "jane@erased.invalid", wantErr: domain.ErrEmailInvalid},
+		"reserved erased domain in capitals": {
+			input:   "erased-0190b5e2-7c1d-7a4e-9f00-000000000000@Erased.INVALID",
+			wantErr: domain.ErrEmailInvalid,
+		},
+		"local part too long": {
+			input:   "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa@example.com",
+			wantErr: domain.ErrEmailInvalid,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := domain.NormaliseEmail(tc.input)
+
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("NormaliseEmail(%q) error = %v, want %v", tc.input, err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormaliseEmail(%q) unexpected error: %v", tc.input, err)
+			}
+			if got != tc.want {
+				t.Errorf("NormaliseEmail(%q) = %q, want %q", tc.input, got, tc.want)
+			}
+		})
+	}
+}
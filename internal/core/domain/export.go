@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// ExportTable is one kind of data stored about a person, rows sharing the same columns. Values are strings, numbers, bools, nil or anything else that encodes to JSON.
+type ExportTable struct {
+	Name    string // also the CSV file name in ZIP exports
+	Columns []string
+	Rows    [][]any
+}
+
+// PersonExport is everything stored about a person, for subject access requests
+type PersonExport struct {
+	PersonID    PersonID
+	GeneratedAt time.Time
+	Tables      []ExportTable // in the order the exporters were registered
+}
@@ -0,0 +1,8 @@
+package domain
+
+// Page is one page of items from a paginated external source
+type Page[T any] struct {
+	Items      []T // what the source sent that could be mapped
+	NextOffset int // where the page after this one starts
+	TotalCount int // items the source has, -1 when it didn't say
+}
@@ -3,10 +3,12 @@ package domain
 import (
 	"database/sql/driver"
 	"fmt"
+	"louder/internal/core/errkind"
 	"louder/pkg/types"
 	"math/rand"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/gofrs/uuid/v5"
 )
@@ -20,13 +22,51 @@ type Person struct {
 	email     string
 	dob       types.UTCTime
 
+	birthCountry     CountryCode // optional
+	residenceCountry CountryCode // optional
+
+	deletedAt time.Time // zero unless the person was soft deleted
+
 	// TODO - implement later
 	pets             []Pet
-	birthCountry     Country
-	residentCountry  Country
 	visitedCountries []Country
 }
 
+// MaxNameLength is the most characters a first or last name can have, the person columns check it too
+const MaxNameLength = 40
+
+var (
+	ErrFirstNameMissing = errkind.NewField("first_name", "cannot be empty")
+	ErrLastNameMissing  = errkind.NewField("last_name", "cannot be empty")
+	ErrFirstNameTooLong = errkind.NewField("first_name", fmt.Sprintf("must be at most %d characters", MaxNameLength))
+	ErrLastNameTooLong  = errkind.NewField("last_name", fmt.Sprintf("must be at most %d characters", MaxNameLength))
+)
+
+// validName trims a name and checks it's neither blank nor longer than MaxNameLength characters
+func validName(name string, errMissing, errTooLong error) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errMissing
+	}
+	if n := utf8.RuneCountInString(name); n > MaxNameLength {
+		return "", fmt.Errorf("%w: got %d", errTooLong, n)
+	}
+	return name, nil
+}
+
+// PersonFilter narrows down queries over people, empty fields are ignored
+type PersonFilter struct {
+	BirthCountryCode     CountryCode
+	ResidenceCountryCode CountryCode
+	IncludeDeleted       bool // soft deleted people are left out unless set
+}
+
+// erased people keep their row, and their ID, but nothing that says who they were. The email stays unique and can never be delivered (RFC 2606).
+const (
+	erasedName        = "erased"
+	erasedEmailDomain = "erased.invalid"
+)
+
 // NewPersonID generates a new unique PersonID (UUID v7)
 func NewPersonID() (PersonID, error) {
 	id, err := uuid.NewV7() // V7 is time ordered
@@ -104,8 +144,28 @@ func NewRandomDOB() time.Time {
 	return time.Now().AddDate(0, 0, -randDays).Add(randomTimeOfDay)
 }
 
-// NewPerson factory function
-func NewPerson(firstName, lastName, email string, dob types.UTCTime) (*Person, error) {
+// NewPerson factory function. Birth and residence countries are optional and can be left empty
+func NewPerson(firstName, lastName, email string, dob types.UTCTime, birthCountry, residenceCountry CountryCode) (*Person, error) {
+	validFirstName, err := validName(firstName, ErrFirstNameMissing, ErrFirstNameTooLong)
+	if err != nil {
+		return nil, err
+	}
+
+	validLastName, err := validName(lastName, ErrLastNameMissing, ErrLastNameTooLong)
+	if err != nil {
+		return nil, err
+	}
+
+	validEmail, err := NormaliseEmail(email)
+	if err != nil {
+		return nil, err
+	}
+
+	validDOB, err := NewDOB(dob.Time, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
 	personID, err := NewPersonID()
 	if err != nil {
 		return nil, err
@@ -113,21 +173,29 @@ func NewPerson(firstName, lastName, email string, dob types.UTCTime) (*Person, e
 
 	return &Person{
 		id:        personID,
-		firstName: firstName,
-		lastName:  lastName,
-		email:     strings.ToLower(email),
-		dob:       dob,
+		firstName: validFirstName,
+		lastName:  validLastName,
+		email:     validEmail,
+		dob:       validDOB,
+
+		birthCountry:     birthCountry,
+		residenceCountry: residenceCountry,
 	}, nil
 }
 
-// HydratePerson accepts data from repository and creates a new Person object from it
-func HydratePerson(id PersonID, firstName, lastName, email string, dob types.UTCTime) *Person {
+// HydratePerson accepts data from repository and creates a new Person object from it, deletedAt is zero for people that aren't deleted
+func HydratePerson(id PersonID, firstName, lastName, email string, dob types.UTCTime, birthCountry, residenceCountry CountryCode, deletedAt time.Time) *Person {
 	return &Person{
 		id:        id,
 		firstName: firstName,
 		lastName:  lastName,
 		email:     email,
 		dob:       dob,
+
+		birthCountry:     birthCountry,
+		residenceCountry: residenceCountry,
+
+		deletedAt: deletedAt,
 	}
 }
 
@@ -151,3 +219,132 @@ func (p *Person) Email() string {
 func (p *Person) DOB() types.UTCTime {
 	return p.dob
 }
+
+// Age returns how many full years old the Person is at the given moment
+func (p *Person) Age(now time.Time) int {
+	return AgeAt(p.dob, now)
+}
+
+// BirthCountry returns the code of the country the Person was born in, empty if unknown
+func (p *Person) BirthCountry() CountryCode {
+	return p.birthCountry
+}
+
+// ResidenceCountry returns the code of the country the Person lives in, empty if unknown
+func (p *Person) ResidenceCountry() CountryCode {
+	return p.residenceCountry
+}
+
+// DeletedAt returns when the Person was soft deleted, zero if they weren't
+func (p *Person) DeletedAt() time.Time {
+	return p.deletedAt
+}
+
+// Deleted reports whether the Person was soft deleted
+func (p *Person) Deleted() bool {
+	return !p.deletedAt.IsZero()
+}
+
+// Delete returns a soft deleted copy of the Person, one already deleted keeps its original time
+func (p *Person) Delete(now time.Time) *Person {
+	deleted := *p
+	if !deleted.Deleted() {
+		deleted.deletedAt = now.UTC().Truncate(time.Second)
+	}
+	return &deleted
+}
+
+// Restore returns a copy of the Person that is no longer deleted
+func (p *Person) Restore() *Person {
+	restored := *p
+	restored.deletedAt = time.Time{}
+	return &restored
+}
+
+// PersonalDataFields are the AuditSnapshot fields an erasure anonymises, the audit trail redacts their history too
+var PersonalDataFields = []string{"first_name", "last_name", "email"}
+
+// Erased reports whether the Person's personal data was erased. NormaliseEmail refuses the erased domain, so only Erase can produce such an email.
+func (p *Person) Erased() bool {
+	return strings.HasSuffix(p.email, "@"+erasedEmailDomain)
+}
+
+// Erase returns an anonymised, soft deleted copy of the Person: the name and email are replaced so no personal data is left once they're gone, the rest stays for statistics
+func (p *Person) Erase(now time.Time) *Person {
+	erased := p.Delete(now)
+	erased.firstName = erasedName
+	erased.lastName = erasedName
+	erased.email = erasedName + "-" + p.id.String() + "@" + erasedEmailDomain
+	return erased
+}
+
+// PersonChanges holds the fields to change on an existing Person, nil fields are left as they are
+type PersonChanges struct {
+	FirstName *string
+	LastName  *string
+	Email     *string
+	DOB       *types.UTCTime
+}
+
+// Apply returns a copy of the Person with the changes applied. The ID never changes and a new name, email or DOB goes through the same validation as on creation.
+func (p *Person) Apply(changes PersonChanges) (*Person, error) {
+	updated := *p
+
+	if changes.FirstName != nil {
+		validFirstName, err := validName(*changes.FirstName, ErrFirstNameMissing, ErrFirstNameTooLong)
+		if err != nil {
+			return nil, err
+		}
+		updated.firstName = validFirstName
+	}
+	if changes.LastName != nil {
+		validLastName, err := validName(*changes.LastName, ErrLastNameMissing, ErrLastNameTooLong)
+		if err != nil {
+			return nil, err
+		}
+		updated.lastName = validLastName
+	}
+	if changes.Email != nil {
+		validEmail, err := NormaliseEmail(*changes.Email)
+		if err != nil {
+			return nil, err
+		}
+		updated.email = validEmail
+	}
+	if changes.DOB != nil {
+		validDOB, err := NewDOB(changes.DOB.Time, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		updated.dob = validDOB
+	}
+
+	return &updated, nil
+}
+
+// AuditSnapshot is the Person as the audit trail records it, unknown countries are nil
+func (p *Person) AuditSnapshot() AuditSnapshot {
+	return AuditSnapshot{
+		"first_name":        p.firstName,
+		"last_name":         p.lastName,
+		"email":             p.email,
+		"dob":               p.dob.Format(time.RFC3339),
+		"birth_country":     auditCountryCode(p.birthCountry),
+		"residence_country": auditCountryCode(p.residenceCountry),
+		AuditDeletedAtField: auditTime(p.deletedAt),
+	}
+}
+
+func auditTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func auditCountryCode(cc CountryCode) any {
+	if cc == "" {
+		return nil
+	}
+	return cc.String()
+}
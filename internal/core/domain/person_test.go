@@ -0,0 +1,208 @@
+package domain_test
+
+import (
+	"errors"
+	"louder/internal/core/domain"
+	"louder/pkg/types"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestPersonDeleteRestoreErase(t *testing.T) {
+	id := domain.PersonID(uuid.Must(uuid.NewV7()))
+	dob := time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)
+	deletedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	now := deletedAt.Add(time.Hour)
+
+	person := func(deletedAt time.Time) *domain.Person {
+		return domain.HydratePerson(id, "Jane", "Doe", "jane@example.com", types.NewUTCTime(dob), "PT", "ES", deletedAt)
+	}
+
+	tests := map[string]struct {
+		person        *domain.Person
+		change        func(*domain.Person) *domain.Person
+		wantDeletedAt time.Time
+		wantErased    bool
+	}{
+		"delete":                      {person: person(time.Time{}), change: func(p *domain.Person) *domain.Person { return p.Delete(now) }, wantDeletedAt: now},
+		"delete keeps the first time": {person: person(deletedAt), change: func(p *domain.Person) *domain.Person { return p.Delete(now) }, wantDeletedAt: deletedAt},
+		"restore":                     {person: person(deletedAt), change: (*domain.Person).Restore},
+		"erase":                       {person: person(time.Time{}), change: func(p *domain.Person) *domain.Person { return p.Erase(now) }, wantDeletedAt: now, wantErased: true},
+		"erase someone deleted":       {person: person(deletedAt), change: func(p *domain.Person) *domain.Person { return p.Erase(now) }, wantDeletedAt: deletedAt, wantErased: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			wasDeletedAt := tc.person.DeletedAt()
+			got := tc.change(tc.person)
+
+			if !got.DeletedAt().Equal(tc.wantDeletedAt) || got.Deleted() != !tc.wantDeletedAt.IsZero() {
+				t.Errorf("deleted at %v (deleted %v), want %v", got.DeletedAt(), got.Deleted(), tc.wantDeletedAt)
+			}
+			if !tc.person.DeletedAt().Equal(wasDeletedAt) || tc.person.Email() != "jane@example.com" {
+				t.Error("the original person was changed")
+			}
+
+			erased := got.FirstName() != "Jane" || got.LastName() != "Doe" || got.Email() != "jane@example.com"
+			if erased != tc.wantErased || got.Erased() != tc.wantErased {
+				t.Errorf("name %q %q, email %q, want erased %v", got.FirstName(), got.LastName(), got.Email(), tc.wantErased)
+			}
+			if tc.wantErased {
+				if strings.Contains(got.FirstName()+got.LastName()+got.Email(), "Jane") || !strings.HasSuffix(got.Email(), ".invalid") {
+					t.Errorf("erased person still says who they were: %q %q %q", got.FirstName(), got.LastName(), got.Email())
+				}
+				if got.BirthCountry() != "PT" || !got.DOB().Equal(dob) {
+					t.Error("erasing changed more than the name and email")
+				}
+			}
+		})
+	}
+}
+
+func TestPersonApply(t *testing.T) {
+	id := domain.PersonID(uuid.Must(uuid.NewV7()))
+	dob := types.NewUTCTime(time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC))
+	original := domain.HydratePerson(id, "Jane", "Doe", "jane@example.com", dob, "PT", "ES", time.Time{})
+
+	ptr := func(s string) *string { return &s }
+	newDOB := types.NewUTCTime(time.Date(1985, 1, 2, 0, 0, 0, 0, time.FixedZone("CET", 3600)))
+	futureDOB := types.UTCTime{Time: time.Now().AddDate(1, 0, 0)}
+
+	tests := map[string]struct {
+		changes   domain.PersonChanges
+		wantFirst string
+		wantLast  string
+		wantEmail string
+		wantDOB   time.Time
+		wantErr   error
+	}{
+		"nil fields change nothing": {
+			changes:   domain.PersonChanges{},
+			wantFirst: "Jane", wantLast: "Doe", wantEmail: "jane@example.com", wantDOB: dob.Time,
+		},
+		"names only, trimmed": {
+			changes:   domain.PersonChanges{FirstName: ptr(" Janet "), LastName: ptr("Smith")},
+			wantFirst: "Janet", wantLast: "Smith", wantEmail: "jane@example.com", wantDOB: dob.Time,
+		},
+		"longest names": {
+			changes:   domain.PersonChanges{FirstName: ptr(strings.Repeat("é", domain.MaxNameLength)), LastName: ptr(strings.Repeat("x", domain.MaxNameLength))},
+			wantFirst: strings.Repeat("é", domain.MaxNameLength), wantLast: strings.Repeat("x", domain.MaxNameLength), wantEmail: "jane@example.com", wantDOB: dob.Time,
+		},
+		"blank first name": {
+			changes: domain.PersonChanges{FirstName: ptr("   ")},
+			wantErr: domain.ErrFirstNameMissing,
+		},
+		"empty last name": {
+			changes: domain.PersonChanges{LastName: ptr("")},
+			wantErr: domain.ErrLastNameMissing,
+		},
+		"first name too long": {
+			changes: domain.PersonChanges{FirstName: ptr(strings.Repeat("a", domain.MaxNameLength+1))},
+			wantErr: domain.ErrFirstNameTooLong,
+		},
+		"last name too long": {
+			changes: domain.PersonChanges{LastName: ptr(strings.Repeat("é", domain.MaxNameLength+1))},
+			wantErr: domain.ErrLastNameTooLong,
+		},
+		"email is normalised": {
+			changes:   domain.PersonChanges{Email: ptr("  Janet@Example.COM ")},
+			wantFirst: "Jane", wantLast: "Doe", wantEmail: "janet@example.com", wantDOB: dob.Time,
+		},
+		"dob is normalised to UTC": {
+			changes:   domain.PersonChanges{DOB: &newDOB},
+			wantFirst: "Jane", wantLast: "Doe", wantEmail: "jane@example.com", wantDOB: time.Date(1985, 1, 1, 23, 0, 0, 0, time.UTC),
+		},
+		"invalid email": {
+			changes: domain.PersonChanges{FirstName: ptr("Janet"), Email: ptr("not-an-email")},
+			wantErr: domain.ErrEmailInvalid,
+		},
+		"missing dob": {
+			changes: domain.PersonChanges{DOB: &types.UTCTime{}},
+			wantErr: domain.ErrDOBMissing,
+		},
+		"dob in the future": {
+			changes: domain.PersonChanges{FirstName: ptr("Janet"), DOB: &futureDOB},
+			wantErr: domain.ErrDOBInFuture,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := original.Apply(tc.changes)
+
+			if original.FirstName() != "Jane" || original.Email() != "jane@example.com" || !original.DOB().Equal(dob.Time) {
+				t.Error("the original person was changed")
+			}
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) || got != nil {
+					t.Fatalf("got %v, %v, want nil, %v", got, err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.ID() != id || got.BirthCountry() != "PT" || got.ResidenceCountry() != "ES" {
+				t.Error("applying changes touched the id or the countries")
+			}
+			if got.FirstName() != tc.wantFirst || got.LastName() != tc.wantLast || got.Email() != tc.wantEmail {
+				t.Errorf("got %q %q %q, want %q %q %q", got.FirstName(), got.LastName(), got.Email(), tc.wantFirst, tc.wantLast, tc.wantEmail)
+			}
+			if !got.DOB().Equal(tc.wantDOB) || got.DOB().Location() != time.UTC {
+				t.Errorf("dob = %v, want %v in UTC", got.DOB().Time, tc.wantDOB)
+			}
+		})
+	}
+}
+
+func TestPersonAge(t *testing.T) {
+	dob := types.NewUTCTime(time.Date(2000, 2, 29, 0, 0, 0, 0, time.UTC))
+	person := domain.HydratePerson(domain.PersonID(uuid.Must(uuid.NewV7())), "Jane", "Doe", "jane@example.com", dob, "", "", time.Time{})
+
+	if got := person.Age(time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)); got != 25 {
+		t.Errorf("age on feb 28 = %d, want 25", got)
+	}
+	if got := person.Age(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)); got != 26 {
+		t.Errorf("age on mar 1 = %d, want 26", got)
+	}
+}
+
+func TestNewPersonNames(t *testing.T) {
+	dob := types.NewUTCTime(time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC))
+	long := strings.Repeat("a", domain.MaxNameLength+1)
+
+	tests := map[string]struct {
+		firstName, lastName string
+		wantFirst, wantLast string
+		wantErr             error
+	}{
+		"valid, trimmed":          {firstName: " Jane", lastName: "Doe ", wantFirst: "Jane", wantLast: "Doe"},
+		"40 multibyte characters": {firstName: strings.Repeat("ł", domain.MaxNameLength), lastName: "Doe", wantFirst: strings.Repeat("ł", domain.MaxNameLength), wantLast: "Doe"},
+		"missing first name":      {firstName: "", lastName: "Doe", wantErr: domain.ErrFirstNameMissing},
+		"blank last name":         {firstName: "Jane", lastName: "\t", wantErr: domain.ErrLastNameMissing},
+		"first name too long":     {firstName: long, lastName: "Doe", wantErr: domain.ErrFirstNameTooLong},
+		"last name too long":      {firstName: "Jane", lastName: long, wantErr: domain.ErrLastNameTooLong},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := domain.NewPerson(tc.firstName, tc.lastName, "jane@example.com", dob, "", "")
+
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.FirstName() != tc.wantFirst || got.LastName() != tc.wantLast {
+				t.Errorf("got %q %q, want %q %q", got.FirstName(), got.LastName(), tc.wantFirst, tc.wantLast)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"louder/internal/core/errkind"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// AuthMethod is how a caller proved who they are
+type AuthMethod string
+
+const (
+	AuthMethodAPIKey AuthMethod = "api_key"
+	AuthMethodJWT    AuthMethod = "jwt"
+	AuthMethodNone   AuthMethod = "none" // authentication is switched off, see AnonymousPrincipal
+)
+
+// APIKeyIssuer is the issuer of principals authenticated with one of our own API keys
+const APIKeyIssuer = "louder"
+
+// Principal is the authenticated caller of a request. The subject is only unique within its issuer.
+type Principal struct {
+	subject string
+	issuer  string
+	method  AuthMethod
+	role    Role
+	person  PersonID // the person the caller is, nil for service credentials like API keys
+}
+
+var (
+	errPrincipalSubjectMissing = errkind.New(errkind.Invalid, "principal subject cannot be empty")
+	errPrincipalRoleMissing    = errkind.New(errkind.Invalid, "principal must have a role")
+)
+
+// NewPrincipal creates a Principal, the subject and role are mandatory
+func NewPrincipal(subject, issuer string, method AuthMethod, role Role) (Principal, error) {
+	if subject == "" {
+		return Principal{}, errPrincipalSubjectMissing
+	}
+	if role == RoleNone {
+		return Principal{}, errPrincipalRoleMissing
+	}
+	return Principal{subject: subject, issuer: issuer, method: method, role: role}, nil
+}
+
+// AnonymousPrincipal stands in for every caller when authentication is switched off. It's an editor, never an admin: admin routes mint API keys and read the audit trail, which must not be open to anyone even for a while, so they still need real credentials.
+func AnonymousPrincipal() Principal {
+	return Principal{subject: "anonymous", issuer: APIKeyIssuer, method: AuthMethodNone, role: RoleEditor}
+}
+
+// ActingAs returns a copy of p tied to the person it represents, which gives it ownership of that person's data
+func (p Principal) ActingAs(person PersonID) Principal {
+	p.person = person
+	return p
+}
+
+// Subject identifies the caller within its issuer, an API key ID or a token's sub claim
+func (p Principal) Subject() string {
+	return p.subject
+}
+
+// Issuer is who vouched for the caller, APIKeyIssuer or a token's iss claim
+func (p Principal) Issuer() string {
+	return p.issuer
+}
+
+// Method is how the caller authenticated
+func (p Principal) Method() AuthMethod {
+	return p.method
+}
+
+// Role is the most p is allowed to do
+func (p Principal) Role() Role {
+	return p.role
+}
+
+// Person is who the caller is, false for service credentials
+func (p Principal) Person() (PersonID, bool) {
+	return p.person, p.person != PersonID(uuid.Nil)
+}
+
+// CanEditPerson is the ownership rule for people and what belongs to them (pets, messages...): editors may change anyone, everybody else only themselves
+func (p Principal) CanEditPerson(id PersonID) bool {
+	if p.role.Includes(RoleEditor) {
+		return true
+	}
+	self, ok := p.Person()
+	return ok && self == id
+}
+
+// String returns issuer/subject, unique across issuers, handy for logs
+func (p Principal) String() string {
+	return p.issuer + "/" + p.subject
+}
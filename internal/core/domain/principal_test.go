@@ -0,0 +1,42 @@
+package domain_test
+
+import (
+	"louder/internal/core/domain"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestPrincipalCanEditPerson(t *testing.T) {
+	self := domain.PersonID(uuid.Must(uuid.NewV7()))
+	other := domain.PersonID(uuid.Must(uuid.NewV7()))
+
+	principal := func(role domain.Role) domain.Principal {
+		p, err := domain.NewPrincipal("someone", "test", domain.AuthMethodJWT, role)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+
+	tests := map[string]struct {
+		principal domain.Principal
+		target    domain.PersonID
+		want      bool
+	}{
+		"reader editing themselves":        {principal: principal(domain.RoleReader).ActingAs(self), target: self, want: true},
+		"reader editing someone else":      {principal: principal(domain.RoleReader).ActingAs(self), target: other, want: false},
+		"reader that is no person":         {principal: principal(domain.RoleReader), target: self, want: false},
+		"editor editing someone else":      {principal: principal(domain.RoleEditor), target: other, want: true},
+		"admin editing someone else":       {principal: principal(domain.RoleAdmin), target: other, want: true},
+		"anonymous with auth switched off": {principal: domain.AnonymousPrincipal(), target: other, want: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.principal.CanEditPerson(tc.target); got != tc.want {
+				t.Errorf("CanEditPerson = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
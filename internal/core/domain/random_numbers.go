@@ -2,30 +2,69 @@ package domain
 
 import (
 	"errors"
+	"fmt"
+	"louder/internal/core/errkind"
 )
 
 type RandomNumber uint
 
 // Not very happy about the exported fields...
 type RandomDice struct {
-	Roll     []uint
-	RollSum  uint
-	MaxDice  uint
-	MaxSides uint
+	Roll    []uint
+	RollSum uint
 }
 
+// DiceLimits holds the maximum number of dice and sides allowed for a single roll
+type DiceLimits struct {
+	maxDice  uint
+	maxSides uint
+}
+
+// smallest limits that still make a roll meaningful (a die needs at least 2 sides)
+const (
+	minDice  = 1
+	minSides = 2
+)
+
 var (
-	ErrInvalidNumDice  = errors.New("Value error for 'numdice': out of range")
-	ErrInvalidNumSides = errors.New("Value error for 'numsides': out of range")
+	ErrInvalidNumDice    = errkind.NewField("numdice", "out of range")
+	ErrInvalidNumSides   = errkind.NewField("numsides", "out of range")
+	ErrInvalidDiceLimits = errors.New("error invalid dice limits")
 )
 
-func (d *RandomDice) ValidateDiceParameters(numDice, sides uint) error {
+// NewDiceLimits creates a DiceLimits value, making sure the limits can be used for at least one valid roll
+func NewDiceLimits(maxDice, maxSides uint) (DiceLimits, error) {
+	if maxDice < minDice {
+		return DiceLimits{}, fmt.Errorf("%w: max dice must be at least %d, got %d", ErrInvalidDiceLimits, minDice, maxDice)
+	}
+	if maxSides < minSides {
+		return DiceLimits{}, fmt.Errorf("%w: max sides must be at least %d, got %d", ErrInvalidDiceLimits, minSides, maxSides)
+	}
+
+	return DiceLimits{
+		maxDice:  maxDice,
+		maxSides: maxSides,
+	}, nil
+}
+
+// MaxDice returns the maximum number of dice allowed in a roll
+func (l DiceLimits) MaxDice() uint {
+	return l.maxDice
+}
+
+// MaxSides returns the maximum number of sides allowed per die
+func (l DiceLimits) MaxSides() uint {
+	return l.maxSides
+}
+
+// Validate checks the given number of dice and sides against the limits
+func (l DiceLimits) Validate(numDice, sides uint) error {
 	allErrors := make([]error, 0, 2)
 
-	if numDice < 1 || numDice > d.MaxDice {
+	if numDice < minDice || numDice > l.maxDice {
 		allErrors = append(allErrors, ErrInvalidNumDice)
 	}
-	if sides < 2 || sides > d.MaxSides {
+	if sides < minSides || sides > l.maxSides {
 		allErrors = append(allErrors, ErrInvalidNumSides)
 	}
 	if len(allErrors) > 0 {
@@ -0,0 +1,73 @@
+package domain_test
+
+import (
+	"errors"
+	"louder/internal/core/domain"
+	"testing"
+)
+
+func TestNewDiceLimits(t *testing.T) {
+	tests := map[string]struct {
+		maxDice, maxSides uint
+		wantErr           bool
+	}{
+		"smallest usable": {maxDice: 1, maxSides: 2},
+		"typical":         {maxDice: 10, maxSides: 20},
+		"no dice":         {maxDice: 0, maxSides: 20, wantErr: true},
+		"one sided die":   {maxDice: 10, maxSides: 1, wantErr: true},
+		"zero sides":      {maxDice: 10, maxSides: 0, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			limits, err := domain.NewDiceLimits(tc.maxDice, tc.maxSides)
+			if tc.wantErr {
+				if !errors.Is(err, domain.ErrInvalidDiceLimits) {
+					t.Errorf("err = %v, want ErrInvalidDiceLimits", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if limits.MaxDice() != tc.maxDice || limits.MaxSides() != tc.maxSides {
+				t.Errorf("limits = %d dice of %d sides, want %d of %d", limits.MaxDice(), limits.MaxSides(), tc.maxDice, tc.maxSides)
+			}
+		})
+	}
+}
+
+func TestDiceLimitsValidate(t *testing.T) {
+	limits, err := domain.NewDiceLimits(10, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		numDice, sides uint
+		wantErrs       []error
+	}{
+		"within":             {numDice: 3, sides: 6},
+		"at the limits":      {numDice: 10, sides: 20},
+		"smallest roll":      {numDice: 1, sides: 2},
+		"too many dice":      {numDice: 11, sides: 6, wantErrs: []error{domain.ErrInvalidNumDice}},
+		"no dice":            {numDice: 0, sides: 6, wantErrs: []error{domain.ErrInvalidNumDice}},
+		"too many sides":     {numDice: 3, sides: 21, wantErrs: []error{domain.ErrInvalidNumSides}},
+		"one sided die":      {numDice: 3, sides: 1, wantErrs: []error{domain.ErrInvalidNumSides}},
+		"both reported once": {numDice: 11, sides: 100, wantErrs: []error{domain.ErrInvalidNumDice, domain.ErrInvalidNumSides}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := limits.Validate(tc.numDice, tc.sides)
+			if len(tc.wantErrs) == 0 && err != nil {
+				t.Fatalf("err = %v, want none", err)
+			}
+			for _, want := range tc.wantErrs {
+				if !errors.Is(err, want) {
+					t.Errorf("err = %v, want it to include %v", err, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"louder/internal/core/errkind"
+	"strings"
+)
+
+// Role is what a principal is allowed to do. Roles are ordered, each one includes everything the ones below it can do.
+type Role uint8
+
+const (
+	RoleNone   Role = iota // not a valid role, the zero value
+	RoleReader             // read anything
+	RoleEditor             // also create and change data
+	RoleAdmin              // also manage API keys
+)
+
+var ErrUnknownRole = errkind.NewField("role", "must be one of admin, editor or reader")
+
+// ParseRole parses a role name, case insensitive
+func ParseRole(s string) (Role, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "reader":
+		return RoleReader, nil
+	case "editor":
+		return RoleEditor, nil
+	case "admin":
+		return RoleAdmin, nil
+	default:
+		return RoleNone, ErrUnknownRole
+	}
+}
+
+func (r Role) String() string {
+	switch r {
+	case RoleReader:
+		return "reader"
+	case RoleEditor:
+		return "editor"
+	case RoleAdmin:
+		return "admin"
+	default:
+		return "none"
+	}
+}
+
+// Includes reports whether r grants everything required does
+func (r Role) Includes(required Role) bool {
+	return r != RoleNone && r >= required
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"fmt"
+	"louder/internal/core/errkind"
+	"math"
+	"strings"
+)
+
+// MaxSampleSize caps how many rows can be drawn in a single sample
+const MaxSampleSize = 100
+
+var (
+	ErrInvalidSampleSize   = errkind.NewField("count", "out of range")
+	ErrInvalidSampleWeight = errkind.NewField("weight", "must be a non-negative number")
+)
+
+// SampleRequest describes a draw of distinct random items: how many, which seed and optional per-item weights
+type SampleRequest struct {
+	count   int
+	seed    uint64
+	hasSeed bool
+	weights map[string]float64 // keyed by the item's lowercased string ID, missing items weigh 1
+}
+
+// NewSampleRequest validates the sample size and weights and returns an unseeded SampleRequest
+func NewSampleRequest(count int, weights map[string]float64) (SampleRequest, error) {
+	if count < 1 || count > MaxSampleSize {
+		return SampleRequest{}, fmt.Errorf("%w: must be between 1 and %d, got %d", ErrInvalidSampleSize, MaxSampleSize, count)
+	}
+
+	weightsCopy := make(map[string]float64, len(weights))
+	for key, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return SampleRequest{}, fmt.Errorf("%w: got %v for '%s'", ErrInvalidSampleWeight, w, key)
+		}
+		weightsCopy[strings.ToLower(key)] = w
+	}
+
+	return SampleRequest{
+		count:   count,
+		weights: weightsCopy,
+	}, nil
+}
+
+// WithSeed returns a copy of the SampleRequest that will be drawn with the given seed
+func (r SampleRequest) WithSeed(seed uint64) SampleRequest {
+	r.seed = seed
+	r.hasSeed = true
+	return r
+}
+
+// Count returns the number of items requested
+func (r SampleRequest) Count() int {
+	return r.count
+}
+
+// Seed returns the seed and whether one was set
+func (r SampleRequest) Seed() (uint64, bool) {
+	return r.seed, r.hasSeed
+}
+
+// Weight returns the weight of the item with the given ID (1 when not specified)
+func (r SampleRequest) Weight(id string) float64 {
+	if w, ok := r.weights[strings.ToLower(id)]; ok {
+		return w
+	}
+	return 1
+}
+
+// Weighted reports whether any weights were given
+func (r SampleRequest) Weighted() bool {
+	return len(r.weights) > 0
+}
+
+// Sample is the result of a draw: the items picked, the seed used (so it can be replayed) and how many items were eligible
+type Sample[T any] struct {
+	items      []T
+	seed       uint64
+	population int
+}
+
+// NewSample creates a Sample
+func NewSample[T any](items []T, seed uint64, population int) *Sample[T] {
+	return &Sample[T]{
+		items:      items,
+		seed:       seed,
+		population: population,
+	}
+}
+
+// Items returns the items drawn, in draw order
+func (s *Sample[T]) Items() []T {
+	return s.items
+}
+
+// Seed returns the seed used for the draw
+func (s *Sample[T]) Seed() uint64 {
+	return s.seed
+}
+
+// Population returns how many items matched the filters before drawing
+func (s *Sample[T]) Population() int {
+	return s.population
+}
@@ -0,0 +1,168 @@
+package domain
+
+import (
+	"louder/internal/core/errkind"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// SyncResource names what a sync job copies from an external API
+type SyncResource string
+
+const (
+	SyncCountries  SyncResource = "countries"
+	SyncCurrencies SyncResource = "currencies"
+)
+
+// SyncRegionsOf is the resource of the regions of a country, each country is synced and checkpointed on its own
+func SyncRegionsOf(country CountryCode) SyncResource {
+	return SyncResource("regions:" + country.String())
+}
+
+// SyncCitiesOf is the resource of the cities of a country
+func SyncCitiesOf(country CountryCode) SyncResource {
+	return SyncResource("cities:" + country.String())
+}
+
+// SyncStatus is where a sync job is at
+type SyncStatus string
+
+const (
+	SyncRunning   SyncStatus = "running" // also a job whose process died, it's resumed like a failed one
+	SyncFailed    SyncStatus = "failed"
+	SyncCompleted SyncStatus = "completed"
+)
+
+var ErrSyncResourceMissing = errkind.New(errkind.Invalid, "a sync job needs a resource")
+
+// SyncJob is the checkpoint of a paginated sync, everything before offset has been saved
+type SyncJob struct {
+	id         string // version 7 UUID
+	resource   SyncResource
+	status     SyncStatus
+	offset     int
+	totalCount int // -1 until the first page tells
+	saved      int
+	lastError  string // empty unless the last attempt failed
+	startedAt  time.Time
+	updatedAt  time.Time
+	finishedAt time.Time // zero until completed
+}
+
+// NewSyncJob starts a sync of resource from the first page
+func NewSyncJob(resource SyncResource, now time.Time) (*SyncJob, error) {
+	if resource == "" {
+		return nil, ErrSyncResourceMissing
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+
+	now = now.UTC()
+	return &SyncJob{
+		id:         id.String(),
+		resource:   resource,
+		status:     SyncRunning,
+		totalCount: -1,
+		startedAt:  now,
+		updatedAt:  now,
+	}, nil
+}
+
+// HydrateSyncJob rebuilds a SyncJob from stored data, no validation is done
+func HydrateSyncJob(id string, resource SyncResource, status SyncStatus, offset, totalCount, saved int, lastError string, startedAt, updatedAt, finishedAt time.Time) *SyncJob {
+	return &SyncJob{
+		id:         id,
+		resource:   resource,
+		status:     status,
+		offset:     offset,
+		totalCount: totalCount,
+		saved:      saved,
+		lastError:  lastError,
+		startedAt:  startedAt,
+		updatedAt:  updatedAt,
+		finishedAt: finishedAt,
+	}
+}
+
+func (j *SyncJob) ID() string {
+	return j.id
+}
+
+func (j *SyncJob) Resource() SyncResource {
+	return j.resource
+}
+
+func (j *SyncJob) Status() SyncStatus {
+	return j.status
+}
+
+// Offset is where the next page starts
+func (j *SyncJob) Offset() int {
+	return j.offset
+}
+
+// TotalCount is how many items the API reported, -1 when not known yet
+func (j *SyncJob) TotalCount() int {
+	return j.totalCount
+}
+
+// Saved counts the items saved by every run of the job
+func (j *SyncJob) Saved() int {
+	return j.saved
+}
+
+func (j *SyncJob) LastError() string {
+	return j.lastError
+}
+
+func (j *SyncJob) StartedAt() time.Time {
+	return j.startedAt
+}
+
+func (j *SyncJob) UpdatedAt() time.Time {
+	return j.updatedAt
+}
+
+func (j *SyncJob) FinishedAt() time.Time {
+	return j.finishedAt
+}
+
+// Finished reports whether the job completed, anything else can be resumed
+func (j *SyncJob) Finished() bool {
+	return j.status == SyncCompleted
+}
+
+// Resume marks an unfinished job as running again from its checkpoint
+func (j *SyncJob) Resume(now time.Time) {
+	j.status = SyncRunning
+	j.lastError = ""
+	j.updatedAt = now.UTC()
+}
+
+// Checkpoint records that the items before offset are saved, saved of them by this page
+func (j *SyncJob) Checkpoint(offset, totalCount, saved int, now time.Time) {
+	j.offset = offset
+	if totalCount >= 0 {
+		j.totalCount = totalCount
+	}
+	j.saved += saved
+	j.updatedAt = now.UTC()
+}
+
+// Fail keeps the checkpoint so the next run resumes from it
+func (j *SyncJob) Fail(err error, now time.Time) {
+	j.status = SyncFailed
+	j.lastError = err.Error()
+	j.updatedAt = now.UTC()
+}
+
+func (j *SyncJob) Complete(now time.Time) {
+	j.status = SyncCompleted
+	j.lastError = ""
+	j.updatedAt = now.UTC()
+	j.finishedAt = j.updatedAt
+}
@@ -0,0 +1,123 @@
+// Error kinds shared by the whole core. Domain, services and driven adapters tag their errors with a Kind so driving adapters can pick a response (e.g. an HTTP status) without knowing every sentinel error in the project.
+package errkind
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kind classifies an error by what the caller can do about it
+type Kind uint8
+
+const (
+	Internal        Kind = iota // unclassified, a bug or an infrastructure failure the caller can't fix
+	Invalid                     // the input was rejected, retrying it unchanged won't help
+	NotFound                    // the requested entity doesn't exist
+	Conflict                    // the request clashes with the current state (duplicate, missing prerequisite data...)
+	Unavailable                 // a dependency is down or busy, the same request may succeed later
+	Unauthenticated             // the caller didn't prove who they are, or the proof was rejected
+	Forbidden                   // the caller is known but not allowed to do this
+	RateLimited                 // the caller sent too many requests, the same request may succeed later
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Invalid:
+		return "invalid"
+	case NotFound:
+		return "not found"
+	case Conflict:
+		return "conflict"
+	case Unavailable:
+		return "unavailable"
+	case Unauthenticated:
+		return "unauthenticated"
+	case Forbidden:
+		return "forbidden"
+	case RateLimited:
+		return "rate_limited"
+	default:
+		return "internal"
+	}
+}
+
+// Error attaches a Kind to an error. Field names the input at fault and is only set on Invalid errors made with NewField.
+type Error struct {
+	Kind  Kind
+	Field string
+	Err   error
+
+	fieldMsg string // the message without the field name, for field level reporting
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// New creates an error of the given kind, usually kept as a package level sentinel
+func New(kind Kind, msg string) error {
+	return &Error{Kind: kind, Err: errors.New(msg)}
+}
+
+// NewField creates an Invalid error for a single input field, using the project's "Value error for 'field': ..." wording
+func NewField(field, msg string) error {
+	return &Error{Kind: Invalid, Field: field, Err: fmt.Errorf("Value error for '%s': %s", field, msg), fieldMsg: msg}
+}
+
+// Wrap tags err with a kind, this takes precedence over any kind further down the chain. A nil err stays nil.
+func Wrap(kind Kind, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Kind: kind, Err: err}
+}
+
+// Of returns the kind of the outermost tagged error in the chain, Internal if there is none
+func Of(err error) Kind {
+	var kindErr *Error
+	if errors.As(err, &kindErr) {
+		return kindErr.Kind
+	}
+	return Internal
+}
+
+// FieldError is a validation problem with a single input field
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// Fields collects every field error in the chain, including the branches of errors.Join, in order
+func Fields(err error) []FieldError {
+	var fields []FieldError
+	walk(err, func(e *Error) {
+		if e.Field != "" {
+			fields = append(fields, FieldError{Field: e.Field, Message: e.fieldMsg})
+		}
+	})
+	return fields
+}
+
+// walk visits every *Error in the error tree depth first
+func walk(err error, visit func(e *Error)) {
+	if err == nil {
+		return
+	}
+
+	if e, ok := err.(*Error); ok {
+		visit(e)
+	}
+
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		walk(u.Unwrap(), visit)
+	case interface{ Unwrap() []error }:
+		for _, inner := range u.Unwrap() {
+			walk(inner, visit)
+		}
+	}
+}
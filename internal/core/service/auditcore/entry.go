@@ -0,0 +1,19 @@
+package auditcore
+
+import (
+	"context"
+	"louder/internal/core/domain"
+	"louder/internal/core/service/authcore"
+	"louder/pkg/logging"
+	"time"
+)
+
+// EntryFor builds the audit entry for a change to an entity made on behalf of whoever is behind ctx, nil when nothing changed. Repositories call it inside the transaction making the change and store the entry in that same transaction, so neither can exist without the other.
+func EntryFor(ctx context.Context, entityType domain.AuditEntityType, entityID string, before, after domain.AuditSnapshot) (*domain.AuditEntry, error) {
+	actor := domain.SystemActor
+	if principal, ok := authcore.PrincipalFromContext(ctx); ok {
+		actor = principal.String()
+	}
+
+	return domain.NewAuditEntry(entityType, entityID, actor, logging.RequestID(ctx), before, after, time.Now())
+}
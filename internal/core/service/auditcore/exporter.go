@@ -0,0 +1,39 @@
+package auditcore
+
+import (
+	"context"
+	"louder/internal/core/domain"
+	"louder/pkg/tracing"
+	"time"
+)
+
+// PersonAuditExporter exports every audit entry about a person, oldest changes last as the trail lists them
+type PersonAuditExporter struct {
+	repo Repository
+}
+
+func NewPersonAuditExporter(repo Repository) *PersonAuditExporter {
+	return &PersonAuditExporter{repo: repo}
+}
+
+func (*PersonAuditExporter) TableName() string {
+	return "audit_log"
+}
+
+func (e *PersonAuditExporter) Export(ctx context.Context, p *domain.Person) (domain.ExportTable, error) {
+	ctx, span := tracing.Start(ctx, "auditcore.PersonAuditExporter.Export")
+	defer span.End()
+
+	entries, err := e.repo.List(ctx, domain.AuditFilter{EntityType: domain.AuditPerson, EntityID: p.ID().String(), Limit: NoLimit})
+	if err != nil {
+		return domain.ExportTable{}, err
+	}
+
+	table := domain.ExportTable{Columns: []string{"id", "occurred_at", "actor", "action", "changes", "request_id"}}
+	for _, entry := range entries {
+		table.Rows = append(table.Rows, []any{
+			entry.ID(), entry.OccurredAt().UTC().Format(time.RFC3339), entry.Actor(), string(entry.Action()), entry.Changes(), entry.RequestID(),
+		})
+	}
+	return table, nil
+}
@@ -0,0 +1,11 @@
+package auditcore
+
+import (
+	"context"
+	"louder/internal/core/domain"
+)
+
+// AuditService defines the use cases around the audit trail, which is only ever read through here. Entries are written by the repositories, see EntryFor.
+type AuditService interface {
+	ListEntries(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditEntry, error)
+}
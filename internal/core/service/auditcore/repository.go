@@ -0,0 +1,11 @@
+package auditcore
+
+import (
+	"context"
+	"louder/internal/core/domain"
+)
+
+type Repository interface {
+	// List returns the entries matching filter, newest first and at most filter.Limit of them, all of them when it's NoLimit
+	List(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditEntry, error)
+}
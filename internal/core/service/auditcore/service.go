@@ -0,0 +1,51 @@
+package auditcore
+
+import (
+	"context"
+	"fmt"
+	"louder/internal/core/domain"
+	"louder/internal/core/errkind"
+	"louder/pkg/tracing"
+)
+
+const (
+	DefaultLimit = 100
+	MaxLimit     = 1000
+	NoLimit      = -1 // lists every entry, for internal callers such as exports, the API never allows it
+)
+
+var (
+	ErrLimitOutOfRange = errkind.NewField("limit", fmt.Sprintf("must be between 1 and %d", MaxLimit))
+	ErrEmptyTimeRange  = errkind.NewField("to", "must be after from")
+)
+
+type auditServiceImpl struct {
+	repo Repository
+}
+
+// NewAuditService is the constructor for auditServiceImpl
+func NewAuditService(repo Repository) AuditService {
+	return &auditServiceImpl{repo: repo}
+}
+
+// ListEntries returns the newest entries matching filter, DefaultLimit of them unless it says otherwise
+func (as *auditServiceImpl) ListEntries(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditEntry, error) {
+	ctx, span := tracing.Start(ctx, "auditcore.ListEntries")
+	defer span.End()
+
+	switch {
+	case filter.Limit == 0:
+		filter.Limit = DefaultLimit
+	case filter.Limit < 0 || filter.Limit > MaxLimit:
+		return nil, ErrLimitOutOfRange
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.To.After(filter.From) {
+		return nil, ErrEmptyTimeRange
+	}
+
+	entries, err := as.repo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("service error: failed to list audit entries: %w", err)
+	}
+	return entries, nil
+}
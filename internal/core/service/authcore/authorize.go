@@ -0,0 +1,37 @@
+package authcore
+
+import (
+	"context"
+	"fmt"
+	"louder/internal/core/domain"
+	"louder/internal/core/errkind"
+	"louder/pkg/logging"
+)
+
+var ErrUnauthenticated = errkind.New(errkind.Unauthenticated, "authentication required, send an API key or a bearer token")
+
+// Rule decides whether a principal may go ahead
+type Rule func(p domain.Principal) bool
+
+// HasRole is the Rule for a minimum role
+func HasRole(required domain.Role) Rule {
+	return func(p domain.Principal) bool {
+		return p.Role().Includes(required)
+	}
+}
+
+// Authorize returns the caller if rule lets them perform action, an Unauthenticated error if there is no caller and a Forbidden one if the rule says no. Every denial is audit logged, the request logger already names the principal.
+func Authorize(ctx context.Context, caller Caller, action string, rule Rule) (domain.Principal, error) {
+	principal, ok := caller.Principal(ctx)
+	if !ok {
+		logging.Audit(ctx).Warn("access denied", "action", action, "reason", "unauthenticated")
+		return domain.Principal{}, ErrUnauthenticated
+	}
+
+	if !rule(principal) {
+		logging.Audit(ctx).Warn("access denied", "action", action, "reason", "forbidden", "role", principal.Role())
+		return domain.Principal{}, errkind.New(errkind.Forbidden, fmt.Sprintf("the %s role is not allowed to %s", principal.Role(), action))
+	}
+
+	return principal, nil
+}
@@ -0,0 +1,31 @@
+package authcore
+
+import (
+	"context"
+	"louder/internal/core/domain"
+)
+
+// Caller is the port core services use to learn who is behind the current request. Driving adapters store the principal with WithPrincipal once they've authenticated it.
+type Caller interface {
+	Principal(ctx context.Context) (domain.Principal, bool)
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying the authenticated principal
+func WithPrincipal(ctx context.Context, p domain.Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the principal stored by WithPrincipal, false for anonymous requests
+func PrincipalFromContext(ctx context.Context) (domain.Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(domain.Principal)
+	return p, ok
+}
+
+// ContextCaller is the Caller backed by the request context
+type ContextCaller struct{}
+
+func (ContextCaller) Principal(ctx context.Context) (domain.Principal, bool) {
+	return PrincipalFromContext(ctx)
+}
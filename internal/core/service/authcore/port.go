@@ -0,0 +1,16 @@
+package authcore
+
+import (
+	"context"
+	"louder/internal/core/domain"
+)
+
+// AuthService defines the use cases around authentication - who is calling, and managing the keys they call with
+type AuthService interface {
+	AuthenticateAPIKey(ctx context.Context, secret string) (domain.Principal, error)
+	AuthenticateToken(ctx context.Context, token string) (domain.Principal, error)
+
+	CreateAPIKey(ctx context.Context, name string, role domain.Role) (*domain.APIKey, string, error) // the string is the secret, only available now
+	ListAPIKeys(ctx context.Context) ([]*domain.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id domain.APIKeyID) error
+}
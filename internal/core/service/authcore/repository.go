@@ -0,0 +1,20 @@
+package authcore
+
+import (
+	"context"
+	"louder/internal/core/domain"
+	"time"
+)
+
+type APIKeyRepository interface {
+	Save(ctx context.Context, key *domain.APIKey) error
+	GetByHash(ctx context.Context, hash []byte) (*domain.APIKey, error)
+	List(ctx context.Context) ([]*domain.APIKey, error)
+	Revoke(ctx context.Context, id domain.APIKeyID, at time.Time) error
+	TouchLastUsed(ctx context.Context, id domain.APIKeyID, at time.Time) error
+}
+
+// TokenVerifier checks a bearer token's signature and claims and tells who it was issued to
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (domain.Principal, error)
+}
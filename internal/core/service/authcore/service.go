@@ -0,0 +1,124 @@
+package authcore
+
+import (
+	"context"
+	"fmt"
+	"louder/internal/core/domain"
+	"louder/internal/core/errkind"
+	"louder/pkg/logging"
+	"louder/pkg/tracing"
+	"strings"
+	"time"
+)
+
+// an API key's last use is only written this often, so authenticating doesn't turn every request into a DB write
+const lastUsedResolution = time.Minute
+
+var (
+	ErrInvalidAPIKey  = errkind.New(errkind.Unauthenticated, "invalid API key")
+	ErrRevokedAPIKey  = errkind.New(errkind.Unauthenticated, "API key has been revoked")
+	ErrTokensDisabled = errkind.New(errkind.Unauthenticated, "bearer tokens are not accepted, no token issuer is configured")
+)
+
+type authServiceImpl struct {
+	keyRepo APIKeyRepository
+	tokens  TokenVerifier // nil when no issuer is configured
+}
+
+// NewAuthService is the constructor for authServiceImpl, tokens may be nil to only accept API keys
+func NewAuthService(keyRepo APIKeyRepository, tokens TokenVerifier) AuthService {
+	return &authServiceImpl{
+		keyRepo: keyRepo,
+		tokens:  tokens,
+	}
+}
+
+// AuthenticateAPIKey finds the active key matching secret
+func (as *authServiceImpl) AuthenticateAPIKey(ctx context.Context, secret string) (domain.Principal, error) {
+	ctx, span := tracing.Start(ctx, "authcore.AuthenticateAPIKey")
+	defer span.End()
+
+	if !strings.HasPrefix(secret, domain.APIKeyPrefix) {
+		return domain.Principal{}, ErrInvalidAPIKey
+	}
+
+	key, err := as.keyRepo.GetByHash(ctx, domain.HashAPIKey(secret))
+	if err != nil {
+		if errkind.Of(err) == errkind.NotFound {
+			return domain.Principal{}, ErrInvalidAPIKey
+		}
+		return domain.Principal{}, fmt.Errorf("service error: failed to look up API key: %w", err)
+	}
+	if !key.Active() {
+		return domain.Principal{}, ErrRevokedAPIKey
+	}
+
+	now := time.Now().UTC()
+	if now.Sub(key.LastUsed()) > lastUsedResolution {
+		// not worth failing the request over
+		if err := as.keyRepo.TouchLastUsed(ctx, key.ID(), now); err != nil {
+			logging.FromContext(ctx).Warn("AuthenticateAPIKey: recording last use failed", "api_key_id", key.ID(), "err", err)
+		}
+	}
+
+	return domain.NewPrincipal(string(key.ID()), domain.APIKeyIssuer, domain.AuthMethodAPIKey, key.Role())
+}
+
+// AuthenticateToken checks a bearer token with the configured issuers
+func (as *authServiceImpl) AuthenticateToken(ctx context.Context, token string) (domain.Principal, error) {
+	ctx, span := tracing.Start(ctx, "authcore.AuthenticateToken")
+	defer span.End()
+
+	if as.tokens == nil {
+		return domain.Principal{}, ErrTokensDisabled
+	}
+
+	principal, err := as.tokens.Verify(ctx, token)
+	if err != nil {
+		return domain.Principal{}, errkind.Wrap(errkind.Unauthenticated, fmt.Errorf("invalid bearer token: %w", err))
+	}
+	return principal, nil
+}
+
+// CreateAPIKey generates and stores a new key, the returned secret is the only time it can be seen
+func (as *authServiceImpl) CreateAPIKey(ctx context.Context, name string, role domain.Role) (*domain.APIKey, string, error) {
+	ctx, span := tracing.Start(ctx, "authcore.CreateAPIKey")
+	defer span.End()
+
+	key, secret, err := domain.NewAPIKey(name, role, time.Now())
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := as.keyRepo.Save(ctx, key); err != nil {
+		return nil, "", fmt.Errorf("service error: failed to save API key: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("API key created", "api_key_id", key.ID(), "name", key.Name(), "role", key.Role())
+	return key, secret, nil
+}
+
+// ListAPIKeys returns every key, revoked ones included
+func (as *authServiceImpl) ListAPIKeys(ctx context.Context) ([]*domain.APIKey, error) {
+	ctx, span := tracing.Start(ctx, "authcore.ListAPIKeys")
+	defer span.End()
+
+	keys, err := as.keyRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("service error: failed to list API keys: %w", err)
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey stops a key from authenticating, revoking it twice is not an error
+func (as *authServiceImpl) RevokeAPIKey(ctx context.Context, id domain.APIKeyID) error {
+	ctx, span := tracing.Start(ctx, "authcore.RevokeAPIKey")
+	defer span.End()
+
+	if err := as.keyRepo.Revoke(ctx, id, time.Now().UTC()); err != nil {
+		return fmt.Errorf("service error: failed to revoke API key %s: %w", id, err)
+	}
+
+	logging.FromContext(ctx).Info("API key revoked", "api_key_id", id)
+	return nil
+}
@@ -0,0 +1,13 @@
+package citycore
+
+import (
+	"context"
+	"iter"
+	"louder/internal/core/domain"
+)
+
+// ExternalCityProvider lists the regions and cities of a country, page by page from offset on
+type ExternalCityProvider interface {
+	RegionPages(ctx context.Context, country domain.CountryCode, offset int) iter.Seq2[*domain.Page[*domain.Region], error]
+	CityPages(ctx context.Context, country domain.CountryCode, offset int) iter.Seq2[*domain.Page[*domain.City], error]
+}
@@ -0,0 +1,13 @@
+package citycore
+
+import (
+	"context"
+	"louder/internal/core/domain"
+)
+
+// Repository keeps the regions and cities synced from GeoDB, saves are upserts
+type Repository interface {
+	SaveRegion(ctx context.Context, region *domain.Region) error
+	SaveCity(ctx context.Context, city *domain.City) error
+	GetCity(ctx context.Context, id domain.CityID) (*domain.City, error) // dbcommon.ErrNotFound if there's no such city
+}
@@ -0,0 +1,53 @@
+package countrycore
+
+import (
+	"context"
+	"fmt"
+	"louder/internal/core/domain"
+	"louder/pkg/tracing"
+	"strings"
+)
+
+// PersonCountryExporter exports the countries a person was born in and lives in
+type PersonCountryExporter struct {
+	repo Repository
+}
+
+func NewPersonCountryExporter(repo Repository) *PersonCountryExporter {
+	return &PersonCountryExporter{repo: repo}
+}
+
+func (*PersonCountryExporter) TableName() string {
+	return "countries"
+}
+
+func (e *PersonCountryExporter) Export(ctx context.Context, p *domain.Person) (domain.ExportTable, error) {
+	ctx, span := tracing.Start(ctx, "countrycore.PersonCountryExporter.Export")
+	defer span.End()
+
+	table := domain.ExportTable{Columns: []string{"relation", "code", "name", "region", "wikidata_id", "currencies"}}
+
+	for _, rel := range []struct {
+		name string
+		code domain.CountryCode
+	}{{"birth", p.BirthCountry()}, {"residence", p.ResidenceCountry()}} {
+		if rel.code == "" {
+			continue
+		}
+
+		country, err := e.repo.GetByID(ctx, rel.code)
+		if err != nil {
+			return domain.ExportTable{}, fmt.Errorf("%s country %s: %w", rel.name, rel.code, err)
+		}
+
+		currencies := make([]string, 0, len(country.Currencies()))
+		for _, c := range country.Currencies() {
+			currencies = append(currencies, c.Code().String())
+		}
+		table.Rows = append(table.Rows, []any{
+			rel.name, country.Code().String(), country.Name(), country.Region(), string(country.WikiId()), strings.Join(currencies, " "),
+		})
+	}
+
+	return table, nil
+}
@@ -2,10 +2,12 @@ package countrycore
 
 import (
 	"context"
+	"iter"
 	"louder/internal/core/domain"
 )
 
 type ExternalCountryProvider interface {
 	FetchAllCountries(ctx context.Context) ([]*domain.Country, error)
+	CountryPages(ctx context.Context, offset int) iter.Seq2[*domain.Page[*domain.Country], error] // pages from offset on, in order, for syncs that checkpoint each one
 	GetTotalCountryCountFromAPI(ctx context.Context) (int, error)
 }
@@ -1 +1,12 @@
 package countrycore
+
+import (
+	"context"
+	"louder/internal/core/domain"
+)
+
+// CountryService defines the primary use cases for Country - What do we do with Countries?
+type CountryService interface {
+	GetCountry(ctx context.Context, code domain.CountryCode) (*domain.Country, error)
+	SampleCountries(ctx context.Context, req domain.SampleRequest, filter domain.CountryFilter) (*domain.Sample[*domain.Country], error)
+}
@@ -10,6 +10,7 @@ type Repository interface {
 	GetByID(ctx context.Context, cc domain.CountryCode) (*domain.Country, error) // ID is the Country's ISO code
 	CountAll(ctx context.Context) (int, error)
 	GetRandom(ctx context.Context) (*domain.Country, error)
+	ListCodes(ctx context.Context, filter domain.CountryFilter) ([]domain.CountryCode, error) // cheap key scan used for sampling
 
 	// GetByName(ctx context.Context, name string) (*domain.Country, error)
 	// Search(ctx context.Context, terms string) ([]*domain.Country, error) // get a list of countries when search terms are given, like Google?
@@ -1 +1,47 @@
 package countrycore
+
+import (
+	"context"
+	"fmt"
+	"louder/internal/core/domain"
+	"louder/internal/core/service/samplingcore"
+	"louder/pkg/tracing"
+)
+
+type countryServiceImpl struct {
+	countryRepo Repository
+	random      samplingcore.RandomSource
+}
+
+// NewCountryService is the constructor for countryServiceImpl
+func NewCountryService(repo Repository, random samplingcore.RandomSource) CountryService {
+	return &countryServiceImpl{
+		countryRepo: repo,
+		random:      random,
+	}
+}
+
+// GetCountry returns the country with the given ISO code
+func (cs *countryServiceImpl) GetCountry(ctx context.Context, code domain.CountryCode) (*domain.Country, error) {
+	ctx, span := tracing.Start(ctx, "countrycore.GetCountry")
+	defer span.End()
+
+	country, err := cs.countryRepo.GetByID(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("service error: failed to get country %s: %w", code, err)
+	}
+	return country, nil
+}
+
+// SampleCountries draws distinct random countries matching the filter
+func (cs *countryServiceImpl) SampleCountries(ctx context.Context, req domain.SampleRequest, filter domain.CountryFilter) (*domain.Sample[*domain.Country], error) {
+	ctx, span := tracing.Start(ctx, "countrycore.SampleCountries")
+	defer span.End()
+
+	codes, err := cs.countryRepo.ListCodes(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("service error: failed to list countries for sampling: %w", err)
+	}
+
+	return samplingcore.Draw(ctx, cs.random, codes, req, cs.countryRepo.GetByID)
+}
@@ -0,0 +1,11 @@
+package currencycore
+
+import (
+	"context"
+	"iter"
+	"louder/internal/core/domain"
+)
+
+type ExternalCurrencyProvider interface {
+	CurrencyPages(ctx context.Context, offset int) iter.Seq2[*domain.Page[*domain.Currency], error] // pages from offset on, in order, with the ISO 4217 names
+}
@@ -1 +1,11 @@
 package currencycore
+
+import (
+	"context"
+	"louder/internal/core/domain"
+)
+
+// CurrencyService defines the primary use cases for Currency - What do we do with Currencies?
+type CurrencyService interface {
+	SampleCurrencies(ctx context.Context, req domain.SampleRequest, filter domain.CurrencyFilter) (*domain.Sample[*domain.Currency], error)
+}
@@ -10,6 +10,7 @@ type Repository interface {
 	GetByID(ctx context.Context, cc domain.CurrencyCode) (*domain.Currency, error) // ID is the Country's ISO code
 	CountAll(ctx context.Context) (int, error)
 	GetRandom(ctx context.Context) (*domain.Currency, error)
+	ListCodes(ctx context.Context, filter domain.CurrencyFilter) ([]domain.CurrencyCode, error) // cheap key scan used for sampling
 
 	// GetByName(ctx context.Context, name string) (*domain.Currency, error)
 	// Search(ctx context.Context, terms string) ([]*domain.Currency, error) // get a list of countries when search terms are given, like Google?
@@ -1 +1,35 @@
 package currencycore
+
+import (
+	"context"
+	"fmt"
+	"louder/internal/core/domain"
+	"louder/internal/core/service/samplingcore"
+	"louder/pkg/tracing"
+)
+
+type currencyServiceImpl struct {
+	currencyRepo Repository
+	random       samplingcore.RandomSource
+}
+
+// NewCurrencyService is the constructor for currencyServiceImpl
+func NewCurrencyService(repo Repository, random samplingcore.RandomSource) CurrencyService {
+	return &currencyServiceImpl{
+		currencyRepo: repo,
+		random:       random,
+	}
+}
+
+// SampleCurrencies draws distinct random currencies matching the filter
+func (cs *currencyServiceImpl) SampleCurrencies(ctx context.Context, req domain.SampleRequest, filter domain.CurrencyFilter) (*domain.Sample[*domain.Currency], error) {
+	ctx, span := tracing.Start(ctx, "currencycore.SampleCurrencies")
+	defer span.End()
+
+	codes, err := cs.currencyRepo.ListCodes(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("service error: failed to list currencies for sampling: %w", err)
+	}
+
+	return samplingcore.Draw(ctx, cs.random, codes, req, cs.currencyRepo.GetByID)
+}
@@ -1,7 +1,15 @@
 package datasync
 
-import "context"
+import (
+	"context"
+	"louder/internal/core/domain"
+)
 
+// DataSynchroniser copies reference data from an external provider into the DB page by page. Every sync resumes the last unfinished one of its resource unless restart is set, and returns the items saved by this run and the total the provider reported.
 type DataSynchroniser interface {
-	SyncCountries(ctx context.Context) (int, int, error)
+	SyncCountries(ctx context.Context, restart bool) (int, int, error)
+	SyncCurrencies(ctx context.Context, restart bool) (int, int, error)
+	SyncRegions(ctx context.Context, country domain.CountryCode, restart bool) (int, int, error) // the country must be synced first
+	SyncCities(ctx context.Context, country domain.CountryCode, restart bool) (int, int, error)  // the country must be synced first
+	LatestSync(ctx context.Context, resource domain.SyncResource) (*domain.SyncJob, error)       // dbcommon.ErrNotFound if resource was never synced
 }
@@ -0,0 +1,22 @@
+package datasync
+
+import (
+	"context"
+	"louder/internal/core/domain"
+	"louder/internal/core/service/citycore"
+	"louder/internal/core/service/countrycore"
+	"louder/internal/core/service/currencycore"
+)
+
+// JobRepository keeps the checkpoints of sync jobs
+type JobRepository interface {
+	Save(ctx context.Context, job *domain.SyncJob) error                                  // inserts or updates the checkpoint
+	GetLatest(ctx context.Context, resource domain.SyncResource) (*domain.SyncJob, error) // dbcommon.ErrNotFound if resource was never synced
+}
+
+// Provider is every external source a sync reads from, geodbclient.Provider serves them all
+type Provider interface {
+	countrycore.ExternalCountryProvider
+	currencycore.ExternalCurrencyProvider
+	citycore.ExternalCityProvider
+}
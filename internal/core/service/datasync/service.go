@@ -0,0 +1,178 @@
+package datasync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	dbcommon "louder/internal/adapters/driven/db/dbcommon"
+	"louder/internal/core/domain"
+	"louder/internal/core/errkind"
+	"louder/internal/core/service/citycore"
+	"louder/internal/core/service/countrycore"
+	"louder/internal/core/service/currencycore"
+	"louder/pkg/logging"
+	"louder/pkg/tracing"
+	"sync"
+	"time"
+)
+
+var ErrSyncInProgress = errkind.New(errkind.Conflict, "a sync of this resource is already running")
+
+type synchroniserImpl struct {
+	provider   Provider
+	countries  countrycore.Repository
+	currencies currencycore.Repository
+	cities     citycore.Repository
+	jobs       JobRepository
+	now        func() time.Time
+
+	running sync.Mutex // one sync at a time, they would share the checkpoint and the API quota
+}
+
+func NewDataSynchroniser(provider Provider, countries countrycore.Repository, currencies currencycore.Repository, cities citycore.Repository, jobs JobRepository) *synchroniserImpl {
+	return &synchroniserImpl{
+		provider:   provider,
+		countries:  countries,
+		currencies: currencies,
+		cities:     cities,
+		jobs:       jobs,
+		now:        time.Now,
+	}
+}
+
+var _ DataSynchroniser = (*synchroniserImpl)(nil)
+
+func (s *synchroniserImpl) SyncCountries(ctx context.Context, restart bool) (int, int, error) {
+	ctx, span := tracing.Start(ctx, "datasync.SyncCountries")
+	defer span.End()
+
+	return syncPages(ctx, s, domain.SyncCountries, restart, s.provider.CountryPages, func(ctx context.Context, country *domain.Country) error {
+		if _, err := s.countries.Save(ctx, country); err != nil {
+			return fmt.Errorf("saving country %s: %w", country.Code(), err)
+		}
+		return nil
+	})
+}
+
+// SyncCurrencies best runs before SyncCountries, the countries then link to currencies with their names
+func (s *synchroniserImpl) SyncCurrencies(ctx context.Context, restart bool) (int, int, error) {
+	ctx, span := tracing.Start(ctx, "datasync.SyncCurrencies")
+	defer span.End()
+
+	return syncPages(ctx, s, domain.SyncCurrencies, restart, s.provider.CurrencyPages, func(ctx context.Context, currency *domain.Currency) error {
+		if _, err := s.currencies.Save(ctx, currency); err != nil {
+			return fmt.Errorf("saving currency %s: %w", currency.Code(), err)
+		}
+		return nil
+	})
+}
+
+func (s *synchroniserImpl) SyncRegions(ctx context.Context, country domain.CountryCode, restart bool) (int, int, error) {
+	ctx, span := tracing.Start(ctx, "datasync.SyncRegions")
+	defer span.End()
+
+	pages := func(ctx context.Context, offset int) iter.Seq2[*domain.Page[*domain.Region], error] {
+		return s.provider.RegionPages(ctx, country, offset)
+	}
+	return syncPages(ctx, s, domain.SyncRegionsOf(country), restart, pages, func(ctx context.Context, region *domain.Region) error {
+		if err := s.cities.SaveRegion(ctx, region); err != nil {
+			return fmt.Errorf("saving region %s-%s: %w", region.CountryCode(), region.Code(), err)
+		}
+		return nil
+	})
+}
+
+func (s *synchroniserImpl) SyncCities(ctx context.Context, country domain.CountryCode, restart bool) (int, int, error) {
+	ctx, span := tracing.Start(ctx, "datasync.SyncCities")
+	defer span.End()
+
+	pages := func(ctx context.Context, offset int) iter.Seq2[*domain.Page[*domain.City], error] {
+		return s.provider.CityPages(ctx, country, offset)
+	}
+	return syncPages(ctx, s, domain.SyncCitiesOf(country), restart, pages, func(ctx context.Context, city *domain.City) error {
+		if err := s.cities.SaveCity(ctx, city); err != nil {
+			return fmt.Errorf("saving city %d %s: %w", city.ID(), city.Name(), err)
+		}
+		return nil
+	})
+}
+
+// syncPages saves every page from the job's checkpoint on with save, checkpointing after each one
+func syncPages[T any](ctx context.Context, s *synchroniserImpl, resource domain.SyncResource, restart bool, pages func(context.Context, int) iter.Seq2[*domain.Page[T], error], save func(context.Context, T) error) (int, int, error) {
+	if !s.running.TryLock() {
+		return 0, 0, ErrSyncInProgress
+	}
+	defer s.running.Unlock()
+
+	job, err := s.job(ctx, resource, restart)
+	if err != nil {
+		return 0, 0, err
+	}
+	logger := logging.FromContext(ctx).With("sync_job", job.ID(), "resource", resource)
+	logger.Info("sync starting", "offset", job.Offset(), "total", job.TotalCount())
+
+	saved := 0
+	for page, err := range pages(ctx, job.Offset()) {
+		if err != nil {
+			return saved, job.TotalCount(), s.fail(ctx, job, fmt.Errorf("fetching %s at offset %d: %w", resource, job.Offset(), err))
+		}
+
+		// saves are upserts, a page saved in part is simply saved again on resume
+		for _, item := range page.Items {
+			if err := save(ctx, item); err != nil {
+				return saved, job.TotalCount(), s.fail(ctx, job, err)
+			}
+		}
+		saved += len(page.Items)
+
+		job.Checkpoint(page.NextOffset, page.TotalCount, len(page.Items), s.now())
+		if err := s.jobs.Save(ctx, job); err != nil {
+			return saved, job.TotalCount(), fmt.Errorf("service error: failed to checkpoint %s sync: %w", resource, err)
+		}
+		logger.Debug("sync checkpoint", "offset", job.Offset(), "total", job.TotalCount())
+	}
+
+	job.Complete(s.now())
+	if err := s.jobs.Save(ctx, job); err != nil {
+		return saved, job.TotalCount(), fmt.Errorf("service error: failed to complete %s sync: %w", resource, err)
+	}
+	logger.Info("sync completed", "saved", saved, "total", job.TotalCount())
+	return saved, job.TotalCount(), nil
+}
+
+// job returns the unfinished job of resource to resume, or a new one
+func (s *synchroniserImpl) job(ctx context.Context, resource domain.SyncResource, restart bool) (*domain.SyncJob, error) {
+	job, err := s.jobs.GetLatest(ctx, resource)
+	switch {
+	case errors.Is(err, dbcommon.ErrNotFound):
+	case err != nil:
+		return nil, fmt.Errorf("service error: failed to read the last %s sync: %w", resource, err)
+	case !restart && !job.Finished():
+		job.Resume(s.now())
+		return job, s.jobs.Save(ctx, job)
+	}
+
+	job, err = domain.NewSyncJob(resource, s.now())
+	if err != nil {
+		return nil, err
+	}
+	return job, s.jobs.Save(ctx, job)
+}
+
+// fail records err on the job so the next run resumes from its checkpoint, and returns err
+func (s *synchroniserImpl) fail(ctx context.Context, job *domain.SyncJob, err error) error {
+	job.Fail(err, s.now())
+	// recorded even when ctx was cancelled, that's how most syncs get interrupted
+	if saveErr := s.jobs.Save(context.WithoutCancel(ctx), job); saveErr != nil {
+		logging.FromContext(ctx).Error("sync: failed to record the failure", "sync_job", job.ID(), "resource", job.Resource(), "err", saveErr)
+	}
+	return fmt.Errorf("service error: %s sync failed, it resumes from offset %d: %w", job.Resource(), job.Offset(), err)
+}
+
+func (s *synchroniserImpl) LatestSync(ctx context.Context, resource domain.SyncResource) (*domain.SyncJob, error) {
+	ctx, span := tracing.Start(ctx, "datasync.LatestSync")
+	defer span.End()
+
+	return s.jobs.GetLatest(ctx, resource)
+}
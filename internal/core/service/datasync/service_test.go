@@ -0,0 +1,225 @@
+package datasync_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	dbcommon "louder/internal/adapters/driven/db/dbcommon"
+	"louder/internal/core/domain"
+	"louder/internal/core/service/citycore"
+	"louder/internal/core/service/countrycore"
+	"louder/internal/core/service/datasync"
+	"testing"
+	"time"
+)
+
+// pageSize countries a page, out of total
+const pageSize, total = 2, 5
+
+// fakeProvider serves countries C0 to C4, failing at offset failAt until it's cleared
+type fakeProvider struct {
+	datasync.Provider
+	failAt  int // -1 never fails
+	offsets []int
+}
+
+func (p *fakeProvider) CountryPages(_ context.Context, offset int) iter.Seq2[*domain.Page[*domain.Country], error] {
+	return func(yield func(*domain.Page[*domain.Country], error) bool) {
+		for ; offset < total; offset += pageSize {
+			p.offsets = append(p.offsets, offset)
+			if offset == p.failAt {
+				yield(nil, errors.New("429 Too Many Requests"))
+				return
+			}
+
+			page := &domain.Page[*domain.Country]{TotalCount: total}
+			for i := offset; i < min(offset+pageSize, total); i++ {
+				country, err := domain.NewCountry(domain.CountryCode(fmt.Sprintf("C%d", i)), "Country", "", nil, "")
+				if err != nil {
+					yield(nil, err)
+					return
+				}
+				page.Items = append(page.Items, country)
+			}
+			page.NextOffset = offset + len(page.Items)
+			if !yield(page, nil) {
+				return
+			}
+		}
+	}
+}
+
+type fakeCountries struct {
+	countrycore.Repository
+	saved map[domain.CountryCode]int // times each country was saved
+}
+
+func (r *fakeCountries) Save(_ context.Context, country *domain.Country) (*domain.Country, error) {
+	r.saved[country.Code()]++
+	return country, nil
+}
+
+// CityPages serves a single page with one city of country
+func (p *fakeProvider) CityPages(_ context.Context, country domain.CountryCode, offset int) iter.Seq2[*domain.Page[*domain.City], error] {
+	return func(yield func(*domain.Page[*domain.City], error) bool) {
+		p.offsets = append(p.offsets, offset)
+		city, err := domain.NewCity(1, "Capital", country, "", 0, 0, 0, "")
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		yield(&domain.Page[*domain.City]{Items: []*domain.City{city}, NextOffset: 1, TotalCount: 1}, nil)
+	}
+}
+
+type fakeCities struct {
+	citycore.Repository
+	saved []*domain.City
+}
+
+func (r *fakeCities) SaveCity(_ context.Context, city *domain.City) error {
+	r.saved = append(r.saved, city)
+	return nil
+}
+
+// fakeJobs keeps the saved jobs in memory, as copies like a DB would
+type fakeJobs struct {
+	jobs []domain.SyncJob
+}
+
+func (r *fakeJobs) Save(_ context.Context, job *domain.SyncJob) error {
+	for i := range r.jobs {
+		if r.jobs[i].ID() == job.ID() {
+			r.jobs[i] = *job
+			return nil
+		}
+	}
+	r.jobs = append(r.jobs, *job)
+	return nil
+}
+
+func (r *fakeJobs) GetLatest(_ context.Context, resource domain.SyncResource) (*domain.SyncJob, error) {
+	for i := len(r.jobs) - 1; i >= 0; i-- {
+		if r.jobs[i].Resource() == resource {
+			job := r.jobs[i]
+			return &job, nil
+		}
+	}
+	return nil, dbcommon.ErrNotFound
+}
+
+func TestSyncCountriesResumes(t *testing.T) {
+	provider := &fakeProvider{failAt: 4}
+	countries := &fakeCountries{saved: map[domain.CountryCode]int{}}
+	jobs := &fakeJobs{}
+	sync := datasync.NewDataSynchroniser(provider, countries, nil, nil, jobs)
+	ctx := context.Background()
+
+	saved, _, err := sync.SyncCountries(ctx, false)
+	if err == nil {
+		t.Fatal("expected the sync to fail at offset 4")
+	}
+	if saved != 4 {
+		t.Errorf("saved %d countries before failing, want 4", saved)
+	}
+	job, _ := sync.LatestSync(ctx, domain.SyncCountries)
+	if job.Status() != domain.SyncFailed || job.Offset() != 4 || job.TotalCount() != total || job.LastError() == "" {
+		t.Fatalf("failed job = %s at %d of %d (%q), want failed at 4 of %d", job.Status(), job.Offset(), job.TotalCount(), job.LastError(), total)
+	}
+
+	// the next run picks up at the checkpoint, in the same job
+	provider.failAt = -1
+	provider.offsets = nil
+	saved, gotTotal, err := sync.SyncCountries(ctx, false)
+	if err != nil {
+		t.Fatalf("resumed sync: %v", err)
+	}
+	if saved != 1 || gotTotal != total {
+		t.Errorf("resumed sync saved %d of %d, want 1 of %d", saved, gotTotal, total)
+	}
+	if len(provider.offsets) != 1 || provider.offsets[0] != 4 {
+		t.Errorf("resumed sync fetched offsets %v, want [4]", provider.offsets)
+	}
+	if len(jobs.jobs) != 1 {
+		t.Errorf("%d jobs stored, want the one resumed", len(jobs.jobs))
+	}
+	job, _ = sync.LatestSync(ctx, domain.SyncCountries)
+	if !job.Finished() || job.Saved() != total || job.FinishedAt().IsZero() {
+		t.Errorf("job = %s with %d saved, want completed with %d", job.Status(), job.Saved(), total)
+	}
+	for code, times := range countries.saved {
+		if times != 1 {
+			t.Errorf("country %s saved %d times", code, times)
+		}
+	}
+}
+
+func TestSyncCountriesNewJob(t *testing.T) {
+	tests := map[string]struct {
+		last      domain.SyncStatus
+		restart   bool
+		wantFirst int // offset of the first page fetched
+	}{
+		"after a completed sync":      {last: domain.SyncCompleted, wantFirst: 0},
+		"restart ignores the failure": {last: domain.SyncFailed, restart: true, wantFirst: 0},
+		"a died process is resumed":   {last: domain.SyncRunning, wantFirst: 2},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			previous, _ := domain.NewSyncJob(domain.SyncCountries, testNow)
+			previous.Checkpoint(2, total, 2, testNow)
+			switch tc.last {
+			case domain.SyncCompleted:
+				previous.Complete(testNow)
+			case domain.SyncFailed:
+				previous.Fail(errors.New("boom"), testNow)
+			}
+			jobs := &fakeJobs{jobs: []domain.SyncJob{*previous}}
+			provider := &fakeProvider{failAt: -1}
+			sync := datasync.NewDataSynchroniser(provider, &fakeCountries{saved: map[domain.CountryCode]int{}}, nil, nil, jobs)
+
+			if _, _, err := sync.SyncCountries(context.Background(), tc.restart); err != nil {
+				t.Fatal(err)
+			}
+			if provider.offsets[0] != tc.wantFirst {
+				t.Errorf("first page fetched at %d, want %d", provider.offsets[0], tc.wantFirst)
+			}
+			wantJobs := 2
+			if tc.wantFirst != 0 {
+				wantJobs = 1
+			}
+			if len(jobs.jobs) != wantJobs {
+				t.Errorf("%d jobs stored, want %d", len(jobs.jobs), wantJobs)
+			}
+		})
+	}
+}
+
+func TestSyncCitiesPerCountry(t *testing.T) {
+	// Italy's cities were synced, France's weren't: each country has its own checkpoint
+	italy, _ := domain.NewSyncJob(domain.SyncCitiesOf("IT"), testNow)
+	italy.Checkpoint(1, 1, 1, testNow)
+	italy.Complete(testNow)
+	jobs := &fakeJobs{jobs: []domain.SyncJob{*italy}}
+	cities := &fakeCities{}
+	sync := datasync.NewDataSynchroniser(&fakeProvider{failAt: -1}, nil, nil, cities, jobs)
+	ctx := context.Background()
+
+	if _, _, err := sync.SyncCities(ctx, "FR", false); err != nil {
+		t.Fatal(err)
+	}
+	if len(cities.saved) != 1 || cities.saved[0].CountryCode() != "FR" {
+		t.Errorf("saved %d cities, want France's one", len(cities.saved))
+	}
+	job, err := sync.LatestSync(ctx, domain.SyncCitiesOf("FR"))
+	if err != nil || !job.Finished() || job.Resource() != "cities:FR" {
+		t.Fatalf("French job = %v (%v), want a completed cities:FR job", job, err)
+	}
+	if job, _ := sync.LatestSync(ctx, domain.SyncCitiesOf("IT")); job.ID() != italy.ID() {
+		t.Errorf("Italian job replaced by %s", job.ID())
+	}
+}
+
+var testNow = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
@@ -0,0 +1,11 @@
+package exportcore
+
+import (
+	"context"
+	"louder/internal/core/domain"
+)
+
+// ExportService gathers everything stored about a person, for GDPR subject access requests
+type ExportService interface {
+	ExportPerson(ctx context.Context, pid domain.PersonID) (*domain.PersonExport, error)
+}
@@ -0,0 +1,17 @@
+package exportcore
+
+import (
+	"context"
+	"louder/internal/core/domain"
+)
+
+// Exporter returns what one subsystem stores about a person as a table. Each subsystem provides its own, built on its repository, and registers it with NewExportService so a new kind of data only needs a new Exporter.
+type Exporter interface {
+	TableName() string // unique among the registered exporters
+	Export(ctx context.Context, person *domain.Person) (domain.ExportTable, error)
+}
+
+// PersonReader finds the person being exported, soft deleted people are still stored so they're exported too
+type PersonReader interface {
+	GetByIDIncludingDeleted(ctx context.Context, pid domain.PersonID) (*domain.Person, error)
+}
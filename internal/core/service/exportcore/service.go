@@ -0,0 +1,88 @@
+package exportcore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	dbcommon "louder/internal/adapters/driven/db/dbcommon"
+	"louder/internal/core/domain"
+	"louder/internal/core/errkind"
+	"louder/internal/core/service"
+	"louder/internal/core/service/authcore"
+	"louder/pkg/logging"
+	"louder/pkg/tracing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+var errNilPersonID = errkind.NewField("id", "cannot be nil")
+
+type exportServiceImpl struct {
+	people    PersonReader
+	exporters []Exporter
+	caller    authcore.Caller // only the person themselves and editors may export
+}
+
+// NewExportService registers exporters, their tables come out in this order. Two exporters with the same table name are an error.
+func NewExportService(people PersonReader, caller authcore.Caller, exporters ...Exporter) (ExportService, error) {
+	seen := make(map[string]bool, len(exporters))
+	for _, e := range exporters {
+		name := e.TableName()
+		switch {
+		case name == "":
+			return nil, errors.New("exporter without a table name")
+		case seen[name]:
+			return nil, fmt.Errorf("two exporters for table %q", name)
+		}
+		seen[name] = true
+	}
+
+	return &exportServiceImpl{
+		people:    people,
+		exporters: exporters,
+		caller:    caller,
+	}, nil
+}
+
+// ExportPerson runs every registered exporter over the person, any of them failing fails the export as a partial one would be misleading
+func (es *exportServiceImpl) ExportPerson(ctx context.Context, pid domain.PersonID) (*domain.PersonExport, error) {
+	ctx, span := tracing.Start(ctx, "exportcore.ExportPerson")
+	defer span.End()
+
+	canEdit := func(p domain.Principal) bool { return p.CanEditPerson(pid) }
+	if _, err := authcore.Authorize(ctx, es.caller, "export person "+pid.String(), canEdit); err != nil {
+		return nil, err
+	}
+
+	if uuid.UUID(pid).IsNil() {
+		return nil, fmt.Errorf("%w: %w", service.ErrInvalidPersonData, errNilPersonID)
+	}
+
+	person, err := es.people.GetByIDIncludingDeleted(ctx, pid)
+	if err != nil {
+		logging.FromContext(ctx).Warn("ExportPerson: people.GetByIDIncludingDeleted failed", "person_id", pid.String(), "err", err)
+		if errors.Is(err, dbcommon.ErrNotFound) {
+			return nil, fmt.Errorf("failed to get person: %w", err)
+		}
+		return nil, fmt.Errorf("service error: failed to retrieve person with ID %s from repository: %w", pid.String(), err)
+	}
+
+	export := &domain.PersonExport{
+		PersonID:    pid,
+		GeneratedAt: time.Now().UTC().Truncate(time.Second),
+		Tables:      make([]domain.ExportTable, 0, len(es.exporters)),
+	}
+	for _, e := range es.exporters {
+		table, err := e.Export(ctx, person)
+		if err != nil {
+			logging.FromContext(ctx).Error("ExportPerson: exporter failed", "person_id", pid.String(), "table", e.TableName(), "err", err)
+			return nil, fmt.Errorf("service error: exporting %s of person %s: %w", e.TableName(), pid.String(), err)
+		}
+		table.Name = e.TableName()
+		export.Tables = append(export.Tables, table)
+	}
+
+	logging.FromContext(ctx).Info("ExportPerson: person exported", "person_id", pid.String(), "tables", len(export.Tables))
+	return export, nil
+}
@@ -0,0 +1,34 @@
+package healthcore
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultCheckTimeout bounds checks that don't set their own, probes are called often and must answer quickly
+const DefaultCheckTimeout = 2 * time.Second
+
+// Check probes one dependency, the driven adapters provide them
+type Check struct {
+	Name     string
+	Critical bool          // readiness fails when it does, the other checks are only reported
+	Timeout  time.Duration // DefaultCheckTimeout when zero
+	CacheFor time.Duration // reuse the last result this long, for checks that cost something such as calling a paid API
+	Run      func(ctx context.Context) error
+}
+
+// CheckResult is the outcome of one Check
+type CheckResult struct {
+	Name      string
+	Critical  bool
+	Err       error // nil when the check passed
+	Duration  time.Duration
+	CheckedAt time.Time // older than the request for cached results
+}
+
+// Report is the outcome of a readiness probe
+type Report struct {
+	Ready    bool
+	Draining bool          // shutdown has started, the checks weren't run
+	Checks   []CheckResult // in the order the checks were registered
+}
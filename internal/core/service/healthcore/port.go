@@ -0,0 +1,9 @@
+package healthcore
+
+import "context"
+
+// HealthService tells orchestrators whether the app can take traffic
+type HealthService interface {
+	Ready(ctx context.Context) Report
+	Drain() // readiness fails from now on, called as graceful shutdown starts
+}
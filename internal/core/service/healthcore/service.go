@@ -0,0 +1,83 @@
+package healthcore
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type healthServiceImpl struct {
+	checks   []*cachedCheck
+	draining atomic.Bool
+}
+
+// cachedCheck keeps the last result of a check with CacheFor set
+type cachedCheck struct {
+	Check
+	mu   sync.Mutex
+	last *CheckResult
+}
+
+func NewHealthService(checks ...Check) HealthService {
+	hs := &healthServiceImpl{checks: make([]*cachedCheck, 0, len(checks))}
+	for _, c := range checks {
+		if c.Timeout <= 0 {
+			c.Timeout = DefaultCheckTimeout
+		}
+		hs.checks = append(hs.checks, &cachedCheck{Check: c})
+	}
+	return hs
+}
+
+// Ready runs every check at once, the app is ready when no critical one fails and it isn't shutting down
+func (hs *healthServiceImpl) Ready(ctx context.Context) Report {
+	if hs.draining.Load() {
+		return Report{Draining: true}
+	}
+
+	report := Report{Ready: true, Checks: make([]CheckResult, len(hs.checks))}
+
+	var wg sync.WaitGroup
+	for i, c := range hs.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Checks[i] = c.run(ctx)
+		}()
+	}
+	wg.Wait()
+
+	for _, result := range report.Checks {
+		if result.Critical && result.Err != nil {
+			report.Ready = false
+		}
+	}
+	return report
+}
+
+func (hs *healthServiceImpl) Drain() {
+	hs.draining.Store(true)
+}
+
+// run runs the check within its timeout, unless a cached result is still fresh. Concurrent probes wait for the one already running.
+func (c *cachedCheck) run(ctx context.Context) CheckResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.last != nil && time.Since(c.last.CheckedAt) < c.CacheFor {
+		return *c.last
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := c.Run(ctx)
+	result := CheckResult{Name: c.Name, Critical: c.Critical, Err: err, Duration: time.Since(start), CheckedAt: start}
+
+	if c.CacheFor > 0 {
+		c.last = &result
+	}
+	return result
+}
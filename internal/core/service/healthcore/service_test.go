@@ -0,0 +1,65 @@
+package healthcore_test
+
+import (
+	"context"
+	"errors"
+	"louder/internal/core/service/healthcore"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestReady(t *testing.T) {
+	ok := func(context.Context) error { return nil }
+	failing := func(context.Context) error { return errors.New("down") }
+	slow := func(ctx context.Context) error { <-ctx.Done(); return ctx.Err() }
+
+	tests := map[string]struct {
+		checks    []healthcore.Check
+		drain     bool
+		wantReady bool
+	}{
+		"no checks":                   {wantReady: true},
+		"all passing":                 {checks: []healthcore.Check{{Name: "db", Critical: true, Run: ok}, {Name: "api", Run: ok}}, wantReady: true},
+		"critical failing":            {checks: []healthcore.Check{{Name: "db", Critical: true, Run: failing}, {Name: "api", Run: ok}}, wantReady: false},
+		"optional failing":            {checks: []healthcore.Check{{Name: "db", Critical: true, Run: ok}, {Name: "api", Run: failing}}, wantReady: true},
+		"critical timing out":         {checks: []healthcore.Check{{Name: "db", Critical: true, Timeout: 10 * time.Millisecond, Run: slow}}, wantReady: false},
+		"draining with checks all ok": {checks: []healthcore.Check{{Name: "db", Critical: true, Run: ok}}, drain: true, wantReady: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			hs := healthcore.NewHealthService(tc.checks...)
+			if tc.drain {
+				hs.Drain()
+			}
+
+			report := hs.Ready(context.Background())
+			if report.Ready != tc.wantReady {
+				t.Errorf("ready = %v, want %v (%+v)", report.Ready, tc.wantReady, report)
+			}
+			if report.Draining != tc.drain {
+				t.Errorf("draining = %v, want %v", report.Draining, tc.drain)
+			}
+			if !tc.drain && len(report.Checks) != len(tc.checks) {
+				t.Errorf("got %d results for %d checks", len(report.Checks), len(tc.checks))
+			}
+		})
+	}
+}
+
+func TestReadyCachesResults(t *testing.T) {
+	var calls atomic.Int32
+	hs := healthcore.NewHealthService(healthcore.Check{
+		Name:     "api",
+		CacheFor: time.Hour,
+		Run:      func(context.Context) error { calls.Add(1); return nil },
+	})
+
+	for range 3 {
+		hs.Ready(context.Background())
+	}
+	if calls.Load() != 1 {
+		t.Errorf("check ran %d times, want once while its result is cached", calls.Load())
+	}
+}
@@ -2,7 +2,7 @@
 package messagecore
 
 import (
-	"log"
+	"log/slog"
 	"louder/internal/core/domain"
 )
 
@@ -18,6 +18,6 @@ func NewMessageService(db MessageWithTimeRepository) MessageService { // returns
 }
 
 func (m *messageServiceImpl) GetMessage() domain.MsgWithTime {
-	log.Println("Getting a message from db...")
+	slog.Debug("Getting a message from db...")
 	return m.messageRepo.GetMessageFromRepo()
 }
@@ -0,0 +1,29 @@
+package personcore
+
+import (
+	"context"
+	"louder/internal/core/domain"
+	"time"
+)
+
+// ProfileExporter exports the person's own fields, the exported person is read from the repository before any exporter runs
+type ProfileExporter struct{}
+
+func (ProfileExporter) TableName() string {
+	return "person"
+}
+
+func (ProfileExporter) Export(_ context.Context, p *domain.Person) (domain.ExportTable, error) {
+	var deletedAt any
+	if p.Deleted() {
+		deletedAt = p.DeletedAt().UTC().Format(time.RFC3339)
+	}
+
+	return domain.ExportTable{
+		Columns: []string{"id", "first_name", "last_name", "email", "dob", "birth_country", "residence_country", "deleted_at"},
+		Rows: [][]any{{
+			p.ID().String(), p.FirstName(), p.LastName(), p.Email(), p.DOB().UTC().Format(time.RFC3339),
+			p.BirthCountry().String(), p.ResidenceCountry().String(), deletedAt,
+		}},
+	}, nil
+}
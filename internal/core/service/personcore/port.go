@@ -3,11 +3,20 @@ package personcore
 import (
 	"context"
 	"louder/internal/core/domain"
+	"louder/pkg/types"
+	"time"
 )
 
 // PersonService defines the primary use case for Person - What do we do with Person?
 type PersonService interface {
-	CreatePerson(ctx context.Context, firstName, lastName, email string) (*domain.Person, error)
-	GetPersonByID(ctx context.Context, pid domain.PersonID) (*domain.Person, error)
+	CreatePerson(ctx context.Context, firstName, lastName, email string, dob types.UTCTime, randomDOB bool) (*domain.Person, error)
+	UpdatePerson(ctx context.Context, pid domain.PersonID, changes domain.PersonChanges) (*domain.Person, error)
+	GetPersonByID(ctx context.Context, pid domain.PersonID, includeDeleted bool) (*domain.Person, error)
+	SamplePeople(ctx context.Context, req domain.SampleRequest, filter domain.PersonFilter) (*domain.Sample[*domain.Person], error)
+	GeneratePeople(ctx context.Context, count int) (int, error)
+	DeletePerson(ctx context.Context, pid domain.PersonID) error
+	RestorePerson(ctx context.Context, pid domain.PersonID) (*domain.Person, error)
+	ErasePerson(ctx context.Context, pid domain.PersonID) (*domain.Person, error)
+	PurgeDeletedPeople(ctx context.Context, retention time.Duration) (int, error)
 	// GetAll(context.Context) ([]domain.Person, error)
 }
@@ -3,12 +3,19 @@ package personcore
 import (
 	"context"
 	"louder/internal/core/domain"
+	"time"
 )
 
+// PersonRepository leaves soft deleted people out unless a method says otherwise
 type PersonRepository interface {
 	GetAll(ctx context.Context) ([]domain.Person, error)
 	GetByID(ctx context.Context, pid domain.PersonID) (*domain.Person, error)
+	GetByIDIncludingDeleted(ctx context.Context, pid domain.PersonID) (*domain.Person, error)
+	GetByEmail(ctx context.Context, email string) (*domain.Person, error) // email must already be normalised, dbcommon.ErrNotFound if nobody has it. Soft deleted people count, their email is still taken.
 	Save(ctx context.Context, person *domain.Person) (*domain.Person, error)
+	ListIDs(ctx context.Context, filter domain.PersonFilter) ([]domain.PersonID, error) // cheap key scan used for sampling
+	SaveBatch(ctx context.Context, people []*domain.Person) error                       // all or nothing, in one transaction
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int, error)             // hard deletes people soft deleted before the given time, returns how many
 	// DelPersonFromRepo(ctx context.Context, personId string) error
 	// GetByNameFromRepo(ctx context.Context, name string) ([]domain.Person, error)
 	// GetByAgeFromRepo(ctx context.Context, min, max int) ([]domain.Person, error)
@@ -17,3 +24,13 @@ type PersonRepository interface {
 	// GetByResidingCountryFromRepo(ctx context.Context, country string) ([]domain.Person, error)
 	// GetByVisitedCountriesFromRepo(ctx context.Context, countries ...string) ([]domain.Person, error)
 }
+
+// CountryLister is the slice of the country repository the person service needs to pick countries for generated people
+type CountryLister interface {
+	ListCodes(ctx context.Context, filter domain.CountryFilter) ([]domain.CountryCode, error)
+}
+
+// PersonFaker generates realistic fake people (locale appropriate names, unique emails) from the given countries. Used for load testing.
+type PersonFaker interface {
+	FakePerson(countries []domain.CountryCode) (*domain.Person, error)
+}
@@ -19,22 +19,46 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
 	dbcommon "louder/internal/adapters/driven/db/dbcommon"
 	"louder/internal/core/domain"
+	"louder/internal/core/errkind"
 	"louder/internal/core/service"
+	"louder/internal/core/service/authcore"
+	"louder/internal/core/service/samplingcore"
+	"louder/pkg/logging"
+	"louder/pkg/tracing"
 	"louder/pkg/types"
+	"time"
 
 	"github.com/gofrs/uuid/v5"
 )
 
+// limits for generating fake people
+const (
+	MaxGeneratedPeople = 10_000 // per call
+	generateBatchSize  = 500    // people per transaction
+)
+
+var (
+	errDOBAndRandomDOB = errkind.NewField("random_dob", "give either a dob or ask for a random one, not both")
+	errNilPersonID     = errkind.NewField("id", "cannot be nil")
+)
+
 type personServiceImpl struct {
 	personRepo PersonRepository
+	random     samplingcore.RandomSource
+	countries  CountryLister
+	faker      PersonFaker
+	caller     authcore.Caller // who is behind the request, for ownership checks
 }
 
-func NewPersonService(db PersonRepository) *personServiceImpl {
+func NewPersonService(db PersonRepository, random samplingcore.RandomSource, countries CountryLister, faker PersonFaker, caller authcore.Caller) *personServiceImpl {
 	return &personServiceImpl{
 		personRepo: db,
+		random:     random,
+		countries:  countries,
+		faker:      faker,
+		caller:     caller,
 	}
 }
 
@@ -42,7 +66,7 @@ var _ PersonService = (*personServiceImpl)(nil)
 
 // GetAllPersons receives the request from driving port and processes, dispatches the data
 // func (ps *personServiceImpl) GetAllPersons(ctx context.Context) ([]domain.Person, error) {
-// 	log.Println("Getting all persons from db...")
+// 	slog.Info("Getting all persons from db...")
 
 // 	persons, err := ps.personRepo.GetAll(ctx)
 // 	if err != nil {
@@ -51,37 +75,47 @@ var _ PersonService = (*personServiceImpl)(nil)
 // 	return persons, nil
 // }
 
-// CreatePerson implements the business logic for creating a new person
-func (ps *personServiceImpl) CreatePerson(ctx context.Context, firstName, lastName, email string) (*domain.Person, error) {
-	// some basic validation but more complex logic in domain if needed
+// CreatePerson implements the business logic for creating a new person. The client supplies the DOB, a random one is only used when randomDOB is explicitly requested
+func (ps *personServiceImpl) CreatePerson(ctx context.Context, firstName, lastName, email string, dob types.UTCTime, randomDOB bool) (*domain.Person, error) {
+	ctx, span := tracing.Start(ctx, "personcore.CreatePerson")
+	defer span.End()
+
+	// some basic validation but more complex logic in domain if needed, every missing field is reported at once
+	var missing []error
 	if firstName == "" {
-		return nil, fmt.Errorf("%w: first name cannot be empty", service.ErrInvalidPersonData)
+		missing = append(missing, domain.ErrFirstNameMissing)
 	}
 	if lastName == "" {
-		return nil, fmt.Errorf("%w: last name cannot be empty", service.ErrInvalidPersonData)
-	}
-	if email == "" { // More robust email validation is usually needed
-		return nil, fmt.Errorf("%w: email cannot be empty", service.ErrInvalidPersonData)
-	}
-
-	// Business Rules (Example: Check for duplicate email before attempting to create)
-	//    This might involve a repository call.
-	//    Note: Some prefer to let the DB handle unique constraints and catch the error,  others prefer a proactive check. A proactive check can give a cleaner error.
-	//    existingPerson, err := ps.personRepo.FindByEmail(ctx, email) // Assume FindByEmail exists
-	//    if err != nil && !errors.Is(err, driven.ErrPersonNotFound) { // driven.ErrPersonNotFound or your repo's equivalent
-	//        log.Printf("ERROR CreatePerson - checking for existing email '%s': %v", email, err)
-	//        return nil, fmt.Errorf("service error checking email: %w", err) // Generic service error
-	//    }
-	//    if existingPerson != nil {
-	//        return nil, fmt.Errorf("%w: email '%s'", driving.ErrPersonEmailConflict, email)
-	//    }
-
-	// create the domain object
-	randomDOBTime := types.NewUTCTime(domain.NewRandomDOB())
-	newPerson, err := domain.NewPerson(firstName, lastName, email, randomDOBTime)
+		missing = append(missing, domain.ErrLastNameMissing)
+	}
+	if email == "" {
+		missing = append(missing, domain.ErrEmailMissing)
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %w", service.ErrInvalidPersonData, errors.Join(missing...))
+	}
+
+	switch {
+	case randomDOB && !dob.IsZero():
+		return nil, fmt.Errorf("%w: %w", service.ErrInvalidPersonData, errDOBAndRandomDOB)
+	case randomDOB:
+		dob = types.NewUTCTime(domain.NewRandomDOB())
+	}
+
+	// proactive check for a cleaner error, the UNIQUE constraint still catches a concurrent insert on Save
+	normalisedEmail, err := domain.NormaliseEmail(email)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", service.ErrInvalidPersonData, err)
+	}
+	if err := ps.ensureEmailAvailable(ctx, normalisedEmail, domain.PersonID{}); err != nil {
+		return nil, err
+	}
+
+	// create the domain object, the DOB rules are enforced there
+	newPerson, err := domain.NewPerson(firstName, lastName, email, dob, "", "")
 	if err != nil {
 		// This error likely means the data failed domain-level validation within NewPerson
-		log.Printf("error CreatePerson - domain.NewPerson: %v", err)
+		logging.FromContext(ctx).Warn("CreatePerson: domain.NewPerson rejected the data", "err", err)
 		return nil, fmt.Errorf("%w: %w", service.ErrInvalidPersonData, err) // Wrap domain error
 	}
 
@@ -90,30 +124,115 @@ func (ps *personServiceImpl) CreatePerson(ctx context.Context, firstName, lastNa
 
 	savedPerson, err := ps.personRepo.Save(ctx, newPerson)
 	if err != nil {
-		log.Printf("error CreatePerson - personRepo.Save (ID: %s): %v", newPerson.ID().String(), err)
-		// Check for specific repository errors (e.g., unique constraint violation if not checked before)
-		// if errors.Is(err, driven.ErrDuplicateEntry) { // Example repo error
-		//  return nil, fmt.Errorf("%w: %w", driving.ErrPersonEmailConflict, err)
-		// }
+		logging.FromContext(ctx).Error("CreatePerson: personRepo.Save failed", "person_id", newPerson.ID().String(), "err", err)
+		if errors.Is(err, dbcommon.ErrDuplicate) {
+			return nil, fmt.Errorf("%w: %s", service.ErrEmailConflict, newPerson.Email())
+		}
 		return nil, fmt.Errorf("failed to save person: %w", err) // Generic persistence error
 	}
 
 	// Publish Domain Event (e.g., PersonCreatedEvent) ps.eventPublisher.Publish(ctx, domain.NewPersonCreatedEvent(savedPerson.ID(), ...))
 
-	log.Printf("INFO CreatePerson: Successfully created person ID %s\n", savedPerson.ID().String())
+	logging.FromContext(ctx).Info("CreatePerson: person created", "person_id", savedPerson.ID().String())
 	return savedPerson, nil
 }
 
-// GetPersonByID implements the business logic for getting a person by ID from the DB
-func (ps *personServiceImpl) GetPersonByID(ctx context.Context, pid domain.PersonID) (*domain.Person, error) {
+// UpdatePerson applies the changes to an existing person and saves it. Only editors and the person themselves may do it.
+func (ps *personServiceImpl) UpdatePerson(ctx context.Context, pid domain.PersonID, changes domain.PersonChanges) (*domain.Person, error) {
+	ctx, span := tracing.Start(ctx, "personcore.UpdatePerson")
+	defer span.End()
+
+	canEdit := func(p domain.Principal) bool { return p.CanEditPerson(pid) }
+	if _, err := authcore.Authorize(ctx, ps.caller, "update person "+pid.String(), canEdit); err != nil {
+		return nil, err
+	}
+
+	var missing []error
+	if changes.FirstName != nil && *changes.FirstName == "" {
+		missing = append(missing, domain.ErrFirstNameMissing)
+	}
+	if changes.LastName != nil && *changes.LastName == "" {
+		missing = append(missing, domain.ErrLastNameMissing)
+	}
+	if changes.Email != nil && *changes.Email == "" {
+		missing = append(missing, domain.ErrEmailMissing)
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %w", service.ErrInvalidPersonData, errors.Join(missing...))
+	}
+
+	existingPerson, err := ps.GetPersonByID(ctx, pid, false)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedPerson, err := existingPerson.Apply(changes)
+	if err != nil {
+		logging.FromContext(ctx).Warn("UpdatePerson: domain Apply rejected the changes", "person_id", pid.String(), "err", err)
+		return nil, fmt.Errorf("%w: %w", service.ErrInvalidPersonData, err)
+	}
+
+	if updatedPerson.Email() != existingPerson.Email() {
+		if err := ps.ensureEmailAvailable(ctx, updatedPerson.Email(), pid); err != nil {
+			return nil, err
+		}
+	}
+
+	savedPerson, err := ps.personRepo.Save(ctx, updatedPerson)
+	if err != nil {
+		logging.FromContext(ctx).Error("UpdatePerson: personRepo.Save failed", "person_id", pid.String(), "err", err)
+		if errors.Is(err, dbcommon.ErrDuplicate) {
+			return nil, fmt.Errorf("%w: %s", service.ErrEmailConflict, updatedPerson.Email())
+		}
+		return nil, fmt.Errorf("failed to save person: %w", err)
+	}
+
+	// the repos return (nil, nil) when nothing actually changed, the current state is what we already have
+	if savedPerson == nil {
+		return updatedPerson, nil
+	}
+
+	logging.FromContext(ctx).Info("UpdatePerson: person updated", "person_id", savedPerson.ID().String())
+	return savedPerson, nil
+}
+
+// ensureEmailAvailable returns service.ErrEmailConflict if the normalised email already belongs to someone other than owner
+func (ps *personServiceImpl) ensureEmailAvailable(ctx context.Context, email string, owner domain.PersonID) error {
+	existingPerson, err := ps.personRepo.GetByEmail(ctx, email)
+	switch {
+	case errors.Is(err, dbcommon.ErrNotFound):
+		return nil
+	case err != nil:
+		logging.FromContext(ctx).Error("ensureEmailAvailable: personRepo.GetByEmail failed", "err", err)
+		return fmt.Errorf("service error: failed to check email: %w", err)
+	case existingPerson.ID() != owner:
+		return fmt.Errorf("%w: %s", service.ErrEmailConflict, email)
+	}
+
+	return nil
+}
+
+// GetPersonByID implements the business logic for getting a person by ID from the DB. Soft deleted people are only found with includeDeleted, which is for admins.
+func (ps *personServiceImpl) GetPersonByID(ctx context.Context, pid domain.PersonID, includeDeleted bool) (*domain.Person, error) {
+	ctx, span := tracing.Start(ctx, "personcore.GetPersonByID")
+	defer span.End()
+
 	// TODO get some proper validation going lazy! (regex?)
 	if uuid.UUID(pid).IsNil() {
-		return nil, fmt.Errorf("%w: id cannot be nil", service.ErrInvalidPersonData)
+		return nil, fmt.Errorf("%w: %w", service.ErrInvalidPersonData, errNilPersonID)
 	}
 
-	savedPerson, err := ps.personRepo.GetByID(ctx, pid)
+	getByID := ps.personRepo.GetByID
+	if includeDeleted {
+		if err := ps.authorizeIncludeDeleted(ctx); err != nil {
+			return nil, err
+		}
+		getByID = ps.personRepo.GetByIDIncludingDeleted
+	}
+
+	savedPerson, err := getByID(ctx, pid)
 	if err != nil {
-		log.Printf("warning GetPersonByID - personRepo (ID: %s): %v", pid.String(), err)
+		logging.FromContext(ctx).Warn("GetPersonByID: personRepo.GetByID failed", "person_id", pid.String(), "err", err)
 
 		if errors.Is(err, dbcommon.ErrNotFound) {
 			return nil, fmt.Errorf("failed to get person: %w", err)
@@ -124,11 +243,189 @@ func (ps *personServiceImpl) GetPersonByID(ctx context.Context, pid domain.Perso
 
 	// On success (err == nil), savedPerson variable holds the result. Defensive check: A well-behaved repository should not return (nil, nil).
 	if savedPerson == nil {
-		log.Printf("error GetPersonByID - repository returned (nil, nil) for ID %s, which is unexpected.", pid.String())
+		logging.FromContext(ctx).Error("GetPersonByID: repository returned (nil, nil), which is unexpected", "person_id", pid.String())
 		// Return a generic service error as this indicates an issue with the repository implementation.
 		return nil, fmt.Errorf("service error: inconsistent repository response for ID %s", pid.String())
 	}
 
-	log.Printf("INFO GetPersonByID: person with ID %s found\n", savedPerson.ID().String())
+	logging.FromContext(ctx).Debug("GetPersonByID: person found", "person_id", savedPerson.ID().String())
+	return savedPerson, nil
+}
+
+// SamplePeople draws distinct random people matching the filter
+func (ps *personServiceImpl) SamplePeople(ctx context.Context, req domain.SampleRequest, filter domain.PersonFilter) (*domain.Sample[*domain.Person], error) {
+	ctx, span := tracing.Start(ctx, "personcore.SamplePeople")
+	defer span.End()
+
+	getByID := ps.personRepo.GetByID
+	if filter.IncludeDeleted {
+		if err := ps.authorizeIncludeDeleted(ctx); err != nil {
+			return nil, err
+		}
+		getByID = ps.personRepo.GetByIDIncludingDeleted
+	}
+
+	ids, err := ps.personRepo.ListIDs(ctx, filter)
+	if err != nil {
+		logging.FromContext(ctx).Error("SamplePeople: personRepo.ListIDs failed", "err", err)
+		return nil, fmt.Errorf("service error: failed to list people for sampling: %w", err)
+	}
+
+	return samplingcore.Draw(ctx, ps.random, ids, req, getByID)
+}
+
+// authorizeIncludeDeleted only lets admins see soft deleted people
+func (ps *personServiceImpl) authorizeIncludeDeleted(ctx context.Context) error {
+	_, err := authcore.Authorize(ctx, ps.caller, "include deleted people", authcore.HasRole(domain.RoleAdmin))
+	return err
+}
+
+// DeletePerson soft deletes a person: they disappear from reads but stay restorable until the purge job removes them
+func (ps *personServiceImpl) DeletePerson(ctx context.Context, pid domain.PersonID) error {
+	ctx, span := tracing.Start(ctx, "personcore.DeletePerson")
+	defer span.End()
+
+	existingPerson, err := ps.GetPersonByID(ctx, pid, false)
+	if err != nil {
+		return err
+	}
+
+	if _, err := ps.personRepo.Save(ctx, existingPerson.Delete(time.Now())); err != nil {
+		logging.FromContext(ctx).Error("DeletePerson: personRepo.Save failed", "person_id", pid.String(), "err", err)
+		return fmt.Errorf("failed to delete person: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("DeletePerson: person soft deleted", "person_id", pid.String())
+	return nil
+}
+
+// RestorePerson undoes a soft delete, restoring someone who isn't deleted changes nothing
+func (ps *personServiceImpl) RestorePerson(ctx context.Context, pid domain.PersonID) (*domain.Person, error) {
+	ctx, span := tracing.Start(ctx, "personcore.RestorePerson")
+	defer span.End()
+
+	existingPerson, err := ps.GetPersonByID(ctx, pid, true)
+	if err != nil {
+		return nil, err
+	}
+
+	restoredPerson := existingPerson.Restore()
+	savedPerson, err := ps.personRepo.Save(ctx, restoredPerson)
+	if err != nil {
+		logging.FromContext(ctx).Error("RestorePerson: personRepo.Save failed", "person_id", pid.String(), "err", err)
+		return nil, fmt.Errorf("failed to restore person: %w", err)
+	}
+
+	if savedPerson == nil {
+		return restoredPerson, nil
+	}
+
+	logging.FromContext(ctx).Info("RestorePerson: person restored", "person_id", pid.String())
 	return savedPerson, nil
 }
+
+// ErasePerson anonymises a person's name and email and soft deletes them, for GDPR erasure requests. Like updates, editors and the person themselves may do it, also once they're deleted.
+func (ps *personServiceImpl) ErasePerson(ctx context.Context, pid domain.PersonID) (*domain.Person, error) {
+	ctx, span := tracing.Start(ctx, "personcore.ErasePerson")
+	defer span.End()
+
+	canEdit := func(p domain.Principal) bool { return p.CanEditPerson(pid) }
+	if _, err := authcore.Authorize(ctx, ps.caller, "erase person "+pid.String(), canEdit); err != nil {
+		return nil, err
+	}
+
+	if uuid.UUID(pid).IsNil() {
+		return nil, fmt.Errorf("%w: %w", service.ErrInvalidPersonData, errNilPersonID)
+	}
+
+	existingPerson, err := ps.personRepo.GetByIDIncludingDeleted(ctx, pid)
+	if err != nil {
+		logging.FromContext(ctx).Warn("ErasePerson: personRepo.GetByIDIncludingDeleted failed", "person_id", pid.String(), "err", err)
+		return nil, fmt.Errorf("failed to get person: %w", err)
+	}
+
+	erasedPerson := existingPerson.Erase(time.Now())
+	savedPerson, err := ps.personRepo.Save(ctx, erasedPerson)
+	if err != nil {
+		logging.FromContext(ctx).Error("ErasePerson: personRepo.Save failed", "person_id", pid.String(), "err", err)
+		return nil, fmt.Errorf("failed to erase person: %w", err)
+	}
+
+	if savedPerson == nil {
+		return erasedPerson, nil
+	}
+
+	logging.FromContext(ctx).Info("ErasePerson: person erased", "person_id", pid.String())
+	return savedPerson, nil
+}
+
+// PurgeDeletedPeople hard deletes people soft deleted more than retention ago, it's run by a background job
+func (ps *personServiceImpl) PurgeDeletedPeople(ctx context.Context, retention time.Duration) (int, error) {
+	ctx, span := tracing.Start(ctx, "personcore.PurgeDeletedPeople")
+	defer span.End()
+
+	if retention <= 0 {
+		return 0, fmt.Errorf("%w: retention must be positive, got %s", service.ErrInvalidPersonData, retention)
+	}
+
+	purged, err := ps.personRepo.PurgeDeleted(ctx, time.Now().Add(-retention))
+	if err != nil {
+		logging.FromContext(ctx).Error("PurgeDeletedPeople: personRepo.PurgeDeleted failed", "err", err)
+		return 0, fmt.Errorf("failed to purge deleted people: %w", err)
+	}
+
+	return purged, nil
+}
+
+// GeneratePeople creates count fake people with countries taken from the DB and saves them in batched transactions. It returns how many were saved, which may be less than count if a batch fails.
+func (ps *personServiceImpl) GeneratePeople(ctx context.Context, count int) (int, error) {
+	ctx, span := tracing.Start(ctx, "personcore.GeneratePeople")
+	defer span.End()
+
+	if count < 1 || count > MaxGeneratedPeople {
+		return 0, fmt.Errorf("%w: count must be between 1 and %d, got %d", service.ErrInvalidPersonData, MaxGeneratedPeople, count)
+	}
+
+	countryCodes, err := ps.countries.ListCodes(ctx, domain.CountryFilter{})
+	if err != nil {
+		return 0, fmt.Errorf("service error: failed to list countries for generated people: %w", err)
+	}
+	if len(countryCodes) == 0 {
+		return 0, service.ErrNoCountriesSeeded
+	}
+
+	// emails must be unique across every batch, not just within one
+	seenEmails := make(map[string]struct{}, count)
+	created := 0
+
+	for created < count {
+		batchSize := min(generateBatchSize, count-created)
+		batch := make([]*domain.Person, 0, batchSize)
+
+		for len(batch) < batchSize {
+			fake, err := ps.faker.FakePerson(countryCodes)
+			if err != nil {
+				return created, fmt.Errorf("service error: failed to generate fake person: %w", err)
+			}
+
+			if _, dup := seenEmails[fake.Email()]; dup {
+				continue
+			}
+			seenEmails[fake.Email()] = struct{}{}
+			batch = append(batch, fake)
+		}
+
+		if err := ps.personRepo.SaveBatch(ctx, batch); err != nil {
+			logging.FromContext(ctx).Error("GeneratePeople: personRepo.SaveBatch failed", "created", created, "err", err)
+			// the faker tokens make this very unlikely, but an email may already exist from an earlier run
+			if errors.Is(err, dbcommon.ErrDuplicate) {
+				return created, fmt.Errorf("%w: %w", service.ErrEmailConflict, err)
+			}
+			return created, fmt.Errorf("failed to save generated people: %w", err)
+		}
+		created += len(batch)
+	}
+
+	logging.FromContext(ctx).Info("GeneratePeople: people generated", "created", created)
+	return created, nil
+}
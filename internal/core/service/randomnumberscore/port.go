@@ -1,9 +1,15 @@
 package randomnumberscore
 
-import "louder/internal/core/domain"
+import (
+	"context"
+	"louder/internal/core/domain"
+)
 
 // What services does random number provide to others? what can we ask out of it?
 type Port interface {
 	GetRandomNumber() domain.RandomNumber
-	RollDice(numDice, numSides uint) (*domain.RandomDice, error)
+	RollDice(ctx context.Context, endpoint string, numDice, numSides uint) (*domain.RandomDice, error) // endpoint is the route pattern the roll came through, e.g. "POST /diceroll"
+	DiceLimits(ctx context.Context, endpoint string) domain.DiceLimits                                 // the limits RollDice enforces for the caller on endpoint
+	DefaultDiceLimits() domain.DiceLimits
+	EndpointDiceLimits() map[string]domain.DiceLimits // the endpoint overrides, by route pattern
 }
@@ -2,12 +2,31 @@
 package randomnumberscore
 
 import (
+	"context"
 	"fmt"
 	"louder/internal/core/domain"
+	"louder/internal/core/service/authcore"
+	"louder/pkg/metrics"
+	"maps"
+	"strconv"
 )
 
+// the sides label stays small, the dice limits cap how many values it can take
+var diceRolls = metrics.Default.NewCounterVec("louder_dice_rolls_total",
+	"Successful dice rolls, by number of sides of the dice.", "sides")
+
 type Service struct {
-	repo Repository // will represent the injected dependency
+	repo   Repository // will represent the injected dependency
+	caller authcore.Caller
+
+	limits DiceLimitRules
+}
+
+// DiceLimitRules are the dice limits a Service enforces. They're picked on the server side: the caller's override if it has one, else the endpoint's, else the default.
+type DiceLimitRules struct {
+	Default   domain.DiceLimits
+	Clients   map[string]domain.DiceLimits // by principal, as its String() "issuer/subject"
+	Endpoints map[string]domain.DiceLimits // by route pattern without the /api/v1 prefix, e.g. "POST /diceroll"
 }
 
 // check if Service implements the Port
@@ -20,9 +39,15 @@ func NewRandNumberService(repo Repository) Port {
 	}
 }
 
-func NewDiceRollService(repo Repository) Port {
+// NewDiceRollService creates a Service that enforces limits, the caller tells whose overrides apply
+func NewDiceRollService(repo Repository, caller authcore.Caller, limits DiceLimitRules) Port {
+	limits.Clients = maps.Clone(limits.Clients)
+	limits.Endpoints = maps.Clone(limits.Endpoints)
+
 	return &Service{
-		repo: repo,
+		repo:   repo,
+		caller: caller,
+		limits: limits,
 	}
 }
 
@@ -34,12 +59,39 @@ func (s *Service) GetRandomNumber() domain.RandomNumber {
 	return rn
 }
 
-// RollDice takes the number of dice and number of sides per dice and return the result as a RandomDice object or an error
-func (s *Service) RollDice(numDice, numSides uint) (*domain.RandomDice, error) {
+// RollDice takes the number of dice and number of sides per dice and return the result as a RandomDice object or an error. The limits are the ones of the caller on endpoint.
+func (s *Service) RollDice(ctx context.Context, endpoint string, numDice, numSides uint) (*domain.RandomDice, error) {
+	if err := s.DiceLimits(ctx, endpoint).Validate(numDice, numSides); err != nil {
+		return nil, fmt.Errorf("Error rolling dice: %w", err)
+	}
+
 	roll, err := s.repo.GenerateDiceRoll(numDice, numSides)
 	if err != nil {
 		return nil, fmt.Errorf("Error rolling dice: %w", err)
 	}
+	diceRolls.With(strconv.FormatUint(uint64(numSides), 10)).Inc()
 
 	return roll, nil
 }
+
+// DiceLimits returns the limits of the caller on endpoint: its own override, the endpoint's, or the default ones
+func (s *Service) DiceLimits(ctx context.Context, endpoint string) domain.DiceLimits {
+	if p, ok := s.caller.Principal(ctx); ok {
+		if limits, ok := s.limits.Clients[p.String()]; ok {
+			return limits
+		}
+	}
+	if limits, ok := s.limits.Endpoints[endpoint]; ok {
+		return limits
+	}
+	return s.limits.Default
+}
+
+func (s *Service) DefaultDiceLimits() domain.DiceLimits {
+	return s.limits.Default
+}
+
+// EndpointDiceLimits returns a copy of the endpoint overrides
+func (s *Service) EndpointDiceLimits() map[string]domain.DiceLimits {
+	return maps.Clone(s.limits.Endpoints)
+}
@@ -0,0 +1,11 @@
+package samplingcore
+
+// RandomSource is the driven port the core uses to pick random items. Randomness is infrastructure, so it lives in an adapter.
+type RandomSource interface {
+	// NewSeed returns a fresh seed for callers that did not provide one
+	NewSeed() uint64
+	// SampleIndices picks up to k distinct indices in [0, population). A nil weights slice means a uniform draw, otherwise
+	// weights[i] is the relative weight of index i and indices with weight 0 are never picked. The same seed always
+	// produces the same indices for the same input.
+	SampleIndices(population, k int, weights []float64, seed uint64) []int
+}
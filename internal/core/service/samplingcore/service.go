@@ -0,0 +1,49 @@
+// Shared sampling use case. Each core service lists the keys that match its filters and Draw does the rest, so every entity gets the same seeding and weighting rules.
+package samplingcore
+
+import (
+	"context"
+	"fmt"
+	"louder/internal/core/domain"
+)
+
+// Key is anything that identifies a stored row and can be matched against a weight
+type Key interface {
+	comparable
+	String() string
+}
+
+// Draw picks req.Count() distinct keys at random (weighted if requested) and fetches the matching items. The seed used is returned as part of the sample so the draw can be replayed.
+func Draw[K Key, T any](ctx context.Context, source RandomSource, keys []K, req domain.SampleRequest, fetch func(ctx context.Context, key K) (T, error)) (*domain.Sample[T], error) {
+	seed, ok := req.Seed()
+	if !ok {
+		seed = source.NewSeed()
+	}
+
+	var weights []float64
+	if req.Weighted() {
+		weights = make([]float64, len(keys))
+		for i, key := range keys {
+			weights[i] = req.Weight(key.String())
+		}
+	}
+
+	picked := source.SampleIndices(len(keys), req.Count(), weights, seed)
+
+	items := make([]T, 0, len(picked))
+	for _, i := range picked {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("sample aborted by context: %w", ctx.Err())
+		default:
+		}
+
+		item, err := fetch(ctx, keys[i])
+		if err != nil {
+			return nil, fmt.Errorf("error fetching sampled item %s: %w", keys[i].String(), err)
+		}
+		items = append(items, item)
+	}
+
+	return domain.NewSample(items, seed, len(keys)), nil
+}
@@ -1,11 +1,10 @@
 package service
 
-type Error string
+import "louder/internal/core/errkind"
 
-func (e Error) Error() string {
-	return string(e)
-}
-
-const (
-	ErrInvalidPersonData = Error("error invalid data received")
+// errors shared by the services, each carries the kind driving adapters use to pick a response
+var (
+	ErrInvalidPersonData = errkind.New(errkind.Invalid, "error invalid data received")
+	ErrNoCountriesSeeded = errkind.New(errkind.Conflict, "error no countries in DB, sync countries first")
+	ErrEmailConflict     = errkind.New(errkind.Conflict, "error email already in use")
 )
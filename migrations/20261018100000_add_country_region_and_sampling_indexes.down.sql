@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_person_country_code;
+DROP INDEX IF EXISTS idx_country_currency_currency;
+DROP INDEX IF EXISTS idx_country_region;
+
+ALTER TABLE country DROP COLUMN region;
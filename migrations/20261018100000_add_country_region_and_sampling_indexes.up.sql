@@ -0,0 +1,6 @@
+ALTER TABLE country ADD COLUMN region VARCHAR(50);
+
+-- indexes backing the filters used by random sampling
+CREATE INDEX IF NOT EXISTS idx_country_region ON country (region);
+CREATE INDEX IF NOT EXISTS idx_country_currency_currency ON country_currency (currency_code);
+CREATE INDEX IF NOT EXISTS idx_person_country_code ON person (country_code);
@@ -0,0 +1,40 @@
+-- SQLite can't drop a column used by a foreign key so the table is rebuilt
+DROP INDEX IF EXISTS idx_person_birth_country_code;
+DROP INDEX IF EXISTS idx_person_country_code;
+DROP TRIGGER IF EXISTS person_updated_at;
+
+ALTER TABLE person RENAME TO person_old;
+
+CREATE TABLE IF NOT EXISTS person (
+    id BLOB(16) PRIMARY KEY,
+    first_name VARCHAR(40) NOT NULL CHECK(LENGTH(first_name) <= 40),
+    last_name VARCHAR(40) NOT NULL CHECK(LENGTH(last_name) <= 40),
+    email VARCHAR(255) UNIQUE NOT NULL CHECK(LENGTH(email) <= 255),
+    dob DATETIME NOT NULL
+        -- IMPORTANT: Use strftime to force UTC default
+        CHECK (datetime(dob) IS NOT NULL AND substr(dob, -1) = 'Z'),
+    created_at DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
+        -- Also UTC'
+        CHECK (datetime(created_at) IS NOT NULL AND substr(created_at, -1) = 'Z'),
+    updated_at DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
+        -- Also enforce UTC
+        CHECK (datetime(updated_at) IS NOT NULL AND substr(updated_at, -1) = 'Z'),
+
+    country_code CHAR(2),
+    CONSTRAINT fk_person_country FOREIGN KEY (country_code) REFERENCES country (code) ON DELETE RESTRICT
+);
+
+INSERT INTO person (id, first_name, last_name, email, dob, created_at, updated_at, country_code)
+SELECT id, first_name, last_name, email, dob, created_at, updated_at, residence_country_code FROM person_old;
+
+DROP TABLE person_old;
+
+CREATE INDEX IF NOT EXISTS idx_person_country_code ON person (country_code);
+
+-- automate updated_at
+CREATE TRIGGER person_updated_at
+AFTER UPDATE ON person
+FOR EACH ROW
+BEGIN
+    UPDATE person SET updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = OLD.id;
+END;
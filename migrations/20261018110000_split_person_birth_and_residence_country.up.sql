@@ -0,0 +1,6 @@
+-- the single country a person had becomes where they live, and where they were born is tracked separately
+ALTER TABLE person RENAME COLUMN country_code TO residence_country_code;
+
+ALTER TABLE person ADD COLUMN birth_country_code CHAR(2) REFERENCES country (code) ON DELETE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS idx_person_birth_country_code ON person (birth_country_code);
@@ -0,0 +1,10 @@
+-- API keys, only the SHA-256 of the secret is kept. Times are RFC3339 UTC text like the person table.
+CREATE TABLE IF NOT EXISTS api_key (
+    id TEXT PRIMARY KEY,
+    name VARCHAR(64) NOT NULL CHECK(LENGTH(name) <= 64),
+    prefix TEXT NOT NULL,
+    hash BLOB NOT NULL UNIQUE,
+    created_at TEXT NOT NULL CHECK (datetime(created_at) IS NOT NULL AND substr(created_at, -1) = 'Z'),
+    last_used_at TEXT CHECK (last_used_at IS NULL OR (datetime(last_used_at) IS NOT NULL AND substr(last_used_at, -1) = 'Z')),
+    revoked_at TEXT CHECK (revoked_at IS NULL OR (datetime(revoked_at) IS NOT NULL AND substr(revoked_at, -1) = 'Z'))
+);
@@ -0,0 +1,2 @@
+-- API keys get a role. Keys created before roles existed could do everything, they stay admins.
+ALTER TABLE api_key ADD COLUMN role TEXT NOT NULL DEFAULT 'admin' CHECK (role IN ('admin', 'editor', 'reader'));
@@ -0,0 +1,15 @@
+-- Audit trail of data mutations, written in the same transaction as the change. changes is a JSON object of field to {"before", "after"}. Times are RFC3339 UTC text like the other tables.
+CREATE TABLE IF NOT EXISTS audit_log (
+    id TEXT PRIMARY KEY,
+    occurred_at TEXT NOT NULL CHECK (datetime(occurred_at) IS NOT NULL AND substr(occurred_at, -1) = 'Z'),
+    actor TEXT NOT NULL,
+    action TEXT NOT NULL CHECK (action IN ('create', 'update', 'delete')),
+    entity_type TEXT NOT NULL,
+    entity_id TEXT NOT NULL,
+    changes TEXT NOT NULL CHECK (json_valid(changes)),
+    request_id TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log (entity_type, entity_id, occurred_at);
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor ON audit_log (actor, occurred_at);
+CREATE INDEX IF NOT EXISTS idx_audit_log_occurred_at ON audit_log (occurred_at);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_person_deleted_at;
+ALTER TABLE person DROP COLUMN deleted_at;
@@ -0,0 +1,4 @@
+-- soft delete: people are only hidden at first, the purge job removes them for good once the retention period is over
+ALTER TABLE person ADD COLUMN deleted_at TEXT CHECK (deleted_at IS NULL OR (datetime(deleted_at) IS NOT NULL AND substr(deleted_at, -1) = 'Z'));
+
+CREATE INDEX IF NOT EXISTS idx_person_deleted_at ON person (deleted_at);
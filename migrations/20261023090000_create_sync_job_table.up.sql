@@ -0,0 +1,15 @@
+-- Checkpoints of the syncs from external APIs, offset is where the next page starts. A job stays running if its process died, the next run resumes it like a failed one. Times are RFC3339 UTC text like the other tables.
+CREATE TABLE IF NOT EXISTS sync_job (
+    id TEXT PRIMARY KEY,
+    resource TEXT NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('running', 'failed', 'completed')),
+    page_offset INTEGER NOT NULL DEFAULT 0 CHECK (page_offset >= 0),
+    total_count INTEGER NOT NULL DEFAULT -1,
+    saved INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    started_at TEXT NOT NULL CHECK (datetime(started_at) IS NOT NULL AND substr(started_at, -1) = 'Z'),
+    updated_at TEXT NOT NULL CHECK (datetime(updated_at) IS NOT NULL AND substr(updated_at, -1) = 'Z'),
+    finished_at TEXT CHECK (finished_at IS NULL OR (datetime(finished_at) IS NOT NULL AND substr(finished_at, -1) = 'Z'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_sync_job_resource ON sync_job (resource, started_at);
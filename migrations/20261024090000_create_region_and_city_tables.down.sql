@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_city_country_name;
+DROP TABLE IF EXISTS city;
+DROP TABLE IF EXISTS region;
@@ -0,0 +1,23 @@
+-- Regions and cities synced from GeoDB, per country. A city keeps GeoDB's ID so a sync updates it in place, its region_code matches region.code but isn't a foreign key: cities can be synced before the regions of their country.
+CREATE TABLE IF NOT EXISTS region (
+    country_code CHAR(2) NOT NULL,
+    code VARCHAR(10) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    wikidataid VARCHAR(12),
+    CONSTRAINT pk_region PRIMARY KEY (country_code, code),
+    CONSTRAINT fk_region_country FOREIGN KEY (country_code) REFERENCES country (code) ON DELETE RESTRICT
+);
+
+CREATE TABLE IF NOT EXISTS city (
+    id INTEGER PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    country_code CHAR(2) NOT NULL,
+    region_code VARCHAR(10),
+    latitude REAL NOT NULL CHECK (latitude BETWEEN -90 AND 90),
+    longitude REAL NOT NULL CHECK (longitude BETWEEN -180 AND 180),
+    population INTEGER NOT NULL DEFAULT 0 CHECK (population >= 0),
+    wikidataid VARCHAR(12),
+    CONSTRAINT fk_city_country FOREIGN KEY (country_code) REFERENCES country (code) ON DELETE RESTRICT
+);
+
+CREATE INDEX IF NOT EXISTS idx_city_country_name ON city (country_code, name);
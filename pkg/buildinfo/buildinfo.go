@@ -0,0 +1,43 @@
+// Package buildinfo reads what the Go toolchain embeds in the binary about how it was built
+package buildinfo
+
+import (
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// Info describes the running binary. The VCS fields are empty for binaries built without VCS information, e.g. by go run or with -buildvcs=false.
+type Info struct {
+	Module    string
+	Version   string // module version, "(devel)" for local builds
+	GoVersion string
+	Revision  string    // VCS commit
+	Time      time.Time // commit time
+	Modified  bool      // built from a working tree with uncommitted changes
+}
+
+// Read returns the build information, it's only worked out once
+var Read = sync.OnceValue(func() Info {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return Info{Version: "unknown"}
+	}
+
+	info := Info{
+		Module:    bi.Main.Path,
+		Version:   bi.Main.Version,
+		GoVersion: bi.GoVersion,
+	}
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			info.Revision = s.Value
+		case "vcs.time":
+			info.Time, _ = time.Parse(time.RFC3339, s.Value)
+		case "vcs.modified":
+			info.Modified = s.Value == "true"
+		}
+	}
+	return info
+})
@@ -2,9 +2,12 @@
 package config
 
 import (
-	"log"
+	"encoding/base64"
+	"log/slog"
+	"net/netip"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv" // Common library for .env files
@@ -12,36 +15,162 @@ import (
 
 // AppConfig holds the entire configuration for the app
 type AppConfig struct {
-	ServerPort            string
-	GeoAPIBaseURL         string
-	GeoAPIKeyHeaderName   string
-	GeoAPIKey             string
-	GeoAPIRateLimitSleep  time.Duration
-	GeoAPIPageLimit       int
-	GeoAPICountryEndpoint string
+	ServerPort             string
+	GeoAPIBaseURL          string
+	GeoAPIKeyHeaderName    string
+	GeoAPIKey              string
+	GeoAPIRateLimit        float64 // GeoDB calls a second allowed by the plan, the API's rate limit headers can slow it further. 0 for no limit
+	GeoAPIRateBurst        int     // GeoDB calls that may go out at once
+	GeoAPIPageConcurrency  int     // pages of a sync fetched at once, the rate limit still applies
+	GeoAPIPageLimit        int
+	GeoAPICountryEndpoint  string
+	GeoAPIHealthCheck      bool          // report whether the GeoDB API is reachable on /readyz, it never fails readiness
+	GeoAPIMaxAttempts      int           // tries per GeoDB call, retrying on 429, 5xx and network errors
+	GeoAPIRetryBaseDelay   time.Duration // backoff before the first retry, doubled for each one after it and jittered
+	GeoAPIRetryMaxDelay    time.Duration // cap of a backoff, a longer Retry-After gives up instead
+	GeoAPIBreakerThreshold int           // consecutive failed GeoDB requests that open the circuit breaker, 0 disables it
+	GeoAPIBreakerCooldown  time.Duration // how long the open breaker fails calls before trying GeoDB again
+
+	DiceLimits         DiceLimitConfig            // default limits for /diceroll
+	DiceProfileLimits  map[string]DiceLimitConfig // named sets of limits, assigned to clients and routes below
+	DiceClientProfiles map[string]string          // profile of a client by principal "issuer/subject", it wins over the route's
+	DiceRouteProfiles  map[string]string          // profile of a route by pattern without /api/v1, e.g. "POST /diceroll"
+
+	LogFormat string     // "text" or "json"
+	LogLevel  slog.Level // minimum level written
+
+	RequestTimeout     time.Duration  // deadline for every request
+	MaxBodyBytes       int64          // largest request body accepted
+	CORSAllowedOrigins []string       // origins browsers may call the API from, "*" for any, empty for none
+	TrustedProxies     []netip.Prefix // proxies whose X-Forwarded-For / X-Real-IP headers are believed
+
+	LegacyRoutesSunset time.Time // when the unversioned routes stop working, announced in their Sunset header
+
+	AuthRequired bool              // refuse anonymous calls to the API, the OpenAPI document stays public
+	JWTIssuers   []JWTIssuerConfig // who may sign bearer tokens, empty to only accept API keys
+	JWTAudience  string            // when set, bearer tokens must name it in their aud claim
+
+	RateLimitDefault RateLimitConfig            // per client budget of the routes without one of their own, zero for no limit
+	RateLimitRoutes  map[string]RateLimitConfig // per client budgets by route pattern without the /api/v1 prefix, e.g. "POST /diceroll"
+	RateLimitAuth    RateLimitConfig            // failed authentications allowed per client IP, zero for no limit
+
+	PersonRetention time.Duration // how long soft deleted people are kept before they're purged, zero to keep them forever
+	PurgeInterval   time.Duration // how often the purge job looks for people past their retention
+
+	ShutdownDrainDelay time.Duration // how long /readyz fails before the server stops, so load balancers stop sending traffic first
+
+	TracingExporter    string            // "none", "stdout" or "otlp"
+	TracingSampleRatio float64           // share of new traces recorded, traces started by a caller follow its choice
+	OTLPEndpoint       string            // base URL of the OpenTelemetry collector, /v1/traces is added
+	OTLPHeaders        map[string]string // sent with every export, for the collector's credentials
+}
+
+// RateLimitConfig is a token bucket: Burst requests at once, refilled at Requests per Per
+type RateLimitConfig struct {
+	Requests int
+	Per      time.Duration
+	Burst    int // Requests when zero
+}
+
+// JWTIssuerConfig holds the algorithm and key bearer tokens from one issuer are checked with
+type JWTIssuerConfig struct {
+	Name      string // the iss claim
+	Algorithm string // HS256 or EdDSA
+	Key       []byte // the shared secret for HS256, the Ed25519 public key for EdDSA
+}
+
+// DiceLimitConfig holds the dice roll caps for either the default or a named profile
+type DiceLimitConfig struct {
+	MaxDice  uint
+	MaxSides uint
 }
 
 // LoadConfig attempt to load .env file. In production, variables are usually set directly.
 func LoadConfig() *AppConfig {
 	err := godotenv.Load() // Tries to load .env from the current directory or parent dirs - By default, godotenv.Load() WILL NOT OVERRIDE existing environment variables
 	if err != nil {
-		log.Println("no .env file found or error loading it, trying env vars")
+		slog.Info("no .env file found or error loading it, trying env vars")
 	}
 
 	// ignore parsing error as this is just to load from .env
-	parsedGeoAPIRateLimitSleep, _ := time.ParseDuration(getEnv("GEO_API_RATE_LIMIT_SLEEP", "1500ms"))
+	parsedGeoAPIRateLimitPerSecond, _ := strconv.ParseFloat(getEnv("GEO_API_RATE_LIMIT", "1"), 64)
+	parsedGeoAPIRateBurst, _ := strconv.Atoi(getEnv("GEO_API_RATE_BURST", "1"))
+	parsedGeoAPIPageConcurrency, _ := strconv.Atoi(getEnv("GEO_API_PAGE_CONCURRENCY", "4"))
 
 	// ignore parsing error as this is just to load from .env
 	parsedGeoAPIRateLimit, _ := strconv.Atoi((getEnv("GEO_API_PAGE_LIMIT", "10")))
 
+	// ignore parsing errors as this is just to load from .env
+	parsedDiceMaxDice, _ := strconv.ParseUint(getEnv("DICE_MAX_DICE", "10"), 10, 0)
+	parsedDiceMaxSides, _ := strconv.ParseUint(getEnv("DICE_MAX_SIDES", "20"), 10, 0)
+
+	// ignore parsing errors as this is just to load from .env
+	parsedRequestTimeout, _ := time.ParseDuration(getEnv("REQUEST_TIMEOUT", "5s"))
+	parsedMaxBodyBytes, _ := strconv.ParseInt(getEnv("MAX_BODY_BYTES", "1048576"), 10, 64)
+	parsedLegacyRoutesSunset, _ := time.Parse(time.DateOnly, getEnv("LEGACY_ROUTES_SUNSET", "2027-04-30"))
+	parsedAuthRequired, _ := strconv.ParseBool(getEnv("AUTH_REQUIRED", "true"))
+	parsedPersonRetention, _ := time.ParseDuration(getEnv("PERSON_RETENTION", "720h"))
+	parsedPurgeInterval, _ := time.ParseDuration(getEnv("PURGE_INTERVAL", "1h"))
+	parsedGeoAPIHealthCheck, _ := strconv.ParseBool(getEnv("GEO_API_HEALTH_CHECK", "false"))
+	parsedGeoAPIMaxAttempts, _ := strconv.Atoi(getEnv("GEO_API_MAX_ATTEMPTS", "4"))
+	parsedGeoAPIRetryBaseDelay, _ := time.ParseDuration(getEnv("GEO_API_RETRY_BASE_DELAY", "500ms"))
+	parsedGeoAPIRetryMaxDelay, _ := time.ParseDuration(getEnv("GEO_API_RETRY_MAX_DELAY", "30s"))
+	parsedGeoAPIBreakerThreshold, _ := strconv.Atoi(getEnv("GEO_API_BREAKER_THRESHOLD", "5"))
+	parsedGeoAPIBreakerCooldown, _ := time.ParseDuration(getEnv("GEO_API_BREAKER_COOLDOWN", "30s"))
+	parsedShutdownDrainDelay, _ := time.ParseDuration(getEnv("SHUTDOWN_DRAIN_DELAY", "5s"))
+	parsedTracingSampleRatio, _ := strconv.ParseFloat(getEnv("TRACING_SAMPLE_RATIO", "1"), 64)
+
 	return &AppConfig{
-		ServerPort:            getEnv("REST_API_SERVER_PORT", "8080"),
-		GeoAPIBaseURL:         getEnv("GEO_API_BASEURL", "https://wft-geo-db.p.rapidapi.com"),
-		GeoAPIKeyHeaderName:   getEnv("GEO_API_KEY_HEADER_NAME", "x-rapidapi-key"),
-		GeoAPIKey:             getEnv("GEO_API_KEY", "COULD_READ_GET_API_KEY"),
-		GeoAPIRateLimitSleep:  parsedGeoAPIRateLimitSleep,
-		GeoAPIPageLimit:       parsedGeoAPIRateLimit,
-		GeoAPICountryEndpoint: getEnv("GEO_API_COUNTRY_ENDPOINT", "/v1/geo/countries"),
+		ServerPort:             getEnv("REST_API_SERVER_PORT", "8080"),
+		GeoAPIBaseURL:          getEnv("GEO_API_BASEURL", "https://wft-geo-db.p.rapidapi.com"),
+		GeoAPIKeyHeaderName:    getEnv("GEO_API_KEY_HEADER_NAME", "x-rapidapi-key"),
+		GeoAPIKey:              getEnv("GEO_API_KEY", "COULD_READ_GET_API_KEY"),
+		GeoAPIRateLimit:        parsedGeoAPIRateLimitPerSecond,
+		GeoAPIRateBurst:        parsedGeoAPIRateBurst,
+		GeoAPIPageConcurrency:  parsedGeoAPIPageConcurrency,
+		GeoAPIPageLimit:        parsedGeoAPIRateLimit,
+		GeoAPICountryEndpoint:  getEnv("GEO_API_COUNTRY_ENDPOINT", "/v1/geo/countries"),
+		GeoAPIHealthCheck:      parsedGeoAPIHealthCheck,
+		GeoAPIMaxAttempts:      parsedGeoAPIMaxAttempts,
+		GeoAPIRetryBaseDelay:   parsedGeoAPIRetryBaseDelay,
+		GeoAPIRetryMaxDelay:    parsedGeoAPIRetryMaxDelay,
+		GeoAPIBreakerThreshold: parsedGeoAPIBreakerThreshold,
+		GeoAPIBreakerCooldown:  parsedGeoAPIBreakerCooldown,
+		DiceLimits: DiceLimitConfig{
+			MaxDice:  uint(parsedDiceMaxDice),
+			MaxSides: uint(parsedDiceMaxSides),
+		},
+		DiceProfileLimits:  parseDiceProfiles(getEnv("DICE_PROFILE_LIMITS", "")),
+		DiceClientProfiles: parseProfileAssignments(getEnv("DICE_CLIENT_PROFILES", ""), false),
+		DiceRouteProfiles:  parseProfileAssignments(getEnv("DICE_ROUTE_PROFILES", ""), true),
+
+		LogFormat: getEnv("LOG_FORMAT", "text"),
+		LogLevel:  parseLogLevel(getEnv("LOG_LEVEL", "info")),
+
+		RequestTimeout:     parsedRequestTimeout,
+		MaxBodyBytes:       parsedMaxBodyBytes,
+		CORSAllowedOrigins: parseList(getEnv("CORS_ALLOWED_ORIGINS", "")),
+		TrustedProxies:     parseTrustedProxies(getEnv("TRUSTED_PROXIES", "")),
+
+		LegacyRoutesSunset: parsedLegacyRoutesSunset,
+
+		AuthRequired: parsedAuthRequired,
+		JWTIssuers:   parseJWTIssuers(getEnv("JWT_ISSUERS", "")),
+		JWTAudience:  strings.TrimSpace(getEnv("JWT_AUDIENCE", "")),
+
+		RateLimitDefault: parseOptionalRateLimit("RATE_LIMIT_DEFAULT", getEnv("RATE_LIMIT_DEFAULT", "300/1m")),
+		RateLimitRoutes:  parseRouteRateLimits(getEnv("RATE_LIMIT_ROUTES", "POST /diceroll=60/1m:10,POST /person/generate=5/1m")),
+		RateLimitAuth:    parseOptionalRateLimit("RATE_LIMIT_AUTH_FAILURES", getEnv("RATE_LIMIT_AUTH_FAILURES", "10/1m")),
+
+		PersonRetention: parsedPersonRetention,
+		PurgeInterval:   parsedPurgeInterval,
+
+		ShutdownDrainDelay: parsedShutdownDrainDelay,
+
+		TracingExporter:    strings.ToLower(strings.TrimSpace(getEnv("TRACING_EXPORTER", "none"))),
+		TracingSampleRatio: parsedTracingSampleRatio,
+		OTLPEndpoint:       strings.TrimSpace(getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4318")),
+		OTLPHeaders:        parseHeaders(getEnv("OTEL_EXPORTER_OTLP_HEADERS", "")),
 	}
 }
 
@@ -53,3 +182,219 @@ func getEnv(key, fallback string) string {
 
 	return fallback
 }
+
+// the smallest dice limits that allow a roll, the same minimums domain.NewDiceLimits enforces
+const (
+	minDiceLimit  = 1
+	minSidesLimit = 2
+)
+
+// parseDiceProfiles parses a list of profiles in the format "name=maxDice:maxSides,other=maxDice:maxSides". Malformed entries, limits below the minimums and repeated names are logged and skipped, the first of a name applies.
+func parseDiceProfiles(raw string) map[string]DiceLimitConfig {
+	profiles := make(map[string]DiceLimitConfig)
+
+	for entry := range strings.SplitSeq(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, limits, found := strings.Cut(entry, "=")
+		maxDiceStr, maxSidesStr, foundSep := strings.Cut(limits, ":")
+		if !found || !foundSep || strings.TrimSpace(name) == "" {
+			slog.Warn("skipping malformed dice profile, expected name=maxDice:maxSides", "entry", entry)
+			continue
+		}
+
+		maxDice, errDice := strconv.ParseUint(strings.TrimSpace(maxDiceStr), 10, 0)
+		maxSides, errSides := strconv.ParseUint(strings.TrimSpace(maxSidesStr), 10, 0)
+		if errDice != nil || errSides != nil {
+			slog.Warn("skipping dice profile, limits must be positive integers", "entry", entry)
+			continue
+		}
+		if maxDice < minDiceLimit || maxSides < minSidesLimit {
+			slog.Warn("skipping dice profile, limits below the minimums", "entry", entry, "min_dice", minDiceLimit, "min_sides", minSidesLimit)
+			continue
+		}
+
+		name = strings.TrimSpace(name)
+		if _, taken := profiles[name]; taken {
+			slog.Warn("skipping repeated dice profile, the first one applies", "entry", entry)
+			continue
+		}
+		profiles[name] = DiceLimitConfig{
+			MaxDice:  uint(maxDice),
+			MaxSides: uint(maxSides),
+		}
+	}
+
+	return profiles
+}
+
+// parseProfileAssignments parses a list in the format "key=profile,...", keys are principals or, with routes set, route patterns. The last "=" splits an entry as issuers can be URLs. Malformed and repeated entries are logged and skipped.
+func parseProfileAssignments(raw string, routes bool) map[string]string {
+	assignments := make(map[string]string)
+
+	for _, entry := range parseList(raw) {
+		split := strings.LastIndex(entry, "=")
+		if split < 0 {
+			slog.Warn("skipping malformed dice profile assignment, expected key=profile", "entry", entry)
+			continue
+		}
+		key, profile := strings.TrimSpace(entry[:split]), strings.TrimSpace(entry[split+1:])
+		if routes {
+			key = strings.Join(strings.Fields(key), " ")
+		}
+		if key == "" || profile == "" {
+			slog.Warn("skipping malformed dice profile assignment, expected key=profile", "entry", entry)
+			continue
+		}
+		if _, taken := assignments[key]; taken {
+			slog.Warn("skipping repeated dice profile assignment, the first one applies", "entry", entry)
+			continue
+		}
+		assignments[key] = profile
+	}
+
+	return assignments
+}
+
+// parseLogLevel parses debug, info, warn or error (case insensitive). Anything else is logged and falls back to info.
+func parseLogLevel(raw string) slog.Level {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(raw))); err != nil {
+		slog.Warn("unknown log level, using info", "level", raw)
+		return slog.LevelInfo
+	}
+	return level
+}
+
+// parseList splits a comma separated list, dropping blank entries
+func parseList(raw string) []string {
+	var items []string
+	for item := range strings.SplitSeq(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseHeaders reads "name=value,name=value" as OTEL_EXPORTER_OTLP_HEADERS writes them, skipping entries without a name
+func parseHeaders(raw string) map[string]string {
+	headers := make(map[string]string)
+	for _, item := range parseList(raw) {
+		name, value, _ := strings.Cut(item, "=")
+		if name = strings.TrimSpace(name); name == "" {
+			slog.Warn("ignoring header without a name", "header", item)
+			continue
+		}
+		headers[name] = strings.TrimSpace(value)
+	}
+	return headers
+}
+
+// parseTrustedProxies parses a comma separated list of CIDRs or single IPs. Malformed entries are logged and skipped.
+func parseTrustedProxies(raw string) []netip.Prefix {
+	var prefixes []netip.Prefix
+
+	for _, entry := range parseList(raw) {
+		if prefix, err := netip.ParsePrefix(entry); err == nil {
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		if addr, err := netip.ParseAddr(entry); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		slog.Warn("skipping malformed trusted proxy, expected a CIDR or an IP", "entry", entry)
+	}
+
+	return prefixes
+}
+
+// parseJWTIssuers parses a list of issuers in the format "issuer=ALG:base64key,other=ALG:base64key". Keys may use standard or URL safe base64, padded or not. Malformed entries are logged and skipped.
+func parseJWTIssuers(raw string) []JWTIssuerConfig {
+	var issuers []JWTIssuerConfig
+
+	for _, entry := range parseList(raw) {
+		name, rest, found := strings.Cut(entry, "=")
+		algorithm, encodedKey, foundSep := strings.Cut(rest, ":")
+		name, algorithm, encodedKey = strings.TrimSpace(name), strings.TrimSpace(algorithm), strings.TrimSpace(encodedKey)
+		if !found || !foundSep || name == "" || algorithm == "" || encodedKey == "" {
+			slog.Warn("skipping malformed JWT issuer, expected issuer=ALG:base64key", "issuer", name)
+			continue
+		}
+
+		key, err := decodeBase64Key(encodedKey)
+		if err != nil {
+			// the entry holds a secret, only the issuer is logged
+			slog.Warn("skipping JWT issuer, the key is not valid base64", "issuer", name)
+			continue
+		}
+
+		issuers = append(issuers, JWTIssuerConfig{Name: name, Algorithm: algorithm, Key: key})
+	}
+
+	return issuers
+}
+
+func decodeBase64Key(encoded string) ([]byte, error) {
+	encoded = strings.TrimRight(encoded, "=")
+	if key, err := base64.RawStdEncoding.DecodeString(encoded); err == nil {
+		return key, nil
+	}
+	return base64.RawURLEncoding.DecodeString(encoded)
+}
+
+// parseRateLimit parses "requests/period" or "requests/period:burst", e.g. "60/1m:10"
+func parseRateLimit(raw string) (RateLimitConfig, bool) {
+	rate, burstStr, hasBurst := strings.Cut(strings.TrimSpace(raw), ":")
+	requestsStr, perStr, found := strings.Cut(rate, "/")
+	if !found {
+		return RateLimitConfig{}, false
+	}
+
+	requests, errRequests := strconv.Atoi(strings.TrimSpace(requestsStr))
+	per, errPer := time.ParseDuration(strings.TrimSpace(perStr))
+	burst := 0
+	var errBurst error
+	if hasBurst {
+		burst, errBurst = strconv.Atoi(strings.TrimSpace(burstStr))
+	}
+	if errRequests != nil || errPer != nil || errBurst != nil || requests <= 0 || per <= 0 || burst < 0 {
+		return RateLimitConfig{}, false
+	}
+
+	return RateLimitConfig{Requests: requests, Per: per, Burst: burst}, true
+}
+
+// parseOptionalRateLimit parses the budget set by the env variable name, empty means no limit. A malformed one is logged and ignored.
+func parseOptionalRateLimit(name, raw string) RateLimitConfig {
+	if strings.TrimSpace(raw) == "" {
+		return RateLimitConfig{}
+	}
+	limit, ok := parseRateLimit(raw)
+	if !ok {
+		slog.Warn("ignoring malformed rate limit, expected requests/period[:burst]", "name", name, "limit", raw)
+	}
+	return limit
+}
+
+// parseRouteRateLimits parses a list of budgets in the format "METHOD /path=requests/period[:burst],...". Malformed entries are logged and skipped.
+func parseRouteRateLimits(raw string) map[string]RateLimitConfig {
+	limits := make(map[string]RateLimitConfig)
+
+	for _, entry := range parseList(raw) {
+		pattern, rawLimit, found := strings.Cut(entry, "=")
+		limit, ok := parseRateLimit(rawLimit)
+		pattern = strings.Join(strings.Fields(pattern), " ")
+		if !found || !ok || pattern == "" {
+			slog.Warn("skipping malformed route rate limit, expected METHOD /path=requests/period[:burst]", "entry", entry)
+			continue
+		}
+		limits[pattern] = limit
+	}
+
+	return limits
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"fmt"
+	"louder/internal/core/domain"
+	"maps"
+	"testing"
+)
+
+func TestParseDiceProfiles(t *testing.T) {
+	tests := map[string]struct {
+		raw  string
+		want map[string]DiceLimitConfig
+	}{
+		"empty":           {raw: "", want: map[string]DiceLimitConfig{}},
+		"two profiles":    {raw: "dnd=8:20, pathfinder = 12:100", want: map[string]DiceLimitConfig{"dnd": {8, 20}, "pathfinder": {12, 100}}},
+		"no name":         {raw: "=8:20,dnd=8:20", want: map[string]DiceLimitConfig{"dnd": {8, 20}}},
+		"no separator":    {raw: "dnd=8,dnd20", want: map[string]DiceLimitConfig{}},
+		"not numbers":     {raw: "dnd=eight:20,neg=-1:6", want: map[string]DiceLimitConfig{}},
+		"zero dice":       {raw: "dnd=0:20,ok=1:6", want: map[string]DiceLimitConfig{"ok": {1, 6}}},
+		"zero sides":      {raw: "dnd=8:0", want: map[string]DiceLimitConfig{}},
+		"one sided dice":  {raw: "coin=1:1,ok=1:2", want: map[string]DiceLimitConfig{"ok": {1, 2}}},
+		"first name wins": {raw: "dnd=8:20,dnd=100:100", want: map[string]DiceLimitConfig{"dnd": {8, 20}}},
+		"blank entries":   {raw: " , dnd=8:20,,", want: map[string]DiceLimitConfig{"dnd": {8, 20}}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := parseDiceProfiles(tc.raw); !maps.Equal(got, tc.want) {
+				t.Errorf("parseDiceProfiles(%q) = %v, want %v", tc.raw, got, tc.want)
+			}
+		})
+	}
+}
+
+// every profile parseDiceProfiles keeps must be one the domain accepts, and the other way round
+func TestParseDiceProfilesMatchesDomainMinimums(t *testing.T) {
+	for maxDice := range uint(4) {
+		for maxSides := range uint(4) {
+			raw := fmt.Sprintf("p=%d:%d", maxDice, maxSides)
+			_, kept := parseDiceProfiles(raw)["p"]
+			_, err := domain.NewDiceLimits(maxDice, maxSides)
+			if kept != (err == nil) {
+				t.Errorf("%s: kept by config %v, domain error %v", raw, kept, err)
+			}
+		}
+	}
+}
+
+func TestParseProfileAssignments(t *testing.T) {
+	tests := map[string]struct {
+		raw    string
+		routes bool
+		want   map[string]string
+	}{
+		"principals":            {raw: "louder/key-1=dnd,https://id.example.com/=user=7=pathfinder", want: map[string]string{"louder/key-1": "dnd", "https://id.example.com/=user=7": "pathfinder"}},
+		"route spaces squeezed": {raw: "POST   /diceroll = dnd", routes: true, want: map[string]string{"POST /diceroll": "dnd"}},
+		"malformed":             {raw: "louder/key-1,=dnd,louder/key-2=", want: map[string]string{}},
+		"first one wins":        {raw: "louder/key-1=dnd,louder/key-1=pathfinder", want: map[string]string{"louder/key-1": "dnd"}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := parseProfileAssignments(tc.raw, tc.routes); !maps.Equal(got, tc.want) {
+				t.Errorf("parseProfileAssignments(%q) = %v, want %v", tc.raw, got, tc.want)
+			}
+		})
+	}
+}
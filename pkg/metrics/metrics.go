@@ -0,0 +1,378 @@
+// Package metrics is a small Prometheus client: counters, gauges and histograms with labels, written in the text exposition format (version 0.0.4). Like expvar, instrumented packages declare their metrics on the Default registry as package variables, and the registry is served at /metrics.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// ContentType is the text exposition format Prometheus scrapes
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefBuckets suit request latencies in seconds, from 5ms to 10s
+var DefBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+var (
+	metricNameRE = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	labelNameRE  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// Default is the registry the app's metrics live on
+var Default = NewRegistry()
+
+// Registry holds metrics by name and writes them all out, sorted by name
+type Registry struct {
+	mu      sync.Mutex
+	metrics map[string]metric
+}
+
+func NewRegistry() *Registry {
+	return &Registry{metrics: make(map[string]metric)}
+}
+
+// metric is one metric family, all the series sharing a name
+type metric interface {
+	describe() *desc
+	writeSeries(w *bufio.Writer)
+}
+
+type desc struct {
+	name       string
+	help       string
+	kind       string // counter, gauge or histogram
+	labelNames []string
+}
+
+func (d *desc) describe() *desc { return d }
+
+// register adds m, a name that is malformed or already taken is a programming error and panics like expvar.Publish does
+func (r *Registry) register(m metric) {
+	d := m.describe()
+	if !metricNameRE.MatchString(d.name) {
+		panic("metrics: invalid metric name " + strconv.Quote(d.name))
+	}
+	for _, l := range d.labelNames {
+		if !labelNameRE.MatchString(l) || strings.HasPrefix(l, "__") || l == "le" {
+			panic(fmt.Sprintf("metrics: invalid label name %q on %s", l, d.name))
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, taken := r.metrics[d.name]; taken {
+		panic("metrics: duplicate metric " + d.name)
+	}
+	r.metrics[d.name] = m
+}
+
+// WriteTo writes every metric in the text exposition format
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	names := make([]string, 0, len(r.metrics))
+	for name := range r.metrics {
+		names = append(names, name)
+	}
+	families := make([]metric, 0, len(names))
+	slices.Sort(names)
+	for _, name := range names {
+		families = append(families, r.metrics[name])
+	}
+	r.mu.Unlock()
+
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+	for _, m := range families {
+		d := m.describe()
+		fmt.Fprintf(bw, "# HELP %s %s\n# TYPE %s %s\n", d.name, escapeHelp(d.help), d.name, d.kind)
+		m.writeSeries(bw)
+	}
+	err := bw.Flush()
+	return cw.n, err
+}
+
+// ServeHTTP serves the registry for Prometheus to scrape
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", ContentType)
+	r.WriteTo(w)
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+// series holds the children of a vector by their label values
+type series[T any] struct {
+	mu       sync.RWMutex
+	children map[string]*child[T]
+}
+
+type child[T any] struct {
+	labelValues []string
+	value       T
+}
+
+// get returns the child for the label values, creating it with newValue the first time
+func (s *series[T]) get(d *desc, labelValues []string, newValue func() T) T {
+	if len(labelValues) != len(d.labelNames) {
+		panic(fmt.Sprintf("metrics: %s has %d labels, got %d values", d.name, len(d.labelNames), len(labelValues)))
+	}
+	key := strings.Join(labelValues, "\xff")
+
+	s.mu.RLock()
+	c, ok := s.children[key]
+	s.mu.RUnlock()
+	if ok {
+		return c.value
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c, ok := s.children[key]; ok {
+		return c.value
+	}
+	if s.children == nil {
+		s.children = make(map[string]*child[T])
+	}
+	c = &child[T]{labelValues: slices.Clone(labelValues), value: newValue()}
+	s.children[key] = c
+	return c.value
+}
+
+// sorted returns the children ordered by label values so the output is stable
+func (s *series[T]) sorted() []*child[T] {
+	s.mu.RLock()
+	children := make([]*child[T], 0, len(s.children))
+	for _, c := range s.children {
+		children = append(children, c)
+	}
+	s.mu.RUnlock()
+
+	slices.SortFunc(children, func(a, b *child[T]) int { return slices.Compare(a.labelValues, b.labelValues) })
+	return children
+}
+
+// value is a float64 updated atomically
+type value struct {
+	bits atomic.Uint64
+}
+
+func (v *value) add(delta float64) {
+	for {
+		old := v.bits.Load()
+		if v.bits.CompareAndSwap(old, math.Float64bits(math.Float64frombits(old)+delta)) {
+			return
+		}
+	}
+}
+
+func (v *value) set(f float64) { v.bits.Store(math.Float64bits(f)) }
+func (v *value) get() float64  { return math.Float64frombits(v.bits.Load()) }
+
+// Counter only goes up
+type Counter struct{ v value }
+
+func (c *Counter) Inc() { c.v.add(1) }
+
+// Add panics on negative deltas, counters never go down
+func (c *Counter) Add(delta float64) {
+	if delta < 0 {
+		panic("metrics: counters cannot decrease")
+	}
+	c.v.add(delta)
+}
+
+func (c *Counter) Value() float64 { return c.v.get() }
+
+// CounterVec is a counter per combination of label values
+type CounterVec struct {
+	desc
+	series series[*Counter]
+}
+
+func (r *Registry) NewCounterVec(name, help string, labelNames ...string) *CounterVec {
+	cv := &CounterVec{desc: desc{name: name, help: help, kind: "counter", labelNames: labelNames}}
+	r.register(cv)
+	return cv
+}
+
+// With returns the counter for the label values, given in the order the label names were
+func (cv *CounterVec) With(labelValues ...string) *Counter {
+	return cv.series.get(&cv.desc, labelValues, func() *Counter { return &Counter{} })
+}
+
+func (cv *CounterVec) writeSeries(w *bufio.Writer) {
+	for _, c := range cv.series.sorted() {
+		writeSample(w, cv.name, cv.labelNames, c.labelValues, "", "", c.value.Value())
+	}
+}
+
+// Gauge goes up and down
+type Gauge struct{ v value }
+
+func (g *Gauge) Set(f float64)     { g.v.set(f) }
+func (g *Gauge) Add(delta float64) { g.v.add(delta) }
+func (g *Gauge) Inc()              { g.v.add(1) }
+func (g *Gauge) Dec()              { g.v.add(-1) }
+func (g *Gauge) Value() float64    { return g.v.get() }
+
+// GaugeVec is a gauge per combination of label values
+type GaugeVec struct {
+	desc
+	series series[*Gauge]
+}
+
+func (r *Registry) NewGaugeVec(name, help string, labelNames ...string) *GaugeVec {
+	gv := &GaugeVec{desc: desc{name: name, help: help, kind: "gauge", labelNames: labelNames}}
+	r.register(gv)
+	return gv
+}
+
+func (gv *GaugeVec) With(labelValues ...string) *Gauge {
+	return gv.series.get(&gv.desc, labelValues, func() *Gauge { return &Gauge{} })
+}
+
+func (gv *GaugeVec) writeSeries(w *bufio.Writer) {
+	for _, c := range gv.series.sorted() {
+		writeSample(w, gv.name, gv.labelNames, c.labelValues, "", "", c.value.Value())
+	}
+}
+
+// funcMetric reads its value when scraped, for numbers kept elsewhere such as database/sql pool stats
+type funcMetric struct {
+	desc
+	fn func() float64
+}
+
+// NewGaugeFunc registers a gauge whose value is fn's at scrape time
+func (r *Registry) NewGaugeFunc(name, help string, fn func() float64) {
+	r.register(&funcMetric{desc: desc{name: name, help: help, kind: "gauge"}, fn: fn})
+}
+
+// NewCounterFunc registers a counter whose value is fn's at scrape time, fn must never go down
+func (r *Registry) NewCounterFunc(name, help string, fn func() float64) {
+	r.register(&funcMetric{desc: desc{name: name, help: help, kind: "counter"}, fn: fn})
+}
+
+func (fm *funcMetric) writeSeries(w *bufio.Writer) {
+	writeSample(w, fm.name, nil, nil, "", "", fm.fn())
+}
+
+// Histogram counts observations into cumulative buckets
+type Histogram struct {
+	upperBounds []float64
+	mu          sync.Mutex
+	counts      []uint64 // per bucket, not cumulative, the last one is +Inf
+	sum         float64
+	count       uint64
+}
+
+func (h *Histogram) Observe(v float64) {
+	i, _ := slices.BinarySearch(h.upperBounds, v) // buckets are le, v == bound falls in that bucket
+
+	h.mu.Lock()
+	h.counts[i]++
+	h.sum += v
+	h.count++
+	h.mu.Unlock()
+}
+
+// HistogramVec is a histogram per combination of label values, all with the same buckets
+type HistogramVec struct {
+	desc
+	buckets []float64
+	series  series[*Histogram]
+}
+
+// NewHistogramVec registers a histogram, buckets are upper bounds in increasing order without +Inf, which is always added
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	if len(buckets) == 0 || !slices.IsSorted(buckets) || slices.Contains(buckets, math.Inf(1)) {
+		panic("metrics: histogram buckets of " + name + " must be increasing and without +Inf")
+	}
+	hv := &HistogramVec{desc: desc{name: name, help: help, kind: "histogram", labelNames: labelNames}, buckets: slices.Clone(buckets)}
+	r.register(hv)
+	return hv
+}
+
+func (hv *HistogramVec) With(labelValues ...string) *Histogram {
+	return hv.series.get(&hv.desc, labelValues, func() *Histogram {
+		return &Histogram{upperBounds: hv.buckets, counts: make([]uint64, len(hv.buckets)+1)}
+	})
+}
+
+func (hv *HistogramVec) writeSeries(w *bufio.Writer) {
+	for _, c := range hv.series.sorted() {
+		h := c.value
+		h.mu.Lock()
+		counts, sum, count := slices.Clone(h.counts), h.sum, h.count
+		h.mu.Unlock()
+
+		var cumulative uint64
+		for i, bound := range hv.buckets {
+			cumulative += counts[i]
+			writeSample(w, hv.name+"_bucket", hv.labelNames, c.labelValues, "le", formatFloat(bound), float64(cumulative))
+		}
+		writeSample(w, hv.name+"_bucket", hv.labelNames, c.labelValues, "le", "+Inf", float64(count))
+		writeSample(w, hv.name+"_sum", hv.labelNames, c.labelValues, "", "", sum)
+		writeSample(w, hv.name+"_count", hv.labelNames, c.labelValues, "", "", float64(count))
+	}
+}
+
+// writeSample writes one line, name{labels} value, with an extra label for histogram buckets
+func writeSample(w *bufio.Writer, name string, labelNames, labelValues []string, extraName, extraValue string, v float64) {
+	w.WriteString(name)
+	if len(labelNames) > 0 || extraName != "" {
+		w.WriteByte('{')
+		for i, l := range labelNames {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			w.WriteString(l + `="` + escapeLabelValue(labelValues[i]) + `"`)
+		}
+		if extraName != "" {
+			if len(labelNames) > 0 {
+				w.WriteByte(',')
+			}
+			w.WriteString(extraName + `="` + extraValue + `"`)
+		}
+		w.WriteByte('}')
+	}
+	w.WriteByte(' ')
+	w.WriteString(formatFloat(v))
+	w.WriteByte('\n')
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string       { return helpEscaper.Replace(s) }
+func escapeLabelValue(s string) string { return labelEscaper.Replace(s) }
@@ -0,0 +1,89 @@
+package metrics_test
+
+import (
+	"louder/pkg/metrics"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRegistryExposition(t *testing.T) {
+	reg := metrics.NewRegistry()
+
+	requests := reg.NewCounterVec("app_requests_total", "Requests served.", "route", "status")
+	requests.With("/b", "200").Add(2)
+	requests.With("/a", "500").Inc()
+	requests.With("/a", "200").Inc()
+
+	reg.NewGaugeVec("app_in_flight", "Requests\nin flight.").With().Set(3)
+	reg.NewGaugeFunc("app_pool_open", `Open "connections" \ pool.`, func() float64 { return 1.5 })
+
+	latency := reg.NewHistogramVec("app_latency_seconds", "Latency.", []float64{0.1, 1}, "route")
+	for _, v := range []float64{0.05, 0.1, 0.5, 2} {
+		latency.With(`say "hi"\n`).Observe(v)
+	}
+
+	want := `# HELP app_in_flight Requests\nin flight.
+# TYPE app_in_flight gauge
+app_in_flight 3
+# HELP app_latency_seconds Latency.
+# TYPE app_latency_seconds histogram
+app_latency_seconds_bucket{route="say \"hi\"\\n",le="0.1"} 2
+app_latency_seconds_bucket{route="say \"hi\"\\n",le="1"} 3
+app_latency_seconds_bucket{route="say \"hi\"\\n",le="+Inf"} 4
+app_latency_seconds_sum{route="say \"hi\"\\n"} 2.65
+app_latency_seconds_count{route="say \"hi\"\\n"} 4
+# HELP app_pool_open Open "connections" \\ pool.
+# TYPE app_pool_open gauge
+app_pool_open 1.5
+# HELP app_requests_total Requests served.
+# TYPE app_requests_total counter
+app_requests_total{route="/a",status="200"} 1
+app_requests_total{route="/a",status="500"} 1
+app_requests_total{route="/b",status="200"} 2
+`
+
+	rec := httptest.NewRecorder()
+	reg.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if got := rec.Header().Get("Content-Type"); got != metrics.ContentType {
+		t.Errorf("Content-Type = %q, want %q", got, metrics.ContentType)
+	}
+	if got := rec.Body.String(); got != want {
+		t.Errorf("exposition mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRegistryRejectsMistakes(t *testing.T) {
+	tests := map[string]func(reg *metrics.Registry){
+		"duplicate name":     func(reg *metrics.Registry) { reg.NewCounterVec("dup", "", "a"); reg.NewGaugeVec("dup", "") },
+		"invalid name":       func(reg *metrics.Registry) { reg.NewCounterVec("no-dashes", "") },
+		"reserved label":     func(reg *metrics.Registry) { reg.NewHistogramVec("h", "", metrics.DefBuckets, "le") },
+		"unsorted buckets":   func(reg *metrics.Registry) { reg.NewHistogramVec("h", "", []float64{1, 0.5}) },
+		"wrong label count":  func(reg *metrics.Registry) { reg.NewCounterVec("c", "", "a", "b").With("x") },
+		"negative increment": func(reg *metrics.Registry) { reg.NewCounterVec("c", "").With().Add(-1) },
+	}
+
+	for name, register := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected a panic")
+				}
+			}()
+			register(metrics.NewRegistry())
+		})
+	}
+}
+
+func TestHistogramBoundaryCountsInBucket(t *testing.T) {
+	reg := metrics.NewRegistry()
+	reg.NewHistogramVec("h", "", []float64{1}).With().Observe(1)
+
+	var sb strings.Builder
+	reg.WriteTo(&sb)
+	if !strings.Contains(sb.String(), `h_bucket{le="1"} 1`) {
+		t.Errorf("an observation equal to a bound belongs to its bucket, got:\n%s", sb.String())
+	}
+}
@@ -0,0 +1,237 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Batch is the finished spans of one export, with the resource they come from
+type Batch struct {
+	Resource []Attr
+	Spans    []SpanData
+}
+
+// Exporter sends finished spans somewhere. The provider calls Export from one goroutine at a time.
+type Exporter interface {
+	Export(ctx context.Context, batch Batch) error
+	Shutdown(ctx context.Context) error
+}
+
+// scopeName is the instrumentation scope every span is reported under
+const scopeName = "louder"
+
+// OTLPExporter posts spans to an OpenTelemetry collector with OTLP/HTTP, JSON encoded
+type OTLPExporter struct {
+	endpoint string
+	headers  map[string]string
+	client   *http.Client
+}
+
+// NewOTLPExporter sends spans to endpoint, the full URL of the traces path such as http://localhost:4318/v1/traces. headers go with every request, for the collector's credentials.
+func NewOTLPExporter(endpoint string, headers map[string]string) *OTLPExporter {
+	return &OTLPExporter{
+		endpoint: endpoint,
+		headers:  headers,
+		client:   &http.Client{Timeout: defaultExportTimeout},
+	}
+}
+
+func (e *OTLPExporter) Export(ctx context.Context, batch Batch) error {
+	body, err := json.Marshal(otlpRequest(batch))
+	if err != nil {
+		return fmt.Errorf("encoding spans: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("building OTLP request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending spans to %s: %w", e.endpoint, err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("collector at %s answered %s", e.endpoint, resp.Status)
+	}
+	return nil
+}
+
+func (e *OTLPExporter) Shutdown(context.Context) error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+// the OTLP/HTTP JSON encoding, https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding: IDs in hex, 64 bit integers as strings
+type (
+	otlpTraces struct {
+		ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+	}
+	otlpResourceSpans struct {
+		Resource   otlpResource     `json:"resource"`
+		ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+	}
+	otlpResource struct {
+		Attributes []otlpKeyValue `json:"attributes"`
+	}
+	otlpScopeSpans struct {
+		Scope otlpScope  `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+	otlpScope struct {
+		Name string `json:"name"`
+	}
+	otlpSpan struct {
+		TraceID           string         `json:"traceId"`
+		SpanID            string         `json:"spanId"`
+		ParentSpanID      string         `json:"parentSpanId,omitempty"`
+		Name              string         `json:"name"`
+		Kind              Kind           `json:"kind"`
+		StartTimeUnixNano string         `json:"startTimeUnixNano"`
+		EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+		Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+		Status            otlpStatus     `json:"status"`
+	}
+	otlpStatus struct {
+		Code    StatusCode `json:"code,omitempty"`
+		Message string     `json:"message,omitempty"`
+	}
+	otlpKeyValue struct {
+		Key   string    `json:"key"`
+		Value otlpValue `json:"value"`
+	}
+	otlpValue struct {
+		StringValue *string  `json:"stringValue,omitempty"`
+		IntValue    *string  `json:"intValue,omitempty"`
+		DoubleValue *float64 `json:"doubleValue,omitempty"`
+		BoolValue   *bool    `json:"boolValue,omitempty"`
+	}
+)
+
+func otlpRequest(batch Batch) otlpTraces {
+	spans := make([]otlpSpan, len(batch.Spans))
+	for i, s := range batch.Spans {
+		spans[i] = otlpSpan{
+			TraceID:           s.SpanContext.TraceID.String(),
+			SpanID:            s.SpanContext.SpanID.String(),
+			Name:              s.Name,
+			Kind:              s.Kind,
+			StartTimeUnixNano: unixNano(s.Start),
+			EndTimeUnixNano:   unixNano(s.End),
+			Attributes:        otlpAttributes(s.Attrs),
+			Status:            otlpStatus{Code: s.Status, Message: s.StatusMessage},
+		}
+		if s.Parent.IsValid() {
+			spans[i].ParentSpanID = s.Parent.String()
+		}
+	}
+
+	return otlpTraces{ResourceSpans: []otlpResourceSpans{{
+		Resource:   otlpResource{Attributes: otlpAttributes(batch.Resource)},
+		ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: scopeName}, Spans: spans}},
+	}}}
+}
+
+func otlpAttributes(attrs []Attr) []otlpKeyValue {
+	kvs := make([]otlpKeyValue, 0, len(attrs))
+	for _, a := range attrs {
+		var v otlpValue
+		switch value := a.Value.(type) {
+		case string:
+			v.StringValue = &value
+		case int64:
+			s := strconv.FormatInt(value, 10)
+			v.IntValue = &s
+		case float64:
+			v.DoubleValue = &value
+		case bool:
+			v.BoolValue = &value
+		default:
+			s := fmt.Sprint(value)
+			v.StringValue = &s
+		}
+		kvs = append(kvs, otlpKeyValue{Key: a.Key, Value: v})
+	}
+	return kvs
+}
+
+func unixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+// StdoutExporter writes each span as a line of JSON, for local runs without a collector
+type StdoutExporter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func NewStdoutExporter(w io.Writer) *StdoutExporter {
+	return &StdoutExporter{enc: json.NewEncoder(w)}
+}
+
+type stdoutSpan struct {
+	TraceID  string         `json:"trace_id"`
+	SpanID   string         `json:"span_id"`
+	ParentID string         `json:"parent_id,omitempty"`
+	Name     string         `json:"name"`
+	Kind     string         `json:"kind"`
+	Start    time.Time      `json:"start"`
+	Duration string         `json:"duration"`
+	Attrs    map[string]any `json:"attributes,omitempty"`
+	Status   string         `json:"status,omitempty"`
+	Error    string         `json:"error,omitempty"`
+}
+
+func (e *StdoutExporter) Export(_ context.Context, batch Batch) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, s := range batch.Spans {
+		line := stdoutSpan{
+			TraceID:  s.SpanContext.TraceID.String(),
+			SpanID:   s.SpanContext.SpanID.String(),
+			Name:     s.Name,
+			Kind:     s.Kind.String(),
+			Start:    s.Start,
+			Duration: s.End.Sub(s.Start).String(),
+			Error:    s.StatusMessage,
+		}
+		if s.Parent.IsValid() {
+			line.ParentID = s.Parent.String()
+		}
+		if len(s.Attrs) > 0 {
+			line.Attrs = make(map[string]any, len(s.Attrs))
+			for _, a := range s.Attrs {
+				line.Attrs[a.Key] = a.Value
+			}
+		}
+		switch s.Status {
+		case StatusOK:
+			line.Status = "ok"
+		case StatusError:
+			line.Status = "error"
+		}
+		if err := e.enc.Encode(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *StdoutExporter) Shutdown(context.Context) error {
+	return nil
+}
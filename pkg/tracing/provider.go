@@ -0,0 +1,223 @@
+package tracing
+
+import (
+	"context"
+	"encoding/binary"
+	"log/slog"
+	"math"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// defaults for the zero values of Options
+const (
+	defaultQueueSize     = 2048
+	defaultBatchSize     = 512
+	defaultFlushInterval = 5 * time.Second
+	defaultExportTimeout = 10 * time.Second
+)
+
+// Options tunes a Provider, zero values pick the defaults
+type Options struct {
+	Resource      []Attr        // describes the process, service.name at least
+	SampleRatio   float64       // share of new traces recorded, traces started elsewhere follow the caller's choice
+	QueueSize     int           // finished spans waiting for export, more are dropped
+	BatchSize     int           // spans per export
+	FlushInterval time.Duration // longest a span waits before export
+}
+
+// Provider samples spans and exports the finished ones in batches from a goroutine of its own
+type Provider struct {
+	exporter Exporter
+	options  Options
+	// new traces are recorded when the low 8 bytes of their ID, read as a number, are under this bound, so every process agrees on a trace
+	sampleBound uint64
+
+	queue     chan SpanData
+	flush     chan chan struct{}
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+
+	dropMu  sync.Mutex
+	dropped uint64 // spans lost to a full queue since the last export
+}
+
+// NewProvider starts exporting spans to exporter, Shutdown stops it
+func NewProvider(exporter Exporter, options Options) *Provider {
+	if options.QueueSize <= 0 {
+		options.QueueSize = defaultQueueSize
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = defaultBatchSize
+	}
+	if options.FlushInterval <= 0 {
+		options.FlushInterval = defaultFlushInterval
+	}
+
+	p := &Provider{
+		exporter:    exporter,
+		options:     options,
+		sampleBound: sampleBound(options.SampleRatio),
+		queue:       make(chan SpanData, options.QueueSize),
+		flush:       make(chan chan struct{}),
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+	go p.run()
+	return p
+}
+
+func sampleBound(ratio float64) uint64 {
+	switch {
+	case ratio <= 0:
+		return 0
+	case ratio >= 1:
+		return math.MaxUint64
+	}
+	return uint64(ratio * math.MaxUint64)
+}
+
+func (p *Provider) newSpan(parent SpanContext, kind Kind, name string, attrs []Attr) *Span {
+	sc := SpanContext{SpanID: newSpanID()}
+	if parent.IsValid() {
+		sc.TraceID = parent.TraceID
+		sc.Sampled = parent.Sampled
+	} else {
+		sc.TraceID = newTraceID()
+		sc.Sampled = p.sampleBound == math.MaxUint64 || binary.BigEndian.Uint64(sc.TraceID[8:]) < p.sampleBound
+	}
+
+	span := &Span{provider: p, recording: sc.Sampled}
+	span.data = SpanData{Name: name, Kind: kind, SpanContext: sc, Start: time.Now()}
+	if parent.IsValid() {
+		span.data.Parent = parent.SpanID
+	}
+	if sc.Sampled {
+		span.data.Attrs = append([]Attr(nil), attrs...)
+	}
+	return span
+}
+
+func newTraceID() TraceID {
+	var id TraceID
+	for !id.IsValid() {
+		binary.BigEndian.PutUint64(id[:8], rand.Uint64())
+		binary.BigEndian.PutUint64(id[8:], rand.Uint64())
+	}
+	return id
+}
+
+func newSpanID() SpanID {
+	var id SpanID
+	for !id.IsValid() {
+		binary.BigEndian.PutUint64(id[:], rand.Uint64())
+	}
+	return id
+}
+
+// enqueue hands a finished span to the export loop, dropping it rather than blocking the request when the queue is full
+func (p *Provider) enqueue(data SpanData) {
+	select {
+	case p.queue <- data:
+	default:
+		p.dropMu.Lock()
+		p.dropped++
+		p.dropMu.Unlock()
+	}
+}
+
+func (p *Provider) run() {
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.options.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]SpanData, 0, p.options.BatchSize)
+	export := func() {
+		p.reportDropped()
+		if len(batch) == 0 {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), defaultExportTimeout)
+		defer cancel()
+		if err := p.exporter.Export(ctx, Batch{Resource: p.options.Resource, Spans: batch}); err != nil {
+			slog.Warn("tracing: exporting spans failed", "spans", len(batch), "err", err)
+		}
+		batch = make([]SpanData, 0, p.options.BatchSize)
+	}
+	// drain takes what's queued right now, without waiting for more
+	drain := func() {
+		for {
+			select {
+			case data := <-p.queue:
+				batch = append(batch, data)
+				if len(batch) == p.options.BatchSize {
+					export()
+				}
+			default:
+				return
+			}
+		}
+	}
+
+	for {
+		select {
+		case data := <-p.queue:
+			batch = append(batch, data)
+			if len(batch) == p.options.BatchSize {
+				export()
+			}
+		case <-ticker.C:
+			export()
+		case flushed := <-p.flush:
+			drain()
+			export()
+			close(flushed)
+		case <-p.stop:
+			drain()
+			export()
+			return
+		}
+	}
+}
+
+func (p *Provider) reportDropped() {
+	p.dropMu.Lock()
+	dropped := p.dropped
+	p.dropped = 0
+	p.dropMu.Unlock()
+	if dropped > 0 {
+		slog.Warn("tracing: export queue full, spans dropped", "spans", dropped)
+	}
+}
+
+// ForceFlush exports every span finished so far
+func (p *Provider) ForceFlush(ctx context.Context) error {
+	flushed := make(chan struct{})
+	select {
+	case p.flush <- flushed:
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-flushed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Shutdown exports the spans still queued and shuts the exporter down. Spans ended afterwards are dropped.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	p.closeOnce.Do(func() { close(p.stop) })
+	select {
+	case <-p.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return p.exporter.Shutdown(ctx)
+}
@@ -0,0 +1,127 @@
+package tracing
+
+import (
+	"sync"
+	"time"
+)
+
+// Kind says what a span does, the values are OTLP's
+type Kind int
+
+const (
+	KindInternal Kind = 1
+	KindServer   Kind = 2
+	KindClient   Kind = 3
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindServer:
+		return "server"
+	case KindClient:
+		return "client"
+	}
+	return "internal"
+}
+
+// StatusCode is the outcome of a span, the values are OTLP's
+type StatusCode int
+
+const (
+	StatusUnset StatusCode = 0
+	StatusOK    StatusCode = 1
+	StatusError StatusCode = 2
+)
+
+// Attr is a span attribute, Value is a string, int64, float64 or bool
+type Attr struct {
+	Key   string
+	Value any
+}
+
+func String(key, value string) Attr      { return Attr{Key: key, Value: value} }
+func Int(key string, value int) Attr     { return Attr{Key: key, Value: int64(value)} }
+func Int64(key string, value int64) Attr { return Attr{Key: key, Value: value} }
+func Float64(key string, v float64) Attr { return Attr{Key: key, Value: v} }
+func Bool(key string, value bool) Attr   { return Attr{Key: key, Value: value} }
+
+// SpanData is a finished span as exporters see it
+type SpanData struct {
+	Name          string
+	Kind          Kind
+	SpanContext   SpanContext
+	Parent        SpanID // zero for a root span
+	Start         time.Time
+	End           time.Time
+	Attrs         []Attr
+	Status        StatusCode
+	StatusMessage string
+}
+
+// Span is an operation being timed. A nil span is valid and does nothing, so callers never need to check whether tracing is on.
+type Span struct {
+	provider  *Provider
+	recording bool // false when the trace isn't sampled, the span only carries the context on
+
+	mu    sync.Mutex
+	data  SpanData
+	ended bool
+}
+
+func (s *Span) SpanContext() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return s.data.SpanContext // never changes once started
+}
+
+func (s *Span) SetAttributes(attrs ...Attr) {
+	if s == nil || !s.recording {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.ended {
+		s.data.Attrs = append(s.data.Attrs, attrs...)
+	}
+}
+
+// SetStatus sets the outcome, an error status keeps its message
+func (s *Span) SetStatus(code StatusCode, message string) {
+	if s == nil || !s.recording {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.ended {
+		s.data.Status = code
+		if code == StatusError {
+			s.data.StatusMessage = message
+		}
+	}
+}
+
+// RecordError marks the span as failed with err, a nil err changes nothing
+func (s *Span) RecordError(err error) {
+	if err != nil {
+		s.SetStatus(StatusError, err.Error())
+	}
+}
+
+// End finishes the span and queues it for export, later calls do nothing
+func (s *Span) End() {
+	if s == nil || !s.recording {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.data.End = time.Now()
+	data := s.data
+	s.mu.Unlock()
+
+	s.provider.enqueue(data)
+}
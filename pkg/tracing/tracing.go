@@ -0,0 +1,146 @@
+// Package tracing records distributed traces: spans with W3C Trace Context propagation (traceparent), batched and sent to an exporter such as an OTLP/HTTP collector or stdout. It follows OpenTelemetry's model and wire formats without pulling in its SDK.
+//
+// Nothing is recorded until SetProvider is called, Start then returns nil spans whose methods do nothing.
+package tracing
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// TraceparentHeader carries the W3C trace context, https://www.w3.org/TR/trace-context/
+const TraceparentHeader = "traceparent"
+
+// TraceID identifies a whole trace, all zeros is invalid
+type TraceID [16]byte
+
+func (t TraceID) IsValid() bool  { return t != TraceID{} }
+func (t TraceID) String() string { return hex.EncodeToString(t[:]) }
+
+// SpanID identifies a span within its trace, all zeros is invalid
+type SpanID [8]byte
+
+func (s SpanID) IsValid() bool  { return s != SpanID{} }
+func (s SpanID) String() string { return hex.EncodeToString(s[:]) }
+
+// SpanContext is what crosses process boundaries: the trace, the span and whether the trace is sampled
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+	Remote  bool // it came from another process
+}
+
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// Traceparent formats sc as a version 00 traceparent header value
+func (sc SpanContext) Traceparent() string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return "00-" + sc.TraceID.String() + "-" + sc.SpanID.String() + "-" + flags
+}
+
+var ErrInvalidTraceparent = errors.New("invalid traceparent")
+
+// ParseTraceparent reads a traceparent header value. Versions after 00 are read as 00 as the spec asks, ignoring anything they add at the end.
+func ParseTraceparent(s string) (SpanContext, error) {
+	s = strings.TrimSpace(s)
+	// version-traceid-spanid-flags, 2+1+32+1+16+1+2
+	if len(s) < 55 || (len(s) > 55 && (s[:2] == "00" || s[55] != '-')) {
+		return SpanContext{}, ErrInvalidTraceparent
+	}
+	if s[2] != '-' || s[35] != '-' || s[52] != '-' {
+		return SpanContext{}, ErrInvalidTraceparent
+	}
+
+	var version, flags [1]byte
+	var sc SpanContext
+	for _, part := range []struct {
+		dst []byte
+		src string
+	}{
+		{version[:], s[0:2]},
+		{sc.TraceID[:], s[3:35]},
+		{sc.SpanID[:], s[36:52]},
+		{flags[:], s[53:55]},
+	} {
+		// upper case hex is not allowed
+		if strings.ToLower(part.src) != part.src {
+			return SpanContext{}, ErrInvalidTraceparent
+		}
+		if _, err := hex.Decode(part.dst, []byte(part.src)); err != nil {
+			return SpanContext{}, ErrInvalidTraceparent
+		}
+	}
+	if version[0] == 0xff || !sc.IsValid() {
+		return SpanContext{}, ErrInvalidTraceparent
+	}
+
+	sc.Sampled = flags[0]&0x01 == 1
+	sc.Remote = true
+	return sc, nil
+}
+
+type spanKey struct{}
+type remoteKey struct{}
+
+// SpanFromContext returns the span started last in ctx, nil when there is none
+func SpanFromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanKey{}).(*Span)
+	return span
+}
+
+// SpanContextFromContext returns the context of the current span, or of the remote parent when no span was started here yet
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	if span := SpanFromContext(ctx); span != nil {
+		return span.SpanContext()
+	}
+	sc, _ := ctx.Value(remoteKey{}).(SpanContext)
+	return sc
+}
+
+// Extract reads the traceparent sent by the caller, the next span started in the returned context becomes its child. A missing or malformed header leaves ctx as it is.
+func Extract(ctx context.Context, header http.Header) context.Context {
+	sc, err := ParseTraceparent(header.Get(TraceparentHeader))
+	if err != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, remoteKey{}, sc)
+}
+
+// Inject sets traceparent on outbound requests so the callee joins the trace
+func Inject(ctx context.Context, header http.Header) {
+	if sc := SpanContextFromContext(ctx); sc.IsValid() {
+		header.Set(TraceparentHeader, sc.Traceparent())
+	}
+}
+
+var global atomic.Pointer[Provider]
+
+// SetProvider makes p the provider Start records spans with, nil switches tracing off
+func SetProvider(p *Provider) {
+	global.Store(p)
+}
+
+// Start begins an internal span, a child of the span in ctx if there is one. It must be ended, and the returned context carries it to the calls it makes.
+func Start(ctx context.Context, name string, attrs ...Attr) (context.Context, *Span) {
+	return StartWithKind(ctx, KindInternal, name, attrs...)
+}
+
+// StartWithKind is Start for spans that serve a request (KindServer) or call another service (KindClient)
+func StartWithKind(ctx context.Context, kind Kind, name string, attrs ...Attr) (context.Context, *Span) {
+	p := global.Load()
+	if p == nil {
+		return ctx, nil
+	}
+	span := p.newSpan(SpanContextFromContext(ctx), kind, name, attrs)
+	return context.WithValue(ctx, spanKey{}, span), span
+}
@@ -0,0 +1,142 @@
+package tracing_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"louder/pkg/tracing"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	const valid = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	tests := map[string]struct {
+		header      string
+		wantErr     bool
+		wantSampled bool
+	}{
+		"sampled":                    {header: valid, wantSampled: true},
+		"not sampled":                {header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00"},
+		"future version with extras": {header: "cc-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-what-the-future-holds", wantSampled: true},
+		"version 00 with extras":     {header: valid + "-extra", wantErr: true},
+		"version ff":                 {header: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", wantErr: true},
+		"zero trace ID":              {header: "00-00000000000000000000000000000000-00f067aa0ba902b7-01", wantErr: true},
+		"zero span ID":               {header: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", wantErr: true},
+		"upper case hex":             {header: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", wantErr: true},
+		"not hex":                    {header: "00-4bf92f3577b34da6a3ce929d0e0e473z-00f067aa0ba902b7-01", wantErr: true},
+		"wrong separator":            {header: "00_4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", wantErr: true},
+		"too short":                  {header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7", wantErr: true},
+		"empty":                      {header: "", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sc, err := tracing.ParseTraceparent(tc.header)
+			if tc.wantErr {
+				if !errors.Is(err, tracing.ErrInvalidTraceparent) {
+					t.Fatalf("err = %v, want ErrInvalidTraceparent", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if sc.Sampled != tc.wantSampled || !sc.Remote {
+				t.Errorf("sampled = %v, remote = %v, want %v and true", sc.Sampled, sc.Remote, tc.wantSampled)
+			}
+			if tc.header == valid && sc.Traceparent() != valid {
+				t.Errorf("round trip = %q, want %q", sc.Traceparent(), valid)
+			}
+		})
+	}
+}
+
+func TestSpansPropagateAndExportAsOTLP(t *testing.T) {
+	var received map[string]any
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &received); err != nil {
+			t.Errorf("collector got invalid JSON: %v", err)
+		}
+	}))
+	defer collector.Close()
+
+	provider := tracing.NewProvider(tracing.NewOTLPExporter(collector.URL+"/v1/traces", nil), tracing.Options{
+		Resource:    []tracing.Attr{tracing.String("service.name", "louder-test")},
+		SampleRatio: 0, // ignored, the incoming trace is sampled
+	})
+	tracing.SetProvider(provider)
+	defer tracing.SetProvider(nil)
+
+	incoming := http.Header{}
+	incoming.Set(tracing.TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx := tracing.Extract(context.Background(), incoming)
+
+	ctx, server := tracing.StartWithKind(ctx, tracing.KindServer, "GET /things/{id}", tracing.Int("http.response.status_code", 200))
+	_, client := tracing.StartWithKind(ctx, tracing.KindClient, "GET", tracing.Bool("retried", false))
+	client.RecordError(errors.New("boom"))
+
+	outgoing := http.Header{}
+	tracing.Inject(ctx, outgoing)
+	if got, want := outgoing.Get(tracing.TraceparentHeader), "00-4bf92f3577b34da6a3ce929d0e0e4736-"+server.SpanContext().SpanID.String()+"-01"; got != want {
+		t.Errorf("injected traceparent = %q, want %q", got, want)
+	}
+
+	client.End()
+	server.End()
+	if err := provider.Shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	var traces struct {
+		ResourceSpans []struct {
+			Resource struct {
+				Attributes []struct {
+					Key   string
+					Value map[string]any
+				}
+			}
+			ScopeSpans []struct {
+				Spans []struct {
+					TraceID, SpanID, ParentSpanID, Name string
+					Kind                                int
+					Status                              struct{ Code int }
+				}
+			}
+		}
+	}
+	raw, _ := json.Marshal(received)
+	json.Unmarshal(raw, &traces)
+
+	if len(traces.ResourceSpans) != 1 || traces.ResourceSpans[0].Resource.Attributes[0].Value["stringValue"] != "louder-test" {
+		t.Fatalf("unexpected resource in %s", raw)
+	}
+	spans := traces.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatalf("exported %d spans, want 2: %s", len(spans), raw)
+	}
+	clientSpan, serverSpan := spans[0], spans[1]
+	if serverSpan.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || serverSpan.ParentSpanID != "00f067aa0ba902b7" || serverSpan.Kind != int(tracing.KindServer) {
+		t.Errorf("server span should continue the remote trace: %+v", serverSpan)
+	}
+	if clientSpan.ParentSpanID != serverSpan.SpanID || clientSpan.Status.Code != int(tracing.StatusError) {
+		t.Errorf("client span should be the server's failed child: %+v", clientSpan)
+	}
+}
+
+func TestTracingOff(t *testing.T) {
+	ctx, span := tracing.Start(context.Background(), "nothing")
+	span.SetAttributes(tracing.String("k", "v"))
+	span.RecordError(errors.New("ignored"))
+	span.End()
+
+	header := http.Header{}
+	tracing.Inject(ctx, header)
+	if span != nil || header.Get(tracing.TraceparentHeader) != "" {
+		t.Errorf("without a provider spans must be nil and nothing injected")
+	}
+}
@@ -13,11 +13,26 @@ type UTCTime struct {
 
 var (
 	ErrZeroValueTime = errors.New("Given time cannot be zero / null")
+	ErrParseUTCTime  = errors.New("Given time is not in a supported format (YYYY-MM-DD or RFC3339)")
 )
 
-// NewUTCTime create a new UTCtime instance given a time.Time
+// NewUTCTime create a new UTCtime instance given a time.Time, whatever its location it is normalised to UTC
 func NewUTCTime(t time.Time) UTCTime {
-	return UTCTime{Time: t}
+	return UTCTime{Time: t.UTC()}
+}
+
+// ParseUTCTime parses either a plain date (YYYY-MM-DD, taken as midnight UTC) or a full RFC3339 timestamp with any offset, and normalises it to UTC
+func ParseUTCTime(s string) (UTCTime, error) {
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return NewUTCTime(t), nil
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return UTCTime{}, fmt.Errorf("%w: %q", ErrParseUTCTime, s)
+	}
+
+	return NewUTCTime(t), nil
 }
 
 // valuer / scanner interfaces
@@ -38,8 +53,17 @@ func (t *UTCTime) Scan(value any) error {
 		t.Time = time.Time{}
 		return nil
 	}
-	if scannedTime, ok := value.(time.Time); ok { // TODO
-		t.Time = scannedTime
+	switch v := value.(type) {
+	case time.Time:
+		t.Time = v.UTC()
+		return nil
+	case string:
+		// columns not declared as DATETIME come back as the text we stored
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return fmt.Errorf("cannot parse UTCTime from %q: %w", v, err)
+		}
+		t.Time = parsed.UTC()
 		return nil
 	}
 	return fmt.Errorf("unsupported type for UTCTime: %T", value)